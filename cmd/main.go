@@ -1,14 +1,29 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/sawitpro/UserService/common/i18n"
 	"github.com/sawitpro/UserService/generated"
 	"github.com/sawitpro/UserService/handler"
+	"github.com/sawitpro/UserService/helper/breach"
+	"github.com/sawitpro/UserService/helper/breach/rangefile"
+	"github.com/sawitpro/UserService/helper/hasher"
+	"github.com/sawitpro/UserService/helper/hasher/argon2id"
 	"github.com/sawitpro/UserService/helper/hasher/bcrypt"
+	"github.com/sawitpro/UserService/helper/hasher/composite"
+	"github.com/sawitpro/UserService/helper/notifier"
+	"github.com/sawitpro/UserService/helper/notifier/console"
+	"github.com/sawitpro/UserService/helper/webauthn"
+	"github.com/sawitpro/UserService/repository"
 	"github.com/sawitpro/UserService/repository/postgres"
+	svcIface "github.com/sawitpro/UserService/service"
 	"github.com/sawitpro/UserService/service/service"
 
 	"github.com/labstack/echo/v4"
@@ -16,27 +31,45 @@ import (
 
 func main() {
 	e := echo.New()
-	var server generated.ServerInterface = newServer()
+	e.HTTPErrorHandler = handler.HTTPErrorHandler
+	e.IPExtractor = newIPExtractor()
+
+	dbDsn, ok := os.LookupEnv("DATABASE_URL")
+	if !ok {
+		panic("DATABASE_URL env not set")
+	}
+
+	repository := postgres.NewClient(postgres.ClientOptions{
+		DSN: dbDsn,
+	})
+
+	svc := newService(repository)
+
+	var server generated.ServerInterface = handler.NewServer(handler.ServerOpts{
+		Service: svc,
+	})
 
 	generated.RegisterHandlers(e, server)
 
-	mw, err := handler.InitMiddleware()
+	mw, err := handler.InitMiddleware(handler.MiddlewareOpts{
+		RevocationStore:  repository,
+		UserStatusReader: repository,
+		SessionStore:     repository,
+		DefaultLanguage:  newDefaultLanguage(),
+	})
 	if err != nil {
 		panic(fmt.Sprintf("error creating middleware, err = %s", err.Error()))
 	}
 
 	e.Use(mw...)
 
+	interval := time.Duration(envUint("ACCOUNT_ERASURE_INTERVAL_MINUTES", 60, 32)) * time.Minute
+	go runErasureWorker(e.Logger, svc, interval)
+
 	e.Logger.Fatal(e.Start(":1323"))
 }
 
-func newServer() generated.ServerInterface {
-	dbDsn, ok := os.LookupEnv("DATABASE_URL")
-
-	if !ok {
-		panic("DATABASE_URL env not set")
-	}
-
+func newService(repo repository.RepositoryInterface) svcIface.ServiceInterface {
 	TZ, ok := os.LookupEnv("TZ")
 	if !ok {
 		TZ = "Asia/Bangkok"
@@ -44,17 +77,302 @@ func newServer() generated.ServerInterface {
 	os.Setenv("TZ", TZ)
 	time.LoadLocation(TZ)
 
-	repository := postgres.NewClient(postgres.ClientOptions{
-		DSN: dbDsn,
+	return service.NewService(service.ServiceOpts{
+		Repository:          repo,
+		Hasher:              newHasher(),
+		RevocationStore:     repo,
+		Notifier:            newNotifier(),
+		Lockout:             newLockoutPolicy(),
+		PasswordPolicy:      newPasswordPolicy(),
+		BreachChecker:       newBreachChecker(),
+		MinBreachCount:      int64(envUint("BREACHED_PASSWORDS_MIN_COUNT", 1, 63)),
+		DeletionGracePeriod: time.Duration(envUint("ACCOUNT_DELETION_GRACE_DAYS", 30, 16)) * 24 * time.Hour,
+		SessionLimit:        newSessionLimitPolicy(),
+		LoginAnomaly:        newLoginAnomalyPolicy(),
+		RelyingParty:        newRelyingParty(),
 	})
+}
+
+// runErasureWorker anonymizes the accounts whose deletion grace period has
+// ended, once at startup and then at every interval.
+func runErasureWorker(logger echo.Logger, svc svcIface.ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	svc := service.NewService(service.ServiceOpts{
-		Repository: repository,
-		Hasher:     bcrypt.NewHasher(),
+	for {
+		erased, errSvc := svc.EraseDueAccounts(context.Background())
+		if errSvc != nil {
+			logger.Errorf("error erasing accounts, err = %s", errSvc.GetErrorMessage())
+		}
+		if erased > 0 {
+			logger.Infof("erased %d accounts", erased)
+		}
+
+		<-ticker.C
+	}
+}
+
+// newIPExtractor takes the client IP from X-Forwarded-For only when the
+// request came through one of the proxies in TRUSTED_PROXIES, a comma
+// separated list of CIDRs. Without it the peer address is used, so clients
+// cannot pick the IP recorded in activity logs and used by login throttling.
+func newIPExtractor() echo.IPExtractor {
+	value, ok := os.LookupEnv("TRUSTED_PROXIES")
+	if !ok || strings.TrimSpace(value) == "" {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range strings.Split(value, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			panic(fmt.Sprintf("invalid TRUSTED_PROXIES env %q, err = %s", value, err.Error()))
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// newHasher hashes new passwords with argon2id and still verifies bcrypt
+// hashes, which are replaced on the user's next login. ARGON2_MEMORY_KIB,
+// ARGON2_TIME and ARGON2_PARALLELISM override the default cost.
+func newHasher() hasher.PasswordHasher {
+	params := argon2id.DefaultParams()
+	params.Memory = uint32(envUint("ARGON2_MEMORY_KIB", uint64(params.Memory), 32))
+	params.Time = uint32(envUint("ARGON2_TIME", uint64(params.Time), 32))
+	params.Parallelism = uint8(envUint("ARGON2_PARALLELISM", uint64(params.Parallelism), 8))
+
+	return composite.NewHasher(composite.HasherOpts{
+		Primary: argon2id.Algorithm,
+		Hashers: map[string]hasher.PasswordHasher{
+			argon2id.Algorithm: argon2id.NewHasher(params),
+			bcrypt.Algorithm:   bcrypt.NewHasher(),
+		},
 	})
+}
 
-	opts := handler.ServerOpts{
-		Service: svc,
+func envUint(key string, fallback uint64, bitSize int) uint64 {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+
+	n, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil || n == 0 {
+		panic(fmt.Sprintf("invalid %s env %q, must be a positive integer", key, value))
+	}
+
+	return n
+}
+
+func envBool(key string, fallback bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s env %q, must be true or false", key, value))
+	}
+
+	return b
+}
+
+func newDefaultLanguage() i18n.Language {
+	tag, ok := os.LookupEnv("DEFAULT_LANGUAGE")
+	if !ok || tag == "" {
+		return i18n.English
+	}
+
+	lang, ok := i18n.ParseLanguage(tag)
+	if !ok {
+		panic(fmt.Sprintf("unsupported DEFAULT_LANGUAGE %q", tag))
 	}
-	return handler.NewServer(opts)
+
+	return lang
+}
+
+// newPasswordPolicy uses the default rules, with the length limits read
+// from PASSWORD_MIN_LENGTH and PASSWORD_MAX_LENGTH, the required character
+// classes from PASSWORD_REQUIRE_UPPER, PASSWORD_REQUIRE_LOWER,
+// PASSWORD_REQUIRE_DIGIT and PASSWORD_REQUIRE_SYMBOL, and the banned
+// password list from BANNED_PASSWORDS_FILE when they are set.
+func newPasswordPolicy() service.PasswordPolicy {
+	policy := service.DefaultPasswordPolicy()
+	policy.MinLength = int(envUint("PASSWORD_MIN_LENGTH", uint64(policy.MinLength), 16))
+	policy.MaxLength = int(envUint("PASSWORD_MAX_LENGTH", uint64(policy.MaxLength), 16))
+	if policy.MinLength > policy.MaxLength {
+		panic(fmt.Sprintf("invalid PASSWORD_MIN_LENGTH %d, must not exceed PASSWORD_MAX_LENGTH %d", policy.MinLength, policy.MaxLength))
+	}
+	policy.RequireUpper = envBool("PASSWORD_REQUIRE_UPPER", policy.RequireUpper)
+	policy.RequireLower = envBool("PASSWORD_REQUIRE_LOWER", policy.RequireLower)
+	policy.RequireDigit = envBool("PASSWORD_REQUIRE_DIGIT", policy.RequireDigit)
+	policy.RequireSymbol = envBool("PASSWORD_REQUIRE_SYMBOL", policy.RequireSymbol)
+
+	path, ok := os.LookupEnv("BANNED_PASSWORDS_FILE")
+	if !ok || path == "" {
+		return policy
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		panic(fmt.Sprintf("error opening banned passwords file, err = %s", err.Error()))
+	}
+	defer f.Close()
+
+	policy.BannedPasswords, err = service.ParseBannedPasswords(f)
+	if err != nil {
+		panic(fmt.Sprintf("error reading banned passwords file, err = %s", err.Error()))
+	}
+
+	return policy
+}
+
+// newLockoutPolicy uses the default rules, with an account locked after
+// LOCKOUT_THRESHOLD consecutive failed logins for LOCKOUT_BASE_SECONDS,
+// doubling up to LOCKOUT_MAX_SECONDS, and a client IP refused after
+// LOGIN_IP_THRESHOLD failed logins within LOGIN_IP_WINDOW_MINUTES.
+func newLockoutPolicy() service.LockoutPolicy {
+	policy := service.DefaultLockoutPolicy()
+	policy.Threshold = int64(envUint("LOCKOUT_THRESHOLD", uint64(policy.Threshold), 32))
+	policy.BaseDuration = time.Duration(envUint("LOCKOUT_BASE_SECONDS", uint64(policy.BaseDuration/time.Second), 32)) * time.Second
+	policy.MaxDuration = time.Duration(envUint("LOCKOUT_MAX_SECONDS", uint64(policy.MaxDuration/time.Second), 32)) * time.Second
+	if policy.BaseDuration > policy.MaxDuration {
+		panic(fmt.Sprintf("invalid LOCKOUT_BASE_SECONDS %d, must not exceed LOCKOUT_MAX_SECONDS %d", policy.BaseDuration/time.Second, policy.MaxDuration/time.Second))
+	}
+	policy.IPThreshold = int64(envUint("LOGIN_IP_THRESHOLD", uint64(policy.IPThreshold), 32))
+	policy.IPWindow = time.Duration(envUint("LOGIN_IP_WINDOW_MINUTES", uint64(policy.IPWindow/time.Minute), 16)) * time.Minute
+
+	return policy
+}
+
+// newSessionLimitPolicy caps the sessions of every user at MAX_SESSIONS, and
+// of the holders of a role at the limits in MAX_SESSIONS_BY_ROLE, a comma
+// separated list of role=limit pairs where a zero limit exempts the role.
+// SESSION_LIMIT_ACTION picks what a login over the limit does: evict_oldest,
+// the default, or reject. Without limits sessions are not capped.
+func newSessionLimitPolicy() service.SessionLimitPolicy {
+	policy := service.SessionLimitPolicy{
+		MaxSessions:     int(envUint("MAX_SESSIONS", 0, 16)),
+		RoleMaxSessions: envIntPairs("MAX_SESSIONS_BY_ROLE"),
+		Action:          service.EvictOldestSession,
+	}
+
+	action, ok := os.LookupEnv("SESSION_LIMIT_ACTION")
+	if ok && action != "" {
+		policy.Action = service.SessionLimitAction(action)
+		if policy.Action != service.EvictOldestSession && policy.Action != service.RejectNewSession {
+			panic(fmt.Sprintf("invalid SESSION_LIMIT_ACTION env %q, must be evict_oldest or reject", action))
+		}
+	}
+
+	return policy
+}
+
+// newLoginAnomalyPolicy uses the default rules, with the threshold of a
+// suspicious login read from LOGIN_ANOMALY_THRESHOLD and the score of each
+// rule from LOGIN_ANOMALY_SCORES, comma separated rule=score pairs such as
+// new_network=1,new_device=2,unusual_hour=1. A zero score turns a rule off.
+func newLoginAnomalyPolicy() service.LoginAnomalyPolicy {
+	policy := service.DefaultLoginAnomalyPolicy()
+	policy.Threshold = int(envUint("LOGIN_ANOMALY_THRESHOLD", uint64(policy.Threshold), 16))
+
+	for rule, score := range envIntPairs("LOGIN_ANOMALY_SCORES") {
+		switch rule {
+		case "new_network":
+			policy.NewNetworkScore = score
+		case "new_device":
+			policy.NewDeviceScore = score
+		case "unusual_hour":
+			policy.UnusualHourScore = score
+		default:
+			panic(fmt.Sprintf("unknown rule %q in LOGIN_ANOMALY_SCORES env", rule))
+		}
+	}
+
+	return policy
+}
+
+// envIntPairs reads a comma separated list of name=number pairs, returning
+// nil when the variable is not set.
+func envIntPairs(key string) map[string]int {
+	value, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	pairs := map[string]int{}
+	for _, pair := range strings.Split(value, ",") {
+		name, number, found := strings.Cut(strings.TrimSpace(pair), "=")
+		n, err := strconv.ParseUint(number, 10, 16)
+		if !found || name == "" || err != nil {
+			panic(fmt.Sprintf("invalid %s env %q, must be name=number pairs", key, value))
+		}
+		pairs[name] = int(n)
+	}
+
+	return pairs
+}
+
+// newRelyingParty scopes passkeys to the domain in WEBAUTHN_RP_ID, shown to
+// users as WEBAUTHN_RP_NAME. WEBAUTHN_ORIGINS is a comma separated list of
+// the origins the web and app clients run on, which must belong to that
+// domain. The defaults suit a client served from http://localhost:3000.
+func newRelyingParty() webauthn.RelyingParty {
+	rp := webauthn.RelyingParty{
+		ID:      "localhost",
+		Name:    "SawitPro",
+		Origins: []string{"http://localhost:3000"},
+	}
+
+	if id, ok := os.LookupEnv("WEBAUTHN_RP_ID"); ok && id != "" {
+		rp.ID = id
+	}
+
+	if name, ok := os.LookupEnv("WEBAUTHN_RP_NAME"); ok && name != "" {
+		rp.Name = name
+	}
+
+	if value, ok := os.LookupEnv("WEBAUTHN_ORIGINS"); ok && strings.TrimSpace(value) != "" {
+		rp.Origins = nil
+		for _, origin := range strings.Split(value, ",") {
+			rp.Origins = append(rp.Origins, strings.TrimSpace(origin))
+		}
+	}
+
+	return rp
+}
+
+// newBreachChecker reads the Have I Been Pwned range files downloaded to
+// BREACHED_PASSWORDS_DIR. Without it breached passwords are not checked.
+func newBreachChecker() breach.Checker {
+	dir, ok := os.LookupEnv("BREACHED_PASSWORDS_DIR")
+	if !ok || dir == "" {
+		return nil
+	}
+
+	return rangefile.NewChecker(os.DirFS(dir))
+}
+
+// newNotifier writes outgoing messages to NOTIFIER_OUTPUT_FILE when it is
+// set, or to stdout otherwise. Swap it for an SMS or WhatsApp gateway in
+// production.
+func newNotifier() notifier.Notifier {
+	path, ok := os.LookupEnv("NOTIFIER_OUTPUT_FILE")
+	if !ok || path == "" {
+		return console.NewNotifier(os.Stdout)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		panic(fmt.Sprintf("error opening notifier output file, err = %s", err.Error()))
+	}
+
+	return console.NewNotifier(f)
 }
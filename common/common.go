@@ -2,8 +2,49 @@ package common
 
 type ErrorType string
 
+type ErrorCode int64
+
 const (
-	LOGIN_ACTIVITY  = "login"
-	USER_ID_CTX_KEY = "userID"
-	TX_KEY          = "tx"
+	LOGIN_ACTIVITY                      = "login"
+	LOGIN_FAILED_ACTIVITY               = "login_failed"
+	ACCOUNT_UNLOCKED_ACTIVITY           = "account_unlocked"
+	LOGOUT_ACTIVITY                     = "logout"
+	PASSWORD_CHANGE_ACTIVITY            = "password_change"
+	REFRESH_TOKEN_REUSE_ACTIVITY        = "token_reuse"
+	PASSWORD_RESET_REQUEST_ACTIVITY     = "password_reset_request"
+	PASSWORD_RESET_ACTIVITY             = "password_reset"
+	PHONE_VERIFIED_ACTIVITY             = "phone_verified"
+	MFA_ENABLED_ACTIVITY                = "mfa_enabled"
+	RECOVERY_CODE_USED_ACTIVITY         = "recovery_code_used"
+	ROLE_ASSIGNED_ACTIVITY              = "role_assigned"
+	ROLE_REMOVED_ACTIVITY               = "role_removed"
+	ACCOUNT_SUSPENDED_ACTIVITY          = "account_suspended"
+	ACCOUNT_UNSUSPENDED_ACTIVITY        = "account_unsuspended"
+	ACCOUNT_DEACTIVATED_ACTIVITY        = "account_deactivated"
+	ACCOUNT_DELETION_REQUESTED_ACTIVITY = "account_deletion_requested"
+	ACCOUNT_DELETION_CANCELLED_ACTIVITY = "account_deletion_cancelled"
+	ACCOUNT_ERASED_ACTIVITY             = "account_erased"
+	DATA_EXPORT_ACTIVITY                = "export"
+	SESSION_REVOKED_ACTIVITY            = "session_revoked"
+	SESSION_EVICTED_ACTIVITY            = "session_evicted"
+	SESSION_LIMIT_REJECTED_ACTIVITY     = "session_limit_rejected"
+	SUSPICIOUS_LOGIN_ACTIVITY           = "suspicious_login"
+	LOGIN_CODE_REQUEST_ACTIVITY         = "login_code_request"
+	PASSKEY_REGISTERED_ACTIVITY         = "passkey_registered"
+	ACTIVE_STATUS                       = "active"
+	SUSPENDED_STATUS                    = "suspended"
+	DEACTIVATED_STATUS                  = "deactivated"
+	PENDING_DELETION_STATUS             = "pending_deletion"
+	DELETED_STATUS                      = "deleted"
+	PASSWORD_RESET_PURPOSE              = "password_reset"
+	PHONE_VERIFICATION_PURPOSE          = "phone_verification"
+	LOGIN_PURPOSE                       = "login"
+	USER_ID_CTX_KEY                     = "userID"
+	TOKEN_ID_CTX_KEY                    = "tokenID"
+	TOKEN_EXPIRES_AT_CTX_KEY            = "tokenExpiresAt"
+	SESSION_ID_CTX_KEY                  = "sessionID"
+	PERMISSIONS_CTX_KEY                 = "permissions"
+	REQUEST_INFO_CTX_KEY                = "requestInfo"
+	LANGUAGE_CTX_KEY                    = "language"
+	TX_KEY                              = "tx"
 )
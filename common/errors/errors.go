@@ -2,31 +2,146 @@ package errors
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sawitpro/UserService/common"
 )
 
 type Error struct {
+	Code    common.ErrorCode
 	Message string
 	Type    common.ErrorType
+	Args    []interface{}
 }
 
 const (
-	SystemErrorType     common.ErrorType = "SystemErrorType"
-	BadRequestErrorType common.ErrorType = "BadRequestErrorType"
-	ConflictErrorType   common.ErrorType = "ConflictedErrorType"
+	SystemErrorType          common.ErrorType = "SystemErrorType"
+	BadRequestErrorType      common.ErrorType = "BadRequestErrorType"
+	ConflictErrorType        common.ErrorType = "ConflictedErrorType"
+	UnauthorizedErrorType    common.ErrorType = "UnauthorizedErrorType"
+	TooManyRequestsErrorType common.ErrorType = "TooManyRequestsErrorType"
+	LockedErrorType          common.ErrorType = "LockedErrorType"
+	ForbiddenErrorType       common.ErrorType = "ForbiddenErrorType"
+	NotFoundErrorType        common.ErrorType = "NotFoundErrorType"
 )
 
+// Error codes are part of the API contract and must never be renumbered.
+// 1xxx are generic codes used when nothing more specific applies, 2xxx
+// identify a single business error.
 const (
-	WrongPhonePasswordErrorMessage string = "password or phone number is incorrect."
-	phoneAlreadyUsedErrorMessage   string = "phone number %s already used."
-	UserDataNotFoundErrorMessage   string = "user data not found."
+	SystemErrorCode           common.ErrorCode = 1000
+	ValidationErrorCode       common.ErrorCode = 1001
+	UnauthorizedErrorCode     common.ErrorCode = 1002
+	ForbiddenErrorCode        common.ErrorCode = 1003
+	NotFoundErrorCode         common.ErrorCode = 1004
+	MethodNotAllowedErrorCode common.ErrorCode = 1005
+	ConflictErrorCode         common.ErrorCode = 1006
+	TooManyRequestsErrorCode  common.ErrorCode = 1007
+	LockedErrorCode           common.ErrorCode = 1008
+
+	PhoneAlreadyUsedErrorCode            common.ErrorCode = 2001
+	WrongPhonePasswordErrorCode          common.ErrorCode = 2002
+	UserDataNotFoundErrorCode            common.ErrorCode = 2003
+	InvalidRefreshTokenErrorCode         common.ErrorCode = 2004
+	RefreshTokenReusedErrorCode          common.ErrorCode = 2005
+	WrongCurrentPasswordErrorCode        common.ErrorCode = 2006
+	SamePasswordErrorCode                common.ErrorCode = 2007
+	PasswordPolicyErrorCode              common.ErrorCode = 2008
+	BreachedPasswordErrorCode            common.ErrorCode = 2009
+	InvalidVerificationCodeErrorCode     common.ErrorCode = 2010
+	TooManyVerificationAttemptsErrorCode common.ErrorCode = 2011
+	TooManyVerificationCodesErrorCode    common.ErrorCode = 2012
+	PhoneAlreadyVerifiedErrorCode        common.ErrorCode = 2013
+	TOTPAlreadyEnabledErrorCode          common.ErrorCode = 2014
+	TOTPNotSetUpErrorCode                common.ErrorCode = 2015
+	InvalidTOTPCodeErrorCode             common.ErrorCode = 2016
+	InvalidMFATokenErrorCode             common.ErrorCode = 2017
+	InvalidMFACodeErrorCode              common.ErrorCode = 2018
+	AccountLockedErrorCode               common.ErrorCode = 2019
+	TooManyLoginAttemptsErrorCode        common.ErrorCode = 2020
+	InvalidCursorErrorCode               common.ErrorCode = 2021
+	MissingPermissionErrorCode           common.ErrorCode = 2022
+	RoleNotFoundErrorCode                common.ErrorCode = 2023
+	AccountSuspendedErrorCode            common.ErrorCode = 2024
+	AccountDeactivatedErrorCode          common.ErrorCode = 2025
+	AccountPendingDeletionErrorCode      common.ErrorCode = 2026
+	AccountStatusConflictErrorCode       common.ErrorCode = 2027
+	TooManyDataExportsErrorCode          common.ErrorCode = 2028
+	SessionNotFoundErrorCode             common.ErrorCode = 2029
+	SessionLimitReachedErrorCode         common.ErrorCode = 2030
+	InvalidPasskeyTokenErrorCode         common.ErrorCode = 2031
+	InvalidPasskeyErrorCode              common.ErrorCode = 2032
+	PasskeyAlreadyRegisteredErrorCode    common.ErrorCode = 2033
+)
+
+const (
+	WrongPhonePasswordErrorMessage          string = "password or phone number is incorrect."
+	phoneAlreadyUsedErrorMessage            string = "phone number %s already used."
+	passwordPolicyErrorMessage              string = "password must %s."
+	UserDataNotFoundErrorMessage            string = "user data not found."
+	InvalidRefreshTokenErrorMessage         string = "refresh token is invalid or expired."
+	RefreshTokenReusedErrorMessage          string = "refresh token has already been used, please login again."
+	WrongCurrentPasswordErrorMessage        string = "current password is incorrect."
+	SamePasswordErrorMessage                string = "new password must be different from the current password."
+	InvalidVerificationCodeErrorMessage     string = "verification code is invalid or expired."
+	TooManyVerificationAttemptsErrorMessage string = "too many attempts, please request a new verification code."
+	TooManyVerificationCodesErrorMessage    string = "too many verification codes requested, please try again later."
+	PhoneAlreadyVerifiedErrorMessage        string = "phone number is already verified."
+	TOTPAlreadyEnabledErrorMessage          string = "two-factor authentication is already enabled."
+	TOTPNotSetUpErrorMessage                string = "two-factor authentication setup has not been started."
+	InvalidTOTPCodeErrorMessage             string = "authentication code is invalid."
+	InvalidMFATokenErrorMessage             string = "two-factor login is invalid or expired, please login again."
+	InvalidMFACodeErrorMessage              string = "authentication code is invalid, please login again."
+	BreachedPasswordErrorMessage            string = "password has appeared in a known data breach, please choose a different one."
+	AccountLockedErrorMessage               string = "account is temporarily locked after too many failed logins, please try again later or reset your password."
+	TooManyLoginAttemptsErrorMessage        string = "too many failed login attempts, please try again later."
+	InvalidCursorErrorMessage               string = "pagination cursor is invalid."
+	missingPermissionErrorMessage           string = "permission %s is required."
+	RoleNotFoundErrorMessage                string = "role not found."
+	AccountSuspendedErrorMessage            string = "account is suspended, please contact support."
+	AccountDeactivatedErrorMessage          string = "account is deactivated."
+	AccountPendingDeletionErrorMessage      string = "account is scheduled for deletion."
+	AccountStatusConflictErrorMessage       string = "account status does not allow this change."
+	TooManyDataExportsErrorMessage          string = "too many data exports requested, please try again later."
+	SessionNotFoundErrorMessage             string = "session not found."
+	SessionLimitReachedErrorMessage         string = "too many active sessions, please log out on another device first."
+	InvalidPasskeyTokenErrorMessage         string = "passkey request is invalid or expired, please try again."
+	InvalidPasskeyErrorMessage              string = "passkey could not be verified."
+	PasskeyAlreadyRegisteredErrorMessage    string = "passkey is already registered."
 )
 
+// defaultCodes gives errors created with NewError the generic code of their
+// type.
+var defaultCodes = map[common.ErrorType]common.ErrorCode{
+	SystemErrorType:          SystemErrorCode,
+	BadRequestErrorType:      ValidationErrorCode,
+	ConflictErrorType:        ConflictErrorCode,
+	UnauthorizedErrorType:    UnauthorizedErrorCode,
+	TooManyRequestsErrorType: TooManyRequestsErrorCode,
+	LockedErrorType:          LockedErrorCode,
+	ForbiddenErrorType:       ForbiddenErrorCode,
+	NotFoundErrorType:        NotFoundErrorCode,
+}
+
+// NewError creates an error carrying the generic code of its type, such as
+// a wrapped system error. Business errors use NewErrorWithCode.
 func NewError(message string, errorType common.ErrorType) common.Error {
+	code, ok := defaultCodes[errorType]
+	if !ok {
+		code = SystemErrorCode
+	}
+
+	return NewErrorWithCode(message, errorType, code)
+}
+
+// NewErrorWithCode creates an error with a specific code. Args are the
+// values formatted into the message, kept so it can be translated.
+func NewErrorWithCode(message string, errorType common.ErrorType, code common.ErrorCode, args ...interface{}) common.Error {
 	return &Error{
+		Code:    code,
 		Message: message,
 		Type:    errorType,
+		Args:    args,
 	}
 }
 
@@ -34,10 +149,42 @@ func (e *Error) GetErrorType() common.ErrorType {
 	return e.Type
 }
 
+func (e *Error) GetErrorCode() common.ErrorCode {
+	return e.Code
+}
+
 func (e *Error) GetErrorMessage() string {
 	return e.Message
 }
 
+func (e *Error) GetErrorArgs() []interface{} {
+	return e.Args
+}
+
 func NewPhoneAlreadyUsedErrorMessage(phoneNumber string) string {
 	return fmt.Sprintf(phoneAlreadyUsedErrorMessage, phoneNumber)
 }
+
+func NewPasswordPolicyErrorMessage(failedRules []string) string {
+	return fmt.Sprintf(passwordPolicyErrorMessage, strings.Join(failedRules, ", "))
+}
+
+func NewMissingPermissionErrorMessage(permission string) string {
+	return fmt.Sprintf(missingPermissionErrorMessage, permission)
+}
+
+// NewAccountStatusError returns the error refusing an account that is not
+// active, naming its status. An erased account no longer exists, so tokens
+// still held for it are refused as if the user were unknown.
+func NewAccountStatusError(status string) common.Error {
+	switch status {
+	case common.SUSPENDED_STATUS:
+		return NewErrorWithCode(AccountSuspendedErrorMessage, ForbiddenErrorType, AccountSuspendedErrorCode)
+	case common.DEACTIVATED_STATUS:
+		return NewErrorWithCode(AccountDeactivatedErrorMessage, ForbiddenErrorType, AccountDeactivatedErrorCode)
+	case common.DELETED_STATUS:
+		return NewErrorWithCode(UserDataNotFoundErrorMessage, UnauthorizedErrorType, UserDataNotFoundErrorCode)
+	default:
+		return NewErrorWithCode(AccountPendingDeletionErrorMessage, ForbiddenErrorType, AccountPendingDeletionErrorCode)
+	}
+}
@@ -2,6 +2,8 @@ package errors
 
 import (
 	"testing"
+
+	"github.com/sawitpro/UserService/common"
 )
 
 func TestNewError(t *testing.T) {
@@ -24,3 +26,60 @@ func TestNewPhoneAlreadyUsedErrorMessage(t *testing.T) {
 		t.Errorf("expected error message '%s', got '%s'", expected, result)
 	}
 }
+
+func TestNewPasswordPolicyErrorMessage(t *testing.T) {
+	expected := "password must contain a digit, not contain your name."
+	result := NewPasswordPolicyErrorMessage([]string{"contain a digit", "not contain your name"})
+
+	if result != expected {
+		t.Errorf("expected error message '%s', got '%s'", expected, result)
+	}
+}
+
+func TestNewErrorCodes(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          common.Error
+		expectedCode int64
+	}{
+		{"System error", NewError("db is down", SystemErrorType), int64(SystemErrorCode)},
+		{"Bad request without code", NewError("bad input", BadRequestErrorType), int64(ValidationErrorCode)},
+		{"Unknown type", NewError("odd", "OddErrorType"), int64(SystemErrorCode)},
+		{"Business error", NewErrorWithCode(UserDataNotFoundErrorMessage, BadRequestErrorType, UserDataNotFoundErrorCode), int64(UserDataNotFoundErrorCode)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if int64(tc.err.GetErrorCode()) != tc.expectedCode {
+				t.Errorf("expected error code %d, got %d", tc.expectedCode, tc.err.GetErrorCode())
+			}
+		})
+	}
+}
+
+func TestNewAccountStatusError(t *testing.T) {
+	testCases := []struct {
+		status       string
+		expectedCode common.ErrorCode
+		expectedType common.ErrorType
+	}{
+		{common.SUSPENDED_STATUS, AccountSuspendedErrorCode, ForbiddenErrorType},
+		{common.DEACTIVATED_STATUS, AccountDeactivatedErrorCode, ForbiddenErrorType},
+		{common.PENDING_DELETION_STATUS, AccountPendingDeletionErrorCode, ForbiddenErrorType},
+		{common.DELETED_STATUS, UserDataNotFoundErrorCode, UnauthorizedErrorType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.status, func(t *testing.T) {
+			err := NewAccountStatusError(tc.status)
+
+			if err.GetErrorCode() != tc.expectedCode {
+				t.Errorf("expected error code %d, got %d", tc.expectedCode, err.GetErrorCode())
+			}
+
+			if err.GetErrorType() != tc.expectedType {
+				t.Errorf("expected error type '%s', got '%s'", tc.expectedType, err.GetErrorType())
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package i18n translates the messages sent to API clients. Messages are
+// looked up by error code, or by phrase key for the parts of a message that
+// vary, such as the failed password rules.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+type Language string
+
+const (
+	English    Language = "en-US"
+	Indonesian Language = "id-ID"
+)
+
+// Localizable is implemented by message arguments that must be translated
+// themselves before they are formatted into a message.
+type Localizable interface {
+	Localize(lang Language) string
+}
+
+// Phrase is a translatable fragment of a message.
+type Phrase struct {
+	Key  string
+	Args []interface{}
+}
+
+func NewPhrase(key string, args ...interface{}) Phrase {
+	return Phrase{Key: key, Args: args}
+}
+
+func (p Phrase) Localize(lang Language) string {
+	return format(lookup(phrases[p.Key], lang), localizeArgs(lang, p.Args))
+}
+
+// Phrases is a list of fragments, joined with commas when localized.
+type Phrases []Phrase
+
+func (p Phrases) Strings(lang Language) []string {
+	var result []string
+	for _, phrase := range p {
+		result = append(result, phrase.Localize(lang))
+	}
+	return result
+}
+
+func (p Phrases) Localize(lang Language) string {
+	return strings.Join(p.Strings(lang), ", ")
+}
+
+// Translate returns the message of code in lang, falling back to English
+// when lang has no translation. It reports false for an unknown code.
+func Translate(lang Language, code common.ErrorCode, args ...interface{}) (string, bool) {
+	translations, ok := messages[code]
+	if !ok {
+		return "", false
+	}
+
+	return format(lookup(translations, lang), localizeArgs(lang, args)), true
+}
+
+// ParseLanguage matches a language tag such as "id", "id-ID" or "en-us" to a
+// supported language.
+func ParseLanguage(tag string) (Language, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	primary, _, _ := strings.Cut(tag, "-")
+
+	switch primary {
+	case "id", "in":
+		return Indonesian, true
+	case "en":
+		return English, true
+	}
+
+	return "", false
+}
+
+// ParseAcceptLanguage picks the supported language the client prefers most
+// from an Accept-Language header, or fallback when there is none.
+func ParseAcceptLanguage(header string, fallback Language) Language {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			parsed, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if lang, ok := ParseLanguage(c.tag); ok {
+			return lang
+		}
+	}
+
+	return fallback
+}
+
+func lookup(translations map[Language]string, lang Language) string {
+	if message, ok := translations[lang]; ok {
+		return message
+	}
+	return translations[English]
+}
+
+func localizeArgs(lang Language, args []interface{}) []interface{} {
+	localized := make([]interface{}, len(args))
+	for i, arg := range args {
+		if l, ok := arg.(Localizable); ok {
+			localized[i] = l.Localize(lang)
+		} else {
+			localized[i] = arg
+		}
+	}
+	return localized
+}
+
+func format(message string, args []interface{}) string {
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		fallback Language
+		expected Language
+	}{
+		{name: "Empty", header: "", fallback: English, expected: English},
+		{name: "Empty With Indonesian Fallback", header: "", fallback: Indonesian, expected: Indonesian},
+		{name: "Exact Tag", header: "id-ID", fallback: English, expected: Indonesian},
+		{name: "Primary Tag", header: "id", fallback: English, expected: Indonesian},
+		{name: "Legacy Indonesian Tag", header: "in", fallback: English, expected: Indonesian},
+		{name: "Case Insensitive", header: "EN-gb", fallback: Indonesian, expected: English},
+		{name: "First Supported", header: "fr-FR, id;q=0.8, en;q=0.5", fallback: English, expected: Indonesian},
+		{name: "Highest Quality", header: "id;q=0.4, en-US;q=0.9", fallback: Indonesian, expected: English},
+		{name: "Zero Quality Is Rejected", header: "id;q=0", fallback: English, expected: English},
+		{name: "Invalid Quality Is Skipped", header: "id;q=abc, en", fallback: Indonesian, expected: English},
+		{name: "Unsupported", header: "fr, de", fallback: Indonesian, expected: Indonesian},
+		{name: "Wildcard", header: "*", fallback: Indonesian, expected: Indonesian},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseAcceptLanguage(tc.header, tc.fallback))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     Language
+		code     common.ErrorCode
+		args     []interface{}
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "English",
+			lang:     English,
+			code:     errors.WrongPhonePasswordErrorCode,
+			expected: errors.WrongPhonePasswordErrorMessage,
+			ok:       true,
+		},
+		{
+			name:     "Indonesian",
+			lang:     Indonesian,
+			code:     errors.WrongPhonePasswordErrorCode,
+			expected: "kata sandi atau nomor telepon salah.",
+			ok:       true,
+		},
+		{
+			name:     "Unsupported Language Falls Back To English",
+			lang:     Language("fr-FR"),
+			code:     errors.SamePasswordErrorCode,
+			expected: errors.SamePasswordErrorMessage,
+			ok:       true,
+		},
+		{
+			name:     "Args",
+			lang:     English,
+			code:     errors.PhoneAlreadyUsedErrorCode,
+			args:     []interface{}{"+628232482440"},
+			expected: errors.NewPhoneAlreadyUsedErrorMessage("+628232482440"),
+			ok:       true,
+		},
+		{
+			name:     "Localizable Args",
+			lang:     English,
+			code:     errors.PasswordPolicyErrorCode,
+			args:     []interface{}{Phrases{NewPhrase(PasswordMinLengthPhrase, 6), NewPhrase(PasswordSymbolPhrase)}},
+			expected: errors.NewPasswordPolicyErrorMessage([]string{"be at least 6 characters long", "contain a symbol"}),
+			ok:       true,
+		},
+		{
+			name:     "Localizable Args In Indonesian",
+			lang:     Indonesian,
+			code:     errors.PasswordPolicyErrorCode,
+			args:     []interface{}{Phrases{NewPhrase(PasswordMinLengthPhrase, 6), NewPhrase(PasswordSymbolPhrase)}},
+			expected: "kata sandi harus terdiri dari minimal 6 karakter, mengandung simbol.",
+			ok:       true,
+		},
+		{
+			name: "Unknown Code",
+			lang: English,
+			code: common.ErrorCode(9999),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			message, ok := Translate(tc.lang, tc.code, tc.args...)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, message)
+		})
+	}
+}
+
+func TestCatalogueIsComplete(t *testing.T) {
+	for code, translations := range messages {
+		for _, lang := range []Language{English, Indonesian} {
+			assert.NotEmpty(t, translations[lang], "code %d has no %s message", code, lang)
+		}
+	}
+
+	for key, translations := range phrases {
+		for _, lang := range []Language{English, Indonesian} {
+			assert.NotEmpty(t, translations[lang], "phrase %s has no %s text", key, lang)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package i18n
+
+import (
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+)
+
+var messages = map[common.ErrorCode]map[Language]string{
+	errors.SystemErrorCode: {
+		English:    "something went wrong, please try again later.",
+		Indonesian: "terjadi kesalahan, silakan coba lagi nanti.",
+	},
+	errors.ValidationErrorCode: {
+		English:    "request is invalid.",
+		Indonesian: "permintaan tidak valid.",
+	},
+	errors.UnauthorizedErrorCode: {
+		English:    "authentication is required.",
+		Indonesian: "autentikasi diperlukan.",
+	},
+	errors.ForbiddenErrorCode: {
+		English:    "access is forbidden.",
+		Indonesian: "akses ditolak.",
+	},
+	errors.NotFoundErrorCode: {
+		English:    "resource not found.",
+		Indonesian: "data tidak ditemukan.",
+	},
+	errors.MethodNotAllowedErrorCode: {
+		English:    "method not allowed.",
+		Indonesian: "metode tidak diizinkan.",
+	},
+	errors.ConflictErrorCode: {
+		English:    "request conflicts with existing data.",
+		Indonesian: "permintaan bertentangan dengan data yang sudah ada.",
+	},
+	errors.TooManyRequestsErrorCode: {
+		English:    "too many requests, please try again later.",
+		Indonesian: "terlalu banyak permintaan, silakan coba lagi nanti.",
+	},
+	errors.LockedErrorCode: {
+		English:    "resource is locked.",
+		Indonesian: "data sedang dikunci.",
+	},
+	errors.PhoneAlreadyUsedErrorCode: {
+		English:    "phone number %s already used.",
+		Indonesian: "nomor telepon %s sudah digunakan.",
+	},
+	errors.WrongPhonePasswordErrorCode: {
+		English:    errors.WrongPhonePasswordErrorMessage,
+		Indonesian: "kata sandi atau nomor telepon salah.",
+	},
+	errors.UserDataNotFoundErrorCode: {
+		English:    errors.UserDataNotFoundErrorMessage,
+		Indonesian: "data pengguna tidak ditemukan.",
+	},
+	errors.InvalidRefreshTokenErrorCode: {
+		English:    errors.InvalidRefreshTokenErrorMessage,
+		Indonesian: "refresh token tidak valid atau sudah kedaluwarsa.",
+	},
+	errors.RefreshTokenReusedErrorCode: {
+		English:    errors.RefreshTokenReusedErrorMessage,
+		Indonesian: "refresh token sudah pernah digunakan, silakan login kembali.",
+	},
+	errors.WrongCurrentPasswordErrorCode: {
+		English:    errors.WrongCurrentPasswordErrorMessage,
+		Indonesian: "kata sandi saat ini salah.",
+	},
+	errors.SamePasswordErrorCode: {
+		English:    errors.SamePasswordErrorMessage,
+		Indonesian: "kata sandi baru harus berbeda dari kata sandi saat ini.",
+	},
+	errors.PasswordPolicyErrorCode: {
+		English:    "password must %s.",
+		Indonesian: "kata sandi harus %s.",
+	},
+	errors.BreachedPasswordErrorCode: {
+		English:    errors.BreachedPasswordErrorMessage,
+		Indonesian: "kata sandi pernah muncul dalam kebocoran data, silakan pilih kata sandi lain.",
+	},
+	errors.InvalidVerificationCodeErrorCode: {
+		English:    errors.InvalidVerificationCodeErrorMessage,
+		Indonesian: "kode verifikasi tidak valid atau sudah kedaluwarsa.",
+	},
+	errors.TooManyVerificationAttemptsErrorCode: {
+		English:    errors.TooManyVerificationAttemptsErrorMessage,
+		Indonesian: "terlalu banyak percobaan, silakan minta kode verifikasi baru.",
+	},
+	errors.TooManyVerificationCodesErrorCode: {
+		English:    errors.TooManyVerificationCodesErrorMessage,
+		Indonesian: "terlalu banyak permintaan kode verifikasi, silakan coba lagi nanti.",
+	},
+	errors.PhoneAlreadyVerifiedErrorCode: {
+		English:    errors.PhoneAlreadyVerifiedErrorMessage,
+		Indonesian: "nomor telepon sudah terverifikasi.",
+	},
+	errors.TOTPAlreadyEnabledErrorCode: {
+		English:    errors.TOTPAlreadyEnabledErrorMessage,
+		Indonesian: "autentikasi dua faktor sudah aktif.",
+	},
+	errors.TOTPNotSetUpErrorCode: {
+		English:    errors.TOTPNotSetUpErrorMessage,
+		Indonesian: "pengaturan autentikasi dua faktor belum dimulai.",
+	},
+	errors.InvalidTOTPCodeErrorCode: {
+		English:    errors.InvalidTOTPCodeErrorMessage,
+		Indonesian: "kode autentikasi tidak valid.",
+	},
+	errors.InvalidMFATokenErrorCode: {
+		English:    errors.InvalidMFATokenErrorMessage,
+		Indonesian: "login dua faktor tidak valid atau sudah kedaluwarsa, silakan login kembali.",
+	},
+	errors.InvalidMFACodeErrorCode: {
+		English:    errors.InvalidMFACodeErrorMessage,
+		Indonesian: "kode autentikasi tidak valid, silakan login kembali.",
+	},
+	errors.AccountLockedErrorCode: {
+		English:    errors.AccountLockedErrorMessage,
+		Indonesian: "akun dikunci sementara karena terlalu banyak login yang gagal, silakan coba lagi nanti atau atur ulang kata sandi Anda.",
+	},
+	errors.TooManyLoginAttemptsErrorCode: {
+		English:    errors.TooManyLoginAttemptsErrorMessage,
+		Indonesian: "terlalu banyak percobaan login yang gagal, silakan coba lagi nanti.",
+	},
+	errors.InvalidCursorErrorCode: {
+		English:    errors.InvalidCursorErrorMessage,
+		Indonesian: "kursor halaman tidak valid.",
+	},
+	errors.MissingPermissionErrorCode: {
+		English:    "permission %s is required.",
+		Indonesian: "izin %s diperlukan.",
+	},
+	errors.RoleNotFoundErrorCode: {
+		English:    errors.RoleNotFoundErrorMessage,
+		Indonesian: "peran tidak ditemukan.",
+	},
+	errors.AccountSuspendedErrorCode: {
+		English:    errors.AccountSuspendedErrorMessage,
+		Indonesian: "akun ditangguhkan, silakan hubungi layanan pelanggan.",
+	},
+	errors.AccountDeactivatedErrorCode: {
+		English:    errors.AccountDeactivatedErrorMessage,
+		Indonesian: "akun telah dinonaktifkan.",
+	},
+	errors.AccountPendingDeletionErrorCode: {
+		English:    errors.AccountPendingDeletionErrorMessage,
+		Indonesian: "akun dijadwalkan untuk dihapus.",
+	},
+	errors.AccountStatusConflictErrorCode: {
+		English:    errors.AccountStatusConflictErrorMessage,
+		Indonesian: "status akun tidak memungkinkan perubahan ini.",
+	},
+	errors.TooManyDataExportsErrorCode: {
+		English:    errors.TooManyDataExportsErrorMessage,
+		Indonesian: "terlalu banyak permintaan ekspor data, silakan coba lagi nanti.",
+	},
+	errors.SessionNotFoundErrorCode: {
+		English:    errors.SessionNotFoundErrorMessage,
+		Indonesian: "sesi tidak ditemukan.",
+	},
+	errors.SessionLimitReachedErrorCode: {
+		English:    errors.SessionLimitReachedErrorMessage,
+		Indonesian: "terlalu banyak sesi aktif, silakan keluar dari perangkat lain terlebih dahulu.",
+	},
+	errors.InvalidPasskeyTokenErrorCode: {
+		English:    errors.InvalidPasskeyTokenErrorMessage,
+		Indonesian: "permintaan passkey tidak valid atau kedaluwarsa, silakan coba lagi.",
+	},
+	errors.InvalidPasskeyErrorCode: {
+		English:    errors.InvalidPasskeyErrorMessage,
+		Indonesian: "passkey tidak dapat diverifikasi.",
+	},
+	errors.PasskeyAlreadyRegisteredErrorCode: {
+		English:    errors.PasskeyAlreadyRegisteredErrorMessage,
+		Indonesian: "passkey sudah terdaftar.",
+	},
+}
+
+const (
+	PasswordMinLengthPhrase     = "password_min_length"
+	PasswordMaxLengthPhrase     = "password_max_length"
+	PasswordUpperPhrase         = "password_upper"
+	PasswordLowerPhrase         = "password_lower"
+	PasswordDigitPhrase         = "password_digit"
+	PasswordSymbolPhrase        = "password_symbol"
+	PasswordRepeatedCharsPhrase = "password_repeated_chars"
+	PasswordPhoneNumberPhrase   = "password_phone_number"
+	PasswordFullNamePhrase      = "password_full_name"
+	PasswordBannedPhrase        = "password_banned"
+	InvalidFieldPhrase          = "invalid_field"
+)
+
+var phrases = map[string]map[Language]string{
+	PasswordMinLengthPhrase: {
+		English:    "be at least %d characters long",
+		Indonesian: "terdiri dari minimal %d karakter",
+	},
+	PasswordMaxLengthPhrase: {
+		English:    "be at most %d characters long",
+		Indonesian: "terdiri dari maksimal %d karakter",
+	},
+	PasswordUpperPhrase: {
+		English:    "contain an uppercase letter",
+		Indonesian: "mengandung huruf kapital",
+	},
+	PasswordLowerPhrase: {
+		English:    "contain a lowercase letter",
+		Indonesian: "mengandung huruf kecil",
+	},
+	PasswordDigitPhrase: {
+		English:    "contain a digit",
+		Indonesian: "mengandung angka",
+	},
+	PasswordSymbolPhrase: {
+		English:    "contain a symbol",
+		Indonesian: "mengandung simbol",
+	},
+	PasswordRepeatedCharsPhrase: {
+		English:    "not repeat a character more than %d times in a row",
+		Indonesian: "tidak mengulang karakter yang sama lebih dari %d kali berturut-turut",
+	},
+	PasswordPhoneNumberPhrase: {
+		English:    "not contain your phone number",
+		Indonesian: "tidak mengandung nomor telepon Anda",
+	},
+	PasswordFullNamePhrase: {
+		English:    "not contain your name",
+		Indonesian: "tidak mengandung nama Anda",
+	},
+	PasswordBannedPhrase: {
+		English:    "not be a commonly used password",
+		Indonesian: "bukan kata sandi yang umum digunakan",
+	},
+	InvalidFieldPhrase: {
+		English:    "request is invalid, please check the %s field.",
+		Indonesian: "permintaan tidak valid, periksa kolom %s.",
+	},
+}
@@ -2,5 +2,7 @@ package common
 
 type Error interface {
 	GetErrorType() ErrorType
+	GetErrorCode() ErrorCode
 	GetErrorMessage() string
+	GetErrorArgs() []interface{}
 }
@@ -0,0 +1,21 @@
+package common
+
+import "context"
+
+// RequestInfo describes the client behind a request. The handler layer puts
+// it in the request context, and every activity log written while serving
+// the request records it.
+type RequestInfo struct {
+	IPAddress    string
+	UserAgent    string
+	DeviceFamily string
+	OSFamily     string
+	RequestID    string
+}
+
+// RequestInfoFromContext returns the RequestInfo carried by ctx, or an empty
+// one outside of a request, such as in background jobs.
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(REQUEST_INFO_CTX_KEY).(RequestInfo)
+	return info
+}
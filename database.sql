@@ -13,15 +13,188 @@ CREATE TABLE users (
     full_name VARCHAR(60) NOT NULL,
     hashed_password VARCHAR(255) NOT NULL,
     phone VARCHAR(13) UNIQUE,
+    phone_verified_at TIMESTAMP,
+    pending_phone VARCHAR(13),
     login_count INT NOT NULL DEFAULT 0,
+    failed_login_count INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    -- One of active, suspended, deactivated, pending_deletion or deleted.
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    status_reason TEXT,
+    status_changed_at TIMESTAMP,
+    deletion_scheduled_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT NOW(),
     updated_at TIMESTAMP DEFAULT NOW()
 );
 
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Admin search matches a phone number prefix and a fragment of the name.
+CREATE INDEX users_phone_pattern_idx ON users (phone varchar_pattern_ops);
+CREATE INDEX users_full_name_trgm_idx ON users USING GIN (full_name gin_trgm_ops);
+CREATE INDEX users_created_at_idx ON users (created_at);
+
+-- The erasure worker looks up accounts whose grace period has ended.
+CREATE INDEX users_deletion_scheduled_at_idx ON users (deletion_scheduled_at) WHERE status = 'pending_deletion';
+
+CREATE TABLE roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(32) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE permissions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE role_permissions (
+    role_id INT NOT NULL,
+    permission_id INT NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    FOREIGN KEY (role_id) REFERENCES roles(id),
+    FOREIGN KEY (permission_id) REFERENCES permissions(id)
+);
+
+CREATE TABLE user_roles (
+    user_id INT NOT NULL,
+    role_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, role_id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (role_id) REFERENCES roles(id)
+);
+
+-- Permissions are the names listed in x-permissions in api.yml.
+INSERT INTO roles (name) VALUES ('admin'), ('support');
+INSERT INTO permissions (name) VALUES ('users:read'), ('users:unlock'), ('users:suspend'), ('roles:assign');
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin'
+   OR (r.name = 'support' AND p.name IN ('users:read', 'users:unlock'));
+
 CREATE TABLE user_activity_logs (
     id SERIAL PRIMARY KEY,
     user_id INT NOT NULL,
-    activity_type VARCHAR(13) NOT NULL,
+    activity_type VARCHAR(32) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(512),
+    -- Coarse families parsed from user_agent, such as mobile and Android.
+    device_family VARCHAR(16),
+    os_family VARCHAR(16),
+    request_id VARCHAR(64),
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX user_activity_logs_user_id_created_at_idx ON user_activity_logs (user_id, created_at);
+-- Activity history pages newest first by id, optionally for one activity type.
+CREATE INDEX user_activity_logs_user_id_id_idx ON user_activity_logs (user_id, id DESC);
+CREATE INDEX user_activity_logs_user_id_activity_type_id_idx ON user_activity_logs (user_id, activity_type, id DESC);
+
+-- One row per failed login, whether or not the phone number belongs to an
+-- account, for throttling client IPs. Rows older than the throttling window
+-- are no longer read and can be pruned.
+CREATE TABLE failed_login_attempts (
+    id SERIAL PRIMARY KEY,
+    ip_address VARCHAR(45) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX failed_login_attempts_ip_address_idx ON failed_login_attempts (ip_address, created_at);
+
+-- One row per login. Access tokens carry the session id, and every refresh
+-- token issued from the login belongs to the session.
+CREATE TABLE user_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    device_name VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(512),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT NOW(),
     FOREIGN KEY (user_id) REFERENCES users(id)
 );
+
+CREATE INDEX user_sessions_user_id_idx ON user_sessions (user_id);
+
+CREATE TABLE refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    session_id INT NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (session_id) REFERENCES user_sessions(id)
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX refresh_tokens_session_id_idx ON refresh_tokens (session_id);
+
+CREATE TABLE revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id INT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE user_token_revocations (
+    user_id INT PRIMARY KEY,
+    revoked_before TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE verification_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    purpose VARCHAR(32) NOT NULL,
+    target VARCHAR(13) NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX verification_codes_user_id_purpose_idx ON verification_codes (user_id, purpose, created_at);
+
+CREATE TABLE user_totp (
+    user_id INT PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX recovery_codes_user_id_idx ON recovery_codes (user_id);
+
+CREATE TABLE webauthn_credentials (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    credential_id BYTEA NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    transports VARCHAR(128) NOT NULL DEFAULT '',
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX webauthn_credentials_user_id_idx ON webauthn_credentials (user_id);
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) AssignUserRole(ctx echo.Context, userId generated.UserId, role generated.Role) error {
+	errSvc := s.Service.AssignUserRole(ctx.Request().Context(), service.UserRoleParam{
+		UserID: userId,
+		Role:   role,
+	})
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestAssignUserRole(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expectedStatus       int
+		expectedServiceError common.Error
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:                 "RoleNotFound",
+			expectedStatus:       http.StatusNotFound,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.RoleNotFoundErrorMessage, commonErr.NotFoundErrorType, commonErr.RoleNotFoundErrorCode),
+		},
+		{
+			name:                 "ServiceInternalError",
+			expectedStatus:       http.StatusInternalServerError,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/admin/users/1/roles/support", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockService.EXPECT().AssignUserRole(gomock.Any(), service.UserRoleParam{UserID: 1, Role: "support"}).Return(tc.expectedServiceError)
+
+			mockServer.AssignUserRole(c, 1, "support")
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
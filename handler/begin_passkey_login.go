@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+)
+
+func (s *Server) BeginPasskeyLogin(ctx echo.Context) error {
+	resp, errSvc := s.Service.BeginPasskeyLogin(ctx.Request().Context())
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.PasskeyLoginOptionsResponse{
+		CeremonyToken: resp.CeremonyToken,
+		PublicKey: generated.PublicKeyCredentialRequestOptions{
+			Challenge:        resp.Challenge,
+			RpId:             resp.RelyingPartyID,
+			Timeout:          resp.Timeout.Milliseconds(),
+			UserVerification: generated.Required,
+		},
+	})
+}
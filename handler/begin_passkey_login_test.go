@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestBeginPasskeyLogin(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expectedStatus       int
+		expectedServiceResp  *service.BeginPasskeyLoginResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusOK,
+			expectedServiceResp: &service.BeginPasskeyLoginResponse{
+				CeremonyToken:  "ceremony token",
+				Challenge:      "Y2hhbGxlbmdl",
+				RelyingPartyID: "sawitpro.example",
+				Timeout:        5 * time.Minute,
+			},
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError",
+			expectedStatus:       http.StatusInternalServerError,
+			expectedServiceResp:  nil,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/passkey/login/begin", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockService.EXPECT().BeginPasskeyLogin(gomock.Any()).Return(tc.expectedServiceResp, tc.expectedServiceError)
+
+			mockServer.BeginPasskeyLogin(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body generated.PasskeyLoginOptionsResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, generated.PasskeyLoginOptionsResponse{
+					CeremonyToken: "ceremony token",
+					PublicKey: generated.PublicKeyCredentialRequestOptions{
+						Challenge:        "Y2hhbGxlbmdl",
+						RpId:             "sawitpro.example",
+						Timeout:          300000,
+						UserVerification: generated.Required,
+					},
+				}, body)
+			}
+		})
+	}
+}
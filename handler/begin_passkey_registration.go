@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/base64"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+)
+
+func (s *Server) BeginPasskeyRegistration(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.BeginPasskeyRegistration(ctx.Request().Context(), userID)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	params := make([]generated.PublicKeyCredentialParameters, 0, len(resp.Algorithms))
+	for _, alg := range resp.Algorithms {
+		params = append(params, generated.PublicKeyCredentialParameters{
+			Type: generated.PublicKeyCredentialParametersTypePublicKey,
+			Alg:  alg,
+		})
+	}
+
+	excludeCredentials := make([]generated.PublicKeyCredentialDescriptor, 0, len(resp.ExcludeCredentials))
+	for _, credential := range resp.ExcludeCredentials {
+		descriptor := generated.PublicKeyCredentialDescriptor{
+			Type: generated.PublicKeyCredentialDescriptorTypePublicKey,
+			Id:   base64.RawURLEncoding.EncodeToString(credential.CredentialID),
+		}
+
+		if len(credential.Transports) > 0 {
+			transports := make([]generated.AuthenticatorTransport, 0, len(credential.Transports))
+			for _, transport := range credential.Transports {
+				transports = append(transports, generated.AuthenticatorTransport(transport))
+			}
+			descriptor.Transports = &transports
+		}
+
+		excludeCredentials = append(excludeCredentials, descriptor)
+	}
+
+	return handleSuccessJSON(ctx, &generated.PasskeyRegistrationOptionsResponse{
+		CeremonyToken: resp.CeremonyToken,
+		PublicKey: generated.PublicKeyCredentialCreationOptions{
+			Rp: generated.PublicKeyCredentialRpEntity{
+				Id:   resp.RelyingPartyID,
+				Name: resp.RelyingPartyName,
+			},
+			User: generated.PublicKeyCredentialUserEntity{
+				Id:          base64.RawURLEncoding.EncodeToString(resp.UserHandle),
+				Name:        resp.UserName,
+				DisplayName: resp.UserDisplayName,
+			},
+			Challenge:          resp.Challenge,
+			PubKeyCredParams:   params,
+			Timeout:            resp.Timeout.Milliseconds(),
+			ExcludeCredentials: excludeCredentials,
+			AuthenticatorSelection: generated.AuthenticatorSelectionCriteria{
+				ResidentKey:        generated.AuthenticatorSelectionCriteriaResidentKeyRequired,
+				RequireResidentKey: true,
+				UserVerification:   generated.AuthenticatorSelectionCriteriaUserVerificationRequired,
+			},
+			Attestation: generated.None,
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestBeginPasskeyRegistration(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.BeginPasskeyRegistrationResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:              "Success",
+			userIDCtxValue:    int64(1),
+			expectedStatus:    http.StatusOK,
+			expectServiceCall: true,
+			expectedServiceResp: &service.BeginPasskeyRegistrationResponse{
+				CeremonyToken:      "ceremony token",
+				Challenge:          "Y2hhbGxlbmdl",
+				RelyingPartyID:     "sawitpro.example",
+				RelyingPartyName:   "SawitPro",
+				UserHandle:         []byte{0, 0, 0, 0, 0, 0, 0, 1},
+				UserName:           "+628123456789",
+				UserDisplayName:    "Budi Santoso",
+				Algorithms:         []int64{-7, -257},
+				ExcludeCredentials: []service.PasskeyDescriptor{{CredentialID: []byte{0xfb, 0xff}, Transports: []string{"internal"}}},
+				Timeout:            5 * time.Minute,
+			},
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ForbiddenAccess",
+			userIDCtxValue:       "invalid",
+			expectedStatus:       http.StatusForbidden,
+			expectServiceCall:    false,
+			expectedServiceResp:  nil,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError",
+			userIDCtxValue:       int64(1),
+			expectedStatus:       http.StatusInternalServerError,
+			expectServiceCall:    true,
+			expectedServiceResp:  nil,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/profile/passkeys/registration/begin", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().BeginPasskeyRegistration(gomock.Any(), int64(1)).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.BeginPasskeyRegistration(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body generated.PasskeyRegistrationOptionsResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, "ceremony token", body.CeremonyToken)
+				assert.Equal(t, "AAAAAAAAAAE", body.PublicKey.User.Id)
+				assert.Equal(t, []generated.PublicKeyCredentialParameters{
+					{Type: generated.PublicKeyCredentialParametersTypePublicKey, Alg: -7},
+					{Type: generated.PublicKeyCredentialParametersTypePublicKey, Alg: -257},
+				}, body.PublicKey.PubKeyCredParams)
+				assert.Equal(t, "-_8", body.PublicKey.ExcludeCredentials[0].Id)
+				assert.Equal(t, &[]generated.AuthenticatorTransport{generated.Internal}, body.PublicKey.ExcludeCredentials[0].Transports)
+				assert.Equal(t, int64(300000), body.PublicKey.Timeout)
+				assert.Equal(t, generated.AuthenticatorSelectionCriteriaResidentKeyRequired, body.PublicKey.AuthenticatorSelection.ResidentKey)
+				assert.Equal(t, generated.None, body.PublicKey.Attestation)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) ChangePassword(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	request := &generated.ChangePasswordRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.ChangePassword(ctx.Request().Context(), service.ChangePasswordParam{
+		UserID:          userID,
+		CurrentPassword: request.CurrentPassword,
+		NewPassword:     request.NewPassword,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.LoginResponse{
+		UserID:       resp.UserID,
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
+	})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestChangePassword(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.LoginResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                 "Success",
+			userIDCtxValue:       int64(1),
+			requestBody:          `{"currentPassword": "Maulana1996@", "newPassword": "Maulana1997@"}`,
+			expectedStatus:       http.StatusOK,
+			expectServiceCall:    true,
+			expectedServiceResp:  &service.LoginResponse{UserID: 1, Token: "token", RefreshToken: "refresh"},
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ForbiddenAccess",
+			userIDCtxValue:       "invalid",
+			requestBody:          `{"currentPassword": "Maulana1996@", "newPassword": "Maulana1997@"}`,
+			expectedStatus:       http.StatusForbidden,
+			expectServiceCall:    false,
+			expectedServiceResp:  nil,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "BadRequest JSON",
+			userIDCtxValue:       int64(1),
+			requestBody:          `Invalid JSON`,
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    false,
+			expectedServiceResp:  nil,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError Bad Request",
+			userIDCtxValue:       int64(1),
+			requestBody:          `{"currentPassword": "Maulana1996@", "newPassword": "Maulana1997@"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    true,
+			expectedServiceResp:  nil,
+			expectedServiceError: commonErr.NewError("any", commonErr.BadRequestErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/profile/password", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().ChangePassword(gomock.Any(), service.ChangePasswordParam{
+					UserID:          1,
+					CurrentPassword: "Maulana1996@",
+					NewPassword:     "Maulana1997@",
+				}).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.ChangePassword(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
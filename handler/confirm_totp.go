@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) ConfirmTotp(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	request := &generated.TOTPConfirmRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.ConfirmTOTP(ctx.Request().Context(), service.ConfirmTOTPParam{
+		UserID: userID,
+		Code:   request.Code,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.TOTPConfirmResponse{
+		RecoveryCodes: resp.RecoveryCodes,
+	})
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) DeactivateAccount(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	request := &generated.DeactivateAccountRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	reason := ""
+	if request.Reason != nil {
+		reason = *request.Reason
+	}
+
+	errSvc := s.Service.DeactivateAccount(ctx.Request().Context(), service.DeactivateAccountParam{
+		UserID:   userID,
+		Password: request.Password,
+		Reason:   reason,
+	})
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestDeactivateAccount(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceError common.Error
+	}{
+		{
+			name:              "Success",
+			userIDCtxValue:    int64(1),
+			requestBody:       `{"password": "Maulana1996@", "reason": "moving away"}`,
+			expectedStatus:    http.StatusNoContent,
+			expectServiceCall: true,
+		},
+		{
+			name:              "ForbiddenAccess",
+			userIDCtxValue:    "invalid",
+			requestBody:       `{"password": "Maulana1996@", "reason": "moving away"}`,
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:              "BadRequest JSON",
+			userIDCtxValue:    int64(1),
+			requestBody:       `Invalid JSON`,
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "Wrong Password",
+			userIDCtxValue:       int64(1),
+			requestBody:          `{"password": "Maulana1996@", "reason": "moving away"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.WrongCurrentPasswordErrorMessage, commonErr.BadRequestErrorType, commonErr.WrongCurrentPasswordErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/profile/deactivate", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().DeactivateAccount(gomock.Any(), service.DeactivateAccountParam{
+					UserID:   1,
+					Password: "Maulana1996@",
+					Reason:   "moving away",
+				}).Return(tc.expectedServiceError)
+			}
+
+			mockServer.DeactivateAccount(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
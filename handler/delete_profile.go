@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) DeleteProfile(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	request := &generated.DeleteProfileRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.DeleteProfile(ctx.Request().Context(), service.DeleteProfileParam{
+		UserID:   userID,
+		Password: request.Password,
+	})
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.DeleteProfileResponse{
+		DeleteAfter: resp.DeleteAfter,
+	})
+}
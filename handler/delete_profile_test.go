@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestDeleteProfile(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.DeleteProfileResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                "Success",
+			userIDCtxValue:      int64(1),
+			requestBody:         `{"password": "Maulana1996@"}`,
+			expectedStatus:      http.StatusOK,
+			expectServiceCall:   true,
+			expectedServiceResp: &service.DeleteProfileResponse{DeleteAfter: time.Now().Add(30 * 24 * time.Hour)},
+		},
+		{
+			name:              "ForbiddenAccess",
+			userIDCtxValue:    "invalid",
+			requestBody:       `{"password": "Maulana1996@"}`,
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:              "BadRequest JSON",
+			userIDCtxValue:    int64(1),
+			requestBody:       `Invalid JSON`,
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "Wrong Password",
+			userIDCtxValue:       int64(1),
+			requestBody:          `{"password": "Maulana1996@"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.WrongCurrentPasswordErrorMessage, commonErr.BadRequestErrorType, commonErr.WrongCurrentPasswordErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/profile", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().DeleteProfile(gomock.Any(), service.DeleteProfileParam{
+					UserID:   1,
+					Password: "Maulana1996@",
+				}).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.DeleteProfile(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
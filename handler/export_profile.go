@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"archive/zip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+const userDataExportFile = "export.json"
+
+func (s *Server) ExportProfile(ctx echo.Context, params generated.ExportProfileParams) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	var out io.Writer = &exportResponse{ctx: ctx, contentType: echo.MIMEApplicationJSON, filename: userDataExportFile}
+	var archive *zipEntryWriter
+	if params.Format != nil && *params.Format == generated.Zip {
+		archive = &zipEntryWriter{w: &exportResponse{ctx: ctx, contentType: "application/zip", filename: "export.zip"}, name: userDataExportFile}
+		out = archive
+	}
+
+	writer := &userDataJSONWriter{w: out}
+	errSvc := s.Service.ExportUserData(ctx.Request().Context(), service.ExportUserDataParam{
+		UserID: userID,
+		Writer: writer,
+	})
+	if errSvc != nil {
+		// Once the export has started streaming the status is already sent,
+		// so the error can only be logged.
+		if ctx.Response().Committed {
+			ctx.Logger().Errorf("error exporting user data, err = %s", errSvc.GetErrorMessage())
+			return nil
+		}
+		return handleServiceError(ctx, errSvc)
+	}
+
+	err = writer.Close()
+	if err == nil && archive != nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		ctx.Logger().Errorf("error exporting user data, err = %s", err.Error())
+	}
+
+	return nil
+}
+
+// exportResponse sends the export headers with the first write, so that an
+// error found before anything is written still gets a JSON error response.
+type exportResponse struct {
+	ctx         echo.Context
+	contentType string
+	filename    string
+}
+
+func (r *exportResponse) Write(p []byte) (int, error) {
+	res := r.ctx.Response()
+	if !res.Committed {
+		res.Header().Set(echo.HeaderContentType, r.contentType)
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", r.filename))
+		res.WriteHeader(http.StatusOK)
+	}
+
+	return res.Write(p)
+}
+
+// zipEntryWriter writes a ZIP archive holding a single file, starting the
+// archive with the first write.
+type zipEntryWriter struct {
+	w     io.Writer
+	name  string
+	zw    *zip.Writer
+	entry io.Writer
+}
+
+func (z *zipEntryWriter) Write(p []byte) (int, error) {
+	if z.entry == nil {
+		z.zw = zip.NewWriter(z.w)
+
+		entry, err := z.zw.Create(z.name)
+		if err != nil {
+			return 0, err
+		}
+		z.entry = entry
+	}
+
+	return z.entry.Write(p)
+}
+
+func (z *zipEntryWriter) Close() error {
+	if z.zw == nil {
+		return nil
+	}
+
+	return z.zw.Close()
+}
+
+// userDataJSONWriter encodes a personal data export as a
+// generated.UserDataExport object, one item at a time. Sections come in a
+// fixed order, and the ones without items are written as empty arrays.
+type userDataJSONWriter struct {
+	w       io.Writer
+	section int
+	empty   bool
+}
+
+var userDataExportSections = []string{"activities", "sessions", "verificationCodes", "passkeys"}
+
+func (e *userDataJSONWriter) WriteProfile(profile service.UserDataProfile) error {
+	e.section = -1
+
+	return e.write(`{"profile":`, generated.UserDataProfile{
+		Id:                  profile.ID,
+		FullName:            profile.FullName,
+		PhoneNumber:         profile.PhoneNumber,
+		PhoneVerifiedAt:     profile.PhoneVerifiedAt,
+		PendingPhoneNumber:  profile.PendingPhoneNumber,
+		LoginCount:          profile.LoginCount,
+		FailedLoginCount:    profile.FailedLoginCount,
+		LockedUntil:         profile.LockedUntil,
+		Status:              profile.Status,
+		StatusReason:        profile.StatusReason,
+		StatusChangedAt:     profile.StatusChangedAt,
+		DeletionScheduledAt: profile.DeletionScheduledAt,
+		Roles:               profile.Roles,
+		TwoFactorEnabledAt:  profile.TwoFactorEnabledAt,
+		RecoveryCodesLeft:   profile.RecoveryCodesLeft,
+		CreatedAt:           profile.CreatedAt,
+		UpdatedAt:           profile.UpdatedAt,
+	})
+}
+
+func (e *userDataJSONWriter) WriteActivity(activity service.UserActivity) error {
+	return e.writeItem(0, toUserActivityJSON(activity))
+}
+
+func (e *userDataJSONWriter) WriteSession(session service.UserSession) error {
+	return e.writeItem(1, toUserSessionJSON(session))
+}
+
+func (e *userDataJSONWriter) WriteVerificationCode(code service.UserVerificationCode) error {
+	return e.writeItem(2, generated.UserVerificationCode{
+		Purpose:    code.Purpose,
+		Target:     code.Target,
+		Attempts:   code.Attempts,
+		ExpiresAt:  code.ExpiresAt,
+		ConsumedAt: code.ConsumedAt,
+		CreatedAt:  code.CreatedAt,
+	})
+}
+
+func (e *userDataJSONWriter) WritePasskey(passkey service.UserPasskey) error {
+	transports := passkey.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	return e.writeItem(3, generated.UserPasskey{
+		CredentialId: base64.RawURLEncoding.EncodeToString(passkey.CredentialID),
+		Transports:   transports,
+		LastUsedAt:   passkey.LastUsedAt,
+		CreatedAt:    passkey.CreatedAt,
+	})
+}
+
+// Close writes the remaining empty sections and ends the object.
+func (e *userDataJSONWriter) Close() error {
+	err := e.openSection(len(userDataExportSections) - 1)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(e.w, "]}")
+	return err
+}
+
+func (e *userDataJSONWriter) writeItem(section int, item interface{}) error {
+	err := e.openSection(section)
+	if err != nil {
+		return err
+	}
+
+	prefix := ","
+	if e.empty {
+		prefix = ""
+	}
+	e.empty = false
+
+	return e.write(prefix, item)
+}
+
+func (e *userDataJSONWriter) openSection(section int) error {
+	for e.section < section {
+		prefix := ","
+		if e.section >= 0 {
+			prefix = "],"
+		}
+		e.section++
+		e.empty = true
+
+		_, err := fmt.Fprintf(e.w, `%s"%s":[`, prefix, userDataExportSections[e.section])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *userDataJSONWriter) write(prefix string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(append([]byte(prefix), data...))
+	return err
+}
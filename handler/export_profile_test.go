@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestExportProfile(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	jsonFormat := generated.Json
+	zipFormat := generated.Zip
+
+	writeExport := func(ctx context.Context, params service.ExportUserDataParam) common.Error {
+		_ = params.Writer.WriteProfile(service.UserDataProfile{ID: 1, FullName: "maulana aji satrio", Status: "active", CreatedAt: now, UpdatedAt: now})
+		_ = params.Writer.WriteActivity(service.UserActivity{ActivityType: "login", IPAddress: "203.0.113.7", CreatedAt: now})
+		_ = params.Writer.WriteActivity(service.UserActivity{ActivityType: "export", CreatedAt: now})
+		_ = params.Writer.WriteVerificationCode(service.UserVerificationCode{Purpose: "phone_verification", Target: "+628232482440", ExpiresAt: now, CreatedAt: now})
+		_ = params.Writer.WritePasskey(service.UserPasskey{CredentialID: []byte{0xfb, 0xff}, Transports: []string{"internal"}, LastUsedAt: &now, CreatedAt: now})
+		return nil
+	}
+
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		format               *generated.ExportProfileParamsFormat
+		expectedStatus       int
+		expectedContentType  string
+		expectServiceCall    bool
+		expectedServiceError common.Error
+	}{
+		{
+			name:                "Success JSON",
+			userIDCtxValue:      int64(1),
+			format:              &jsonFormat,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectServiceCall:   true,
+		},
+		{
+			name:                "Success ZIP",
+			userIDCtxValue:      int64(1),
+			format:              &zipFormat,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/zip",
+			expectServiceCall:   true,
+		},
+		{
+			name:              "ForbiddenAccess",
+			userIDCtxValue:    "invalid",
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "Too Many Exports",
+			userIDCtxValue:       int64(1),
+			format:               &zipFormat,
+			expectedStatus:       http.StatusTooManyRequests,
+			expectedContentType:  echo.MIMEApplicationJSON,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.TooManyDataExportsErrorMessage, commonErr.TooManyRequestsErrorType, commonErr.TooManyDataExportsErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/profile/export", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				call := mockService.EXPECT().ExportUserData(gomock.Any(), gomock.Any())
+				if tc.expectedServiceError != nil {
+					call.Return(tc.expectedServiceError)
+				} else {
+					call.DoAndReturn(writeExport)
+				}
+			}
+
+			mockServer.ExportProfile(c, generated.ExportProfileParams{Format: tc.format})
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedContentType != "" {
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tc.expectedContentType)
+			}
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			body := rec.Body.Bytes()
+			if tc.format == &zipFormat {
+				archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+				assert.NoError(t, err)
+				assert.Len(t, archive.File, 1)
+				assert.Equal(t, "export.json", archive.File[0].Name)
+
+				f, err := archive.File[0].Open()
+				assert.NoError(t, err)
+				body, err = io.ReadAll(f)
+				assert.NoError(t, err)
+			}
+
+			var export generated.UserDataExport
+			assert.NoError(t, json.Unmarshal(body, &export))
+			assert.Equal(t, int64(1), export.Profile.Id)
+			assert.Len(t, export.Activities, 2)
+			assert.Equal(t, "203.0.113.7", *export.Activities[0].IpAddress)
+			assert.Empty(t, export.Sessions)
+			assert.NotNil(t, export.Sessions)
+			assert.Len(t, export.VerificationCodes, 1)
+			assert.Equal(t, []generated.UserPasskey{{CredentialId: "-_8", Transports: []string{"internal"}, LastUsedAt: &now, CreatedAt: now}}, export.Passkeys)
+		})
+	}
+}
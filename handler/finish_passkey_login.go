@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) FinishPasskeyLogin(ctx echo.Context) error {
+	request := &generated.PasskeyLoginRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	params := service.FinishPasskeyLoginParam{
+		CeremonyToken: request.CeremonyToken,
+	}
+
+	var err error
+	params.CredentialID, err = decodeBase64URL(request.Credential.Id)
+	if err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	params.ClientDataJSON, err = decodeBase64URL(request.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	params.AuthenticatorData, err = decodeBase64URL(request.Credential.Response.AuthenticatorData)
+	if err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	params.Signature, err = decodeBase64URL(request.Credential.Response.Signature)
+	if err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	if request.Credential.Response.UserHandle != nil {
+		params.UserHandle, err = decodeBase64URL(*request.Credential.Response.UserHandle)
+		if err != nil {
+			return handleBadRequestJSON(ctx, err)
+		}
+	}
+
+	resp, errSvc := s.Service.FinishPasskeyLogin(ctx.Request().Context(), params)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.LoginResponse{
+		UserID:       resp.UserID,
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
+	})
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestFinishPasskeyLogin(t *testing.T) {
+	validBody := `{"ceremonyToken": "ceremony token", "credential": {"id": "AQI", "type": "public-key", "response": {"clientDataJSON": "e30", "authenticatorData": "AAE=", "signature": "MEU", "userHandle": "AAAAAAAAAAE"}}}`
+	expectedParam := service.FinishPasskeyLoginParam{
+		CeremonyToken:     "ceremony token",
+		CredentialID:      []byte{1, 2},
+		ClientDataJSON:    []byte("{}"),
+		AuthenticatorData: []byte{0, 1},
+		Signature:         []byte{0x30, 0x45},
+		UserHandle:        []byte{0, 0, 0, 0, 0, 0, 0, 1},
+	}
+
+	tests := []struct {
+		name                 string
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.LoginResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                "Success",
+			requestBody:         validBody,
+			expectedStatus:      http.StatusOK,
+			expectServiceCall:   true,
+			expectedServiceResp: &service.LoginResponse{UserID: 1, Token: "token", RefreshToken: "refresh"},
+		},
+		{
+			name:              "BadRequest",
+			requestBody:       "Invalid JSON",
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:              "InvalidBase64URL",
+			requestBody:       `{"ceremonyToken": "ceremony token", "credential": {"id": "AQI", "type": "public-key", "response": {"clientDataJSON": "e30", "authenticatorData": "AAE=", "signature": "M+E/"}}}`,
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "InvalidPasskey",
+			requestBody:          validBody,
+			expectedStatus:       http.StatusUnauthorized,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.InvalidPasskeyErrorMessage, commonErr.UnauthorizedErrorType, commonErr.InvalidPasskeyErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/passkey/login/finish", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().FinishPasskeyLogin(gomock.Any(), expectedParam).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.FinishPasskeyLogin(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) FinishPasskeyRegistration(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	request := &generated.PasskeyRegistrationRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	params := service.FinishPasskeyRegistrationParam{
+		UserID:        userID,
+		CeremonyToken: request.CeremonyToken,
+	}
+
+	params.CredentialID, err = decodeBase64URL(request.Credential.Id)
+	if err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	params.ClientDataJSON, err = decodeBase64URL(request.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	params.AttestationObject, err = decodeBase64URL(request.Credential.Response.AttestationObject)
+	if err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	if request.Credential.Response.Transports != nil {
+		for _, transport := range *request.Credential.Response.Transports {
+			params.Transports = append(params.Transports, string(transport))
+		}
+	}
+
+	errSvc := s.Service.FinishPasskeyRegistration(ctx.Request().Context(), params)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestFinishPasskeyRegistration(t *testing.T) {
+	validBody := `{"ceremonyToken": "ceremony token", "credential": {"id": "AQI", "type": "public-key", "response": {"clientDataJSON": "e30", "attestationObject": "oA==", "transports": ["internal", "hybrid"]}}}`
+	expectedParam := service.FinishPasskeyRegistrationParam{
+		UserID:            1,
+		CeremonyToken:     "ceremony token",
+		CredentialID:      []byte{1, 2},
+		ClientDataJSON:    []byte("{}"),
+		AttestationObject: []byte{0xa0},
+		Transports:        []string{"internal", "hybrid"},
+	}
+
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceError common.Error
+	}{
+		{
+			name:              "Success",
+			userIDCtxValue:    int64(1),
+			requestBody:       validBody,
+			expectedStatus:    http.StatusNoContent,
+			expectServiceCall: true,
+		},
+		{
+			name:              "ForbiddenAccess",
+			userIDCtxValue:    "invalid",
+			requestBody:       validBody,
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:              "BadRequest",
+			userIDCtxValue:    int64(1),
+			requestBody:       "Invalid JSON",
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:              "InvalidBase64URL",
+			userIDCtxValue:    int64(1),
+			requestBody:       `{"ceremonyToken": "ceremony token", "credential": {"id": "AQI", "type": "public-key", "response": {"clientDataJSON": "e30", "attestationObject": "o+A/"}}}`,
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "ServiceError Conflict",
+			userIDCtxValue:       int64(1),
+			requestBody:          validBody,
+			expectedStatus:       http.StatusConflict,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.PasskeyAlreadyRegisteredErrorMessage, commonErr.ConflictErrorType, commonErr.PasskeyAlreadyRegisteredErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/profile/passkeys/registration/finish", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().FinishPasskeyRegistration(gomock.Any(), expectedParam).Return(tc.expectedServiceError)
+			}
+
+			mockServer.FinishPasskeyRegistration(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) ForgotPassword(ctx echo.Context) error {
+	request := new(generated.ForgotPasswordRequest)
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	errSvc := s.Service.ForgotPassword(ctx.Request().Context(), service.ForgotPasswordParam{
+		PhoneNumber: request.PhoneNumber,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
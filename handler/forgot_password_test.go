@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestForgotPassword(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceError common.Error
+	}{
+		{
+			name:                 "Success",
+			requestBody:          `{"phoneNumber": "+628123456789"}`,
+			expectedStatus:       http.StatusNoContent,
+			expectServiceCall:    true,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "BadRequest",
+			requestBody:          "Invalid JSON",
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    false,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError",
+			requestBody:          `{"phoneNumber": "+628123456789"}`,
+			expectedStatus:       http.StatusInternalServerError,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/password/forgot", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().ForgotPassword(gomock.Any(), service.ForgotPasswordParam{PhoneNumber: "+628123456789"}).Return(tc.expectedServiceError)
+			}
+
+			mockServer.ForgotPassword(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
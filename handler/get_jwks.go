@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/helper"
+)
+
+func (s *Server) GetJwks(ctx echo.Context) error {
+	jwks := helper.GetJWKS()
+
+	keys := make([]generated.JWK, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		keys = append(keys, generated.JWK{
+			Kty: key.KeyType,
+			Use: key.Use,
+			Alg: key.Algorithm,
+			Kid: key.KeyID,
+			N:   key.Modulus,
+			E:   key.Exponent,
+		})
+	}
+
+	return handleSuccessJSON(ctx, &generated.JWKSResponse{Keys: keys})
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/helper"
+)
+
+func TestGetJwks(t *testing.T) {
+	mockServer := &Server{}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := mockServer.GetJwks(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp generated.JWKSResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Keys, len(helper.GetJWKS().Keys))
+	assert.NotEmpty(t, resp.Keys[0].Kid)
+	assert.Equal(t, "RS256", resp.Keys[0].Alg)
+}
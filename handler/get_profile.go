@@ -16,8 +16,15 @@ func (s *Server) GetProfile(ctx echo.Context) error {
 		return handleServiceError(ctx, errSvc)
 	}
 
-	return handleSuccessJSON(ctx, &generated.GetProfileResponse{
-		FullName:    resp.FullName,
-		PhoneNumber: resp.PhoneNumber,
-	})
+	response := &generated.GetProfileResponse{
+		FullName:      resp.FullName,
+		PhoneNumber:   resp.PhoneNumber,
+		PhoneVerified: resp.PhoneVerified,
+	}
+
+	if resp.PendingPhoneNumber != "" {
+		response.PendingPhoneNumber = &resp.PendingPhoneNumber
+	}
+
+	return handleSuccessJSON(ctx, response)
 }
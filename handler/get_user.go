@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+)
+
+func (s *Server) GetUser(ctx echo.Context, userId generated.UserId) error {
+	resp, errSvc := s.Service.GetUserDetail(ctx.Request().Context(), userId)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	response := &generated.AdminUserDetailResponse{
+		User:             toAdminUserJSON(resp.User),
+		Roles:            resp.Roles,
+		Permissions:      resp.Permissions,
+		RecentActivities: make([]generated.UserActivity, 0, len(resp.RecentActivities)),
+	}
+
+	for _, activity := range resp.RecentActivities {
+		response.RecentActivities = append(response.RecentActivities, toUserActivityJSON(activity))
+	}
+
+	return handleSuccessJSON(ctx, response)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestGetUser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expectedStatus       int
+		expectedServiceResp  *service.UserDetailResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusOK,
+			expectedServiceResp: &service.UserDetailResponse{
+				User:             service.AdminUser{ID: 1, FullName: "maulana aji satrio"},
+				Roles:            []string{"support"},
+				Permissions:      []string{"users:read", "users:unlock"},
+				RecentActivities: []service.UserActivity{{ActivityType: common.LOGIN_ACTIVITY, IPAddress: "203.0.113.7"}},
+			},
+		},
+		{
+			name:                 "NotFound",
+			expectedStatus:       http.StatusNotFound,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType, commonErr.UserDataNotFoundErrorCode),
+		},
+		{
+			name:                 "ServiceInternalError",
+			expectedStatus:       http.StatusInternalServerError,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/admin/users/1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockService.EXPECT().GetUserDetail(gomock.Any(), int64(1)).Return(tc.expectedServiceResp, tc.expectedServiceError)
+
+			mockServer.GetUser(c, 1)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
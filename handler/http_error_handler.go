@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/common"
+	cmnErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/common/i18n"
+	"github.com/sawitpro/UserService/generated"
+)
+
+var httpStatusErrorCodes = map[int]common.ErrorCode{
+	http.StatusBadRequest:       cmnErr.ValidationErrorCode,
+	http.StatusUnauthorized:     cmnErr.UnauthorizedErrorCode,
+	http.StatusForbidden:        cmnErr.ForbiddenErrorCode,
+	http.StatusNotFound:         cmnErr.NotFoundErrorCode,
+	http.StatusMethodNotAllowed: cmnErr.MethodNotAllowedErrorCode,
+	http.StatusConflict:         cmnErr.ConflictErrorCode,
+	http.StatusTooManyRequests:  cmnErr.TooManyRequestsErrorCode,
+	http.StatusLocked:           cmnErr.LockedErrorCode,
+}
+
+// HTTPErrorHandler renders errors returned by middlewares and the router,
+// such as OpenAPI validation failures and echo.ErrForbidden from
+// AuthMiddleware, as the same ErrorResponse the handlers send.
+func HTTPErrorHandler(err error, ctx echo.Context) {
+	if ctx.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	if he, ok := err.(*echo.HTTPError); ok {
+		status = he.Code
+	}
+
+	code, ok := httpStatusErrorCodes[status]
+	if !ok {
+		code = cmnErr.SystemErrorCode
+	}
+
+	// Messages come from the catalogue so they can be translated and so
+	// internal details never leak.
+	lang := requestLanguage(ctx)
+	message, _ := i18n.Translate(lang, code)
+	if field := invalidField(err); field != "" {
+		message = i18n.NewPhrase(i18n.InvalidFieldPhrase, field).Localize(lang)
+	}
+
+	if ctx.Request().Method == http.MethodHead {
+		err = ctx.NoContent(status)
+	} else {
+		err = ctx.JSON(status, &generated.ErrorResponse{
+			ErrorCode: int64(code),
+			ErrorMsg:  message,
+		})
+	}
+
+	if err != nil {
+		ctx.Logger().Error(err)
+	}
+}
+
+// invalidField names the parameter or body property that failed OpenAPI
+// request validation, or returns "" when err is not a validation error.
+func invalidField(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return ""
+	}
+
+	if reqErr.Parameter != nil {
+		return reqErr.Parameter.Name
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		return strings.Join(schemaErr.JSONPointer(), ".")
+	}
+
+	return ""
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	cmnErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/helper/revocation/memory"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		expectedStatus int
+		expectedBody   generated.ErrorResponse
+	}{
+		{
+			name:           "Forbidden From AuthMiddleware",
+			err:            echo.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   generated.ErrorResponse{ErrorCode: int64(cmnErr.ForbiddenErrorCode), ErrorMsg: "access is forbidden."},
+		},
+		{
+			name:           "Forbidden In Indonesian",
+			err:            echo.ErrForbidden,
+			acceptLanguage: "id-ID,id;q=0.9,en;q=0.8",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   generated.ErrorResponse{ErrorCode: int64(cmnErr.ForbiddenErrorCode), ErrorMsg: "akses ditolak."},
+		},
+		{
+			name:           "Bad Request",
+			err:            echo.NewHTTPError(http.StatusBadRequest, "request body has an error: value is required but missing"),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   generated.ErrorResponse{ErrorCode: int64(cmnErr.ValidationErrorCode), ErrorMsg: "request is invalid."},
+		},
+		{
+			name:           "Route Not Found",
+			err:            echo.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   generated.ErrorResponse{ErrorCode: int64(cmnErr.NotFoundErrorCode), ErrorMsg: "resource not found."},
+		},
+		{
+			name:           "Internal HTTP Error",
+			err:            echo.NewHTTPError(http.StatusInternalServerError, "database is down"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   generated.ErrorResponse{ErrorCode: int64(cmnErr.SystemErrorCode), ErrorMsg: "something went wrong, please try again later."},
+		},
+		{
+			name:           "Plain Error",
+			err:            errors.New("boom"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   generated.ErrorResponse{ErrorCode: int64(cmnErr.SystemErrorCode), ErrorMsg: "something went wrong, please try again later."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			HTTPErrorHandler(tc.err, c)
+
+			var body generated.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func TestHTTPErrorHandlerValidation(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		acceptLanguage  string
+		expectedMessage string
+	}{
+		{
+			name:            "Missing Property",
+			body:            `{"phoneNumber":"+628232482440"}`,
+			expectedMessage: "request is invalid, please check the password field.",
+		},
+		{
+			name:            "Missing Property In Indonesian",
+			body:            `{"phoneNumber":"+628232482440"}`,
+			acceptLanguage:  "id",
+			expectedMessage: "permintaan tidak valid, periksa kolom password.",
+		},
+		{
+			name:            "Wrong Property Type",
+			body:            `{"phoneNumber":628232482440,"password":"Password1!"}`,
+			expectedMessage: "request is invalid, please check the phoneNumber field.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = HTTPErrorHandler
+			mw, err := InitMiddleware(MiddlewareOpts{RevocationStore: memory.NewStore()})
+			assert.NoError(t, err)
+			e.Use(mw...)
+			e.POST("/auth/login", func(ctx echo.Context) error {
+				return ctx.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:8080/auth/login", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			var body generated.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, int64(cmnErr.ValidationErrorCode), body.ErrorCode)
+			assert.Equal(t, tc.expectedMessage, body.ErrorMsg)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) ListActivities(ctx echo.Context, params generated.ListActivitiesParams) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	svcParams := service.ListActivitiesParam{
+		UserID:      userID,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+	}
+	if params.ActivityType != nil {
+		svcParams.ActivityType = *params.ActivityType
+	}
+	if params.Cursor != nil {
+		svcParams.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		svcParams.Limit = *params.Limit
+	}
+
+	resp, errSvc := s.Service.ListActivities(ctx.Request().Context(), svcParams)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	response := &generated.ActivityListResponse{
+		Activities: make([]generated.UserActivity, 0, len(resp.Activities)),
+	}
+	for _, activity := range resp.Activities {
+		response.Activities = append(response.Activities, toUserActivityJSON(activity))
+	}
+
+	if resp.NextCursor != "" {
+		response.NextCursor = &resp.NextCursor
+	}
+
+	return handleSuccessJSON(ctx, response)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestListActivities(t *testing.T) {
+	activityType := "login"
+	limit := 10
+
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		params               generated.ListActivitiesParams
+		expectedParams       service.ListActivitiesParam
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.ListActivitiesResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                "Success",
+			userIDCtxValue:      int64(1),
+			params:              generated.ListActivitiesParams{ActivityType: &activityType, Limit: &limit},
+			expectedParams:      service.ListActivitiesParam{UserID: 1, ActivityType: activityType, Limit: limit},
+			expectedStatus:      http.StatusOK,
+			expectServiceCall:   true,
+			expectedServiceResp: &service.ListActivitiesResponse{Activities: []service.UserActivity{{ActivityType: "login", CreatedAt: time.Now()}}, NextCursor: "MQ"},
+		},
+		{
+			name:              "ForbiddenAccess",
+			userIDCtxValue:    "invalid",
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "InvalidCursor",
+			userIDCtxValue:       int64(1),
+			expectedParams:       service.ListActivitiesParam{UserID: 1},
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.InvalidCursorErrorMessage, commonErr.BadRequestErrorType, commonErr.InvalidCursorErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/profile/activities", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().ListActivities(gomock.Any(), tc.expectedParams).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.ListActivities(c, tc.params)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) ListSessions(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	sessionID, err := getContextSessionID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.ListSessions(ctx.Request().Context(), service.ListSessionsParam{
+		UserID:           userID,
+		CurrentSessionID: sessionID,
+	})
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	response := &generated.SessionListResponse{
+		Sessions: make([]generated.UserSession, 0, len(resp.Sessions)),
+	}
+	for _, session := range resp.Sessions {
+		item := toUserSessionJSON(session)
+		current := session.Current
+		item.Current = &current
+		response.Sessions = append(response.Sessions, item)
+	}
+
+	return handleSuccessJSON(ctx, response)
+}
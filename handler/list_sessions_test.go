@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestListSessions(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		sessionIDCtxValue    interface{}
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.ListSessionsResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:              "Success",
+			userIDCtxValue:    int64(1),
+			sessionIDCtxValue: int64(2),
+			expectedStatus:    http.StatusOK,
+			expectServiceCall: true,
+			expectedServiceResp: &service.ListSessionsResponse{Sessions: []service.UserSession{
+				{ID: 2, DeviceName: "iOS mobile", IPAddress: "203.0.113.7", Current: true, LastSeenAt: time.Now(), CreatedAt: time.Now()},
+				{ID: 1, DeviceName: "Unknown device", LastSeenAt: time.Now(), CreatedAt: time.Now()},
+			}},
+		},
+		{
+			name:              "ForbiddenAccess User ID",
+			userIDCtxValue:    "invalid",
+			sessionIDCtxValue: int64(2),
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:              "ForbiddenAccess Session ID",
+			userIDCtxValue:    int64(1),
+			sessionIDCtxValue: nil,
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "ServiceError",
+			userIDCtxValue:       int64(1),
+			sessionIDCtxValue:    int64(2),
+			expectedStatus:       http.StatusInternalServerError,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/profile/sessions", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+			c.Set(common.SESSION_ID_CTX_KEY, tc.sessionIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().ListSessions(gomock.Any(), service.ListSessionsParam{UserID: 1, CurrentSessionID: 2}).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.ListSessions(c)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				response := generated.SessionListResponse{}
+				assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Len(t, response.Sessions, 2)
+				assert.True(t, *response.Sessions[0].Current)
+				assert.Equal(t, "203.0.113.7", *response.Sessions[0].IpAddress)
+				assert.False(t, *response.Sessions[1].Current)
+				assert.Nil(t, response.Sessions[1].IpAddress)
+			}
+		})
+	}
+}
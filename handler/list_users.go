@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) ListUsers(ctx echo.Context, params generated.ListUsersParams) error {
+	svcParams := service.ListUsersParam{
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+	}
+	if params.PhonePrefix != nil {
+		svcParams.PhonePrefix = *params.PhonePrefix
+	}
+	if params.Name != nil {
+		svcParams.Name = *params.Name
+	}
+	if params.Cursor != nil {
+		svcParams.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		svcParams.Limit = *params.Limit
+	}
+
+	resp, errSvc := s.Service.ListUsers(ctx.Request().Context(), svcParams)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	response := &generated.AdminUserListResponse{
+		Users: make([]generated.AdminUser, 0, len(resp.Users)),
+	}
+	for _, user := range resp.Users {
+		response.Users = append(response.Users, toAdminUserJSON(user))
+	}
+
+	if resp.NextCursor != "" {
+		response.NextCursor = &resp.NextCursor
+	}
+
+	return handleSuccessJSON(ctx, response)
+}
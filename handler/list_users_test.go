@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestListUsers(t *testing.T) {
+	phonePrefix := "+62823"
+	limit := 10
+
+	tests := []struct {
+		name                 string
+		params               generated.ListUsersParams
+		expectedParams       service.ListUsersParam
+		expectedStatus       int
+		expectedServiceResp  *service.ListUsersResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                "Success",
+			params:              generated.ListUsersParams{PhonePrefix: &phonePrefix, Limit: &limit},
+			expectedParams:      service.ListUsersParam{PhonePrefix: phonePrefix, Limit: limit},
+			expectedStatus:      http.StatusOK,
+			expectedServiceResp: &service.ListUsersResponse{Users: []service.AdminUser{{ID: 1, FullName: "maulana aji satrio"}}, NextCursor: "MQ"},
+		},
+		{
+			name:                 "InvalidCursor",
+			expectedStatus:       http.StatusBadRequest,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.InvalidCursorErrorMessage, commonErr.BadRequestErrorType, commonErr.InvalidCursorErrorCode),
+		},
+		{
+			name:                 "ServiceInternalError",
+			expectedStatus:       http.StatusInternalServerError,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockService.EXPECT().ListUsers(gomock.Any(), tc.expectedParams).Return(tc.expectedServiceResp, tc.expectedServiceError)
+
+			mockServer.ListUsers(c, tc.params)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 	"github.com/sawitpro/UserService/generated"
 	"github.com/sawitpro/UserService/service"
@@ -21,8 +23,16 @@ func (s *Server) Login(ctx echo.Context) error {
 		return handleServiceError(ctx, errSvc)
 	}
 
+	if resp.MFARequired {
+		return ctx.JSON(http.StatusAccepted, &generated.MFAChallengeResponse{
+			Status:   generated.MfaRequired,
+			MfaToken: resp.MFAToken,
+		})
+	}
+
 	return handleSuccessJSON(ctx, &generated.LoginResponse{
-		UserID: resp.UserID,
-		Token:  resp.Token,
+		UserID:       resp.UserID,
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
 	})
 }
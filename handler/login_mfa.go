@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) LoginMfa(ctx echo.Context) error {
+	request := new(generated.MFALoginRequest)
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.VerifyMFALogin(ctx.Request().Context(), service.VerifyMFALoginParam{
+		MFAToken: request.MfaToken,
+		Code:     request.Code,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.LoginResponse{
+		UserID:       resp.UserID,
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
+	})
+}
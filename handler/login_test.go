@@ -33,6 +33,14 @@ func TestLogin(t *testing.T) {
 			expectedServiceResp:  &service.LoginResponse{UserID: 1, Token: "token"},
 			expectedServiceError: nil,
 		},
+		{
+			name:                 "MFA Required",
+			requestBody:          `{"phoneNumber": "+628232482440", "password": "Maulana1996@"}`,
+			expectedStatus:       http.StatusAccepted,
+			expectServiceCall:    true,
+			expectedServiceResp:  &service.LoginResponse{UserID: 1, MFARequired: true, MFAToken: "mfa token"},
+			expectedServiceError: nil,
+		},
 		{
 			name:                 "BadRequest",
 			requestBody:          "Invalid JSON",
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) Logout(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	sessionID, err := getContextSessionID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	tokenID, expiresAt, err := getContextTokenID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	request := &generated.LogoutRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	refreshToken := ""
+	if request.RefreshToken != nil {
+		refreshToken = *request.RefreshToken
+	}
+
+	errSvc := s.Service.Logout(ctx.Request().Context(), service.LogoutParam{
+		UserID:         userID,
+		SessionID:      sessionID,
+		TokenID:        tokenID,
+		TokenExpiresAt: expiresAt,
+		RefreshToken:   refreshToken,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestLogout(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		tokenIDCtxValue      interface{}
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceParam service.LogoutParam
+		expectedServiceError common.Error
+	}{
+		{
+			name:              "Success",
+			userIDCtxValue:    int64(1),
+			tokenIDCtxValue:   "token-id",
+			requestBody:       `{"refreshToken": "refresh"}`,
+			expectedStatus:    http.StatusNoContent,
+			expectServiceCall: true,
+			expectedServiceParam: service.LogoutParam{
+				UserID:         1,
+				SessionID:      2,
+				TokenID:        "token-id",
+				TokenExpiresAt: expiresAt,
+				RefreshToken:   "refresh",
+			},
+			expectedServiceError: nil,
+		},
+		{
+			name:              "Success Without Refresh Token",
+			userIDCtxValue:    int64(1),
+			tokenIDCtxValue:   "token-id",
+			requestBody:       `{}`,
+			expectedStatus:    http.StatusNoContent,
+			expectServiceCall: true,
+			expectedServiceParam: service.LogoutParam{
+				UserID:         1,
+				SessionID:      2,
+				TokenID:        "token-id",
+				TokenExpiresAt: expiresAt,
+			},
+			expectedServiceError: nil,
+		},
+		{
+			name:              "ForbiddenAccess User ID",
+			userIDCtxValue:    "invalid",
+			tokenIDCtxValue:   "token-id",
+			requestBody:       `{}`,
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:              "ForbiddenAccess Token ID",
+			userIDCtxValue:    int64(1),
+			tokenIDCtxValue:   nil,
+			requestBody:       `{}`,
+			expectedStatus:    http.StatusForbidden,
+			expectServiceCall: false,
+		},
+		{
+			name:              "BadRequest JSON",
+			userIDCtxValue:    int64(1),
+			tokenIDCtxValue:   "token-id",
+			requestBody:       `Invalid JSON`,
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:              "ServiceError",
+			userIDCtxValue:    int64(1),
+			tokenIDCtxValue:   "token-id",
+			requestBody:       `{}`,
+			expectedStatus:    http.StatusInternalServerError,
+			expectServiceCall: true,
+			expectedServiceParam: service.LogoutParam{
+				UserID:         1,
+				SessionID:      2,
+				TokenID:        "token-id",
+				TokenExpiresAt: expiresAt,
+			},
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+			c.Set(common.SESSION_ID_CTX_KEY, int64(2))
+			c.Set(common.TOKEN_ID_CTX_KEY, tc.tokenIDCtxValue)
+			c.Set(common.TOKEN_EXPIRES_AT_CTX_KEY, expiresAt)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().Logout(gomock.Any(), tc.expectedServiceParam).Return(tc.expectedServiceError)
+			}
+
+			mockServer.Logout(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -3,22 +3,86 @@ package handler
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	codegenMiddleware "github.com/deepmap/oapi-codegen/pkg/middleware"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sawitpro/UserService/common"
+	cmnErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/common/i18n"
 	"github.com/sawitpro/UserService/generated"
 	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/revocation"
+	"github.com/sawitpro/UserService/helper/useragent"
 )
 
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// maxUserAgentLength and maxRequestIDLength match the columns of
+// user_activity_logs. Clients may send their own X-Request-ID.
+const (
+	maxUserAgentLength = 512
+	maxRequestIDLength = 64
+)
+
+// sessionTouchInterval is how stale the last seen time of a session may get
+// before AuthMiddleware updates it.
+const sessionTouchInterval = time.Minute
+
+// permissionsExtension lists the permissions an operation in api.yml
+// requires, all of which the caller's access token must carry.
+const permissionsExtension = "x-permissions"
+
+// pathParamPattern matches an OpenAPI path parameter such as {userId}.
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
 var whitelistPaths = map[string]struct{}{
-	"/auth/login":    {},
-	"/auth/register": {},
+	"/auth/login":                {},
+	"/auth/register":             {},
+	"/auth/login/mfa":            {},
+	"/auth/otp/request":          {},
+	"/auth/otp/verify":           {},
+	"/auth/passkey/login/begin":  {},
+	"/auth/passkey/login/finish": {},
+	"/auth/refresh":              {},
+	"/auth/password/forgot":      {},
+	"/auth/password/reset":       {},
+	"/.well-known/jwks.json":     {},
+}
+
+// UserStatusReader returns the account status of a user, or an empty string
+// when the user no longer exists.
+type UserStatusReader interface {
+	GetUserStatus(ctx context.Context, userID int64) (string, error)
+}
+
+// SessionStore lets AuthMiddleware refuse tokens of revoked sessions and
+// record when each session was last used.
+type SessionStore interface {
+	IsUserSessionActive(ctx context.Context, userID, sessionID int64) (bool, error)
+	TouchUserSession(ctx context.Context, sessionID int64, seenBefore time.Time) error
+}
+
+type MiddlewareOpts struct {
+	RevocationStore revocation.Store
+	// UserStatusReader lets AuthMiddleware refuse tokens of accounts that
+	// were suspended or deactivated after the token was issued.
+	UserStatusReader UserStatusReader
+	SessionStore     SessionStore
+	// DefaultLanguage is used when the client sends no supported
+	// Accept-Language. Defaults to English.
+	DefaultLanguage i18n.Language
 }
 
-func InitMiddleware() ([]echo.MiddlewareFunc, error) {
+func InitMiddleware(opts MiddlewareOpts) ([]echo.MiddlewareFunc, error) {
 	spec, err := generated.GetSwagger()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
@@ -32,41 +96,197 @@ func InitMiddleware() ([]echo.MiddlewareFunc, error) {
 		},
 	})
 
-	return []echo.MiddlewareFunc{middleware.Recover(), reqValidatorMiddleware, AuthMiddleware}, nil
+	defaultLanguage := opts.DefaultLanguage
+	if defaultLanguage == "" {
+		defaultLanguage = i18n.English
+	}
+
+	permissionMiddleware, err := PermissionMiddleware(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return []echo.MiddlewareFunc{middleware.Recover(), middleware.RequestID(), RequestInfoMiddleware(), LanguageMiddleware(defaultLanguage), reqValidatorMiddleware, AuthMiddleware(opts.RevocationStore, opts.UserStatusReader, opts.SessionStore), permissionMiddleware}, nil
 }
 
-func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(ctx echo.Context) error {
-		if _, ok := whitelistPaths[ctx.Request().URL.Path]; ok {
+// RequestInfoMiddleware puts the client IP, User-Agent and request ID in the
+// request context, where the repository reads them for activity logs. The IP
+// comes from the echo IPExtractor, so X-Forwarded-For is only honoured when
+// the server is configured to trust the proxy that set it. It runs after
+// middleware.RequestID, which sets the request ID.
+func RequestInfoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			userAgent := truncate(req.UserAgent(), maxUserAgentLength)
+			agent := useragent.Parse(userAgent)
+			info := common.RequestInfo{
+				IPAddress:    ctx.RealIP(),
+				UserAgent:    userAgent,
+				DeviceFamily: agent.Device,
+				OSFamily:     agent.OS,
+				RequestID:    truncate(ctx.Response().Header().Get(echo.HeaderXRequestID), maxRequestIDLength),
+			}
+
+			ctx.SetRequest(req.WithContext(context.WithValue(req.Context(), common.REQUEST_INFO_CTX_KEY, info)))
+
 			return next(ctx)
 		}
+	}
+}
+
+// truncate cuts s to at most n bytes without leaving a broken UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
 
-		token := getBearerToken(ctx)
-		claimsToken, err := helper.ValidateToken(token)
-		if err != nil {
-			return echo.ErrForbidden
+	return strings.ToValidUTF8(s[:n], "")
+}
+
+// LanguageMiddleware resolves the language of error messages from the
+// Accept-Language header.
+func LanguageMiddleware(defaultLanguage i18n.Language) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			lang := i18n.ParseAcceptLanguage(ctx.Request().Header.Get(headerAcceptLanguage), defaultLanguage)
+			ctx.Set(common.LANGUAGE_CTX_KEY, lang)
+			ctx.Response().Header().Set(headerContentLanguage, string(lang))
+
+			return next(ctx)
 		}
-		ctx.Set(common.USER_ID_CTX_KEY, claimsToken.UserID)
+	}
+}
+
+func AuthMiddleware(store revocation.Store, statusReader UserStatusReader, sessions SessionStore) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if _, ok := whitelistPaths[ctx.Request().URL.Path]; ok {
+				return next(ctx)
+			}
+
+			token := getBearerToken(ctx)
+			claimsToken, err := helper.ValidateToken(token)
+			if err != nil {
+				return echo.ErrForbidden
+			}
 
-		return next(ctx)
+			revoked, err := store.IsTokenRevoked(ctx.Request().Context(), claimsToken.Id, claimsToken.UserID, claimsToken.IssuedTime())
+			if err != nil {
+				return echo.ErrInternalServerError
+			}
+
+			if revoked {
+				return echo.ErrForbidden
+			}
+
+			active, err := sessions.IsUserSessionActive(ctx.Request().Context(), claimsToken.UserID, claimsToken.SessionID)
+			if err != nil {
+				return echo.ErrInternalServerError
+			}
+
+			if !active {
+				return echo.ErrForbidden
+			}
+
+			status, err := statusReader.GetUserStatus(ctx.Request().Context(), claimsToken.UserID)
+			if err != nil {
+				return echo.ErrInternalServerError
+			}
+
+			if status == "" {
+				return echo.ErrForbidden
+			}
+
+			if status != common.ACTIVE_STATUS {
+				return handleServiceError(ctx, cmnErr.NewAccountStatusError(status))
+			}
+
+			// The last seen time is informational, so failing to record it
+			// does not fail the request.
+			_ = sessions.TouchUserSession(ctx.Request().Context(), claimsToken.SessionID, time.Now().Add(-sessionTouchInterval))
+
+			ctx.Set(common.USER_ID_CTX_KEY, claimsToken.UserID)
+			ctx.Set(common.SESSION_ID_CTX_KEY, claimsToken.SessionID)
+			ctx.Set(common.TOKEN_ID_CTX_KEY, claimsToken.Id)
+			ctx.Set(common.TOKEN_EXPIRES_AT_CTX_KEY, time.Unix(claimsToken.ExpiresAt, 0))
+			ctx.Set(common.PERMISSIONS_CTX_KEY, claimsToken.Permissions)
+
+			return next(ctx)
+		}
 	}
 }
 
-// func CreateAuthMiddlewareFunc() echo.MiddlewareFunc {
-// 	return func(next echo.HandlerFunc) echo.HandlerFunc {
-// 		return func(ctx echo.Context) error {
-// 			if _, ok := whitelistPaths[ctx.Request().URL.Path]; ok {
-// 				return next(ctx)
-// 			}
-
-// 			token := getBearerToken(ctx)
-// 			claimsToken, err := helper.ValidateToken(token)
-// 			if err != nil {
-// 				return echo.ErrForbidden
-// 			}
-// 			ctx.Set(common.USER_ID_CTX_KEY, claimsToken.UserID)
-
-// 			return next(ctx)
-// 		}
-// 	}
-// }
+// PermissionMiddleware rejects callers whose access token lacks a permission
+// listed in the x-permissions of the matched operation. It runs after
+// AuthMiddleware, which sets the permissions.
+func PermissionMiddleware(spec *openapi3.T) (echo.MiddlewareFunc, error) {
+	required, err := operationPermissions(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			permissions := required[routeKey(ctx.Request().Method, ctx.Path())]
+			if len(permissions) == 0 {
+				return next(ctx)
+			}
+
+			granted := map[string]struct{}{}
+			claims, _ := ctx.Get(common.PERMISSIONS_CTX_KEY).([]string)
+			for _, permission := range claims {
+				granted[permission] = struct{}{}
+			}
+
+			for _, permission := range permissions {
+				if _, ok := granted[permission]; !ok {
+					return handleServiceError(ctx, cmnErr.NewErrorWithCode(
+						cmnErr.NewMissingPermissionErrorMessage(permission),
+						cmnErr.ForbiddenErrorType,
+						cmnErr.MissingPermissionErrorCode,
+						permission))
+				}
+			}
+
+			return next(ctx)
+		}
+	}, nil
+}
+
+// operationPermissions reads the x-permissions of every operation in spec,
+// keyed by method and the route path echo matched.
+func operationPermissions(spec *openapi3.T) (map[string][]string, error) {
+	required := map[string][]string{}
+
+	for path, item := range spec.Paths {
+		for method, operation := range item.Operations() {
+			raw, ok := operation.Extensions[permissionsExtension]
+			if !ok {
+				continue
+			}
+
+			values, ok := raw.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s of %s %s must be a list", permissionsExtension, method, path)
+			}
+
+			var permissions []string
+			for _, value := range values {
+				permission, ok := value.(string)
+				if !ok || permission == "" {
+					return nil, fmt.Errorf("%s of %s %s must only contain permission names", permissionsExtension, method, path)
+				}
+				permissions = append(permissions, permission)
+			}
+
+			required[routeKey(method, pathParamPattern.ReplaceAllString(path, ":$1"))] = permissions
+		}
+	}
+
+	return required, nil
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
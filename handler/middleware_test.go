@@ -1,22 +1,125 @@
 package handler
 
 import (
+	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/common"
+	cmnErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/generated"
 	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/revocation/memory"
+	"github.com/sawitpro/UserService/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestInitMiddleware(t *testing.T) {
 
-	middlewareFuncs, err := InitMiddleware()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	middlewareFuncs, err := InitMiddleware(MiddlewareOpts{
+		RevocationStore:  memory.NewStore(),
+		UserStatusReader: mocks.NewMockRepositoryInterface(ctrl),
+		SessionStore:     mocks.NewMockRepositoryInterface(ctrl),
+	})
 	assert.NoError(t, err)
 	assert.NotNil(t, middlewareFuncs)
-	assert.Len(t, middlewareFuncs, 3)
+	assert.Len(t, middlewareFuncs, 7)
+}
+
+func TestRequestInfoMiddleware(t *testing.T) {
+	_, proxyNet, _ := net.ParseCIDR("10.0.0.0/8")
+	iPhone := "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1"
+
+	tests := []struct {
+		name         string
+		ipExtractor  echo.IPExtractor
+		userAgent    string
+		requestID    string
+		expectedInfo common.RequestInfo
+	}{
+		{
+			name:        "Direct Client Ignores Forwarded For",
+			ipExtractor: echo.ExtractIPDirect(),
+			userAgent:   iPhone,
+			requestID:   "req-1",
+			expectedInfo: common.RequestInfo{
+				IPAddress:    "10.0.0.2",
+				UserAgent:    iPhone,
+				DeviceFamily: "mobile",
+				OSFamily:     "iOS",
+				RequestID:    "req-1",
+			},
+		},
+		{
+			name:        "Trusted Proxy Forwarded For",
+			ipExtractor: echo.ExtractIPFromXFFHeader(echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false), echo.TrustIPRange(proxyNet)),
+			userAgent:   iPhone,
+			requestID:   "req-2",
+			expectedInfo: common.RequestInfo{
+				IPAddress:    "203.0.113.7",
+				UserAgent:    iPhone,
+				DeviceFamily: "mobile",
+				OSFamily:     "iOS",
+				RequestID:    "req-2",
+			},
+		},
+		{
+			name:        "Long Values Truncated",
+			ipExtractor: echo.ExtractIPDirect(),
+			userAgent:   strings.Repeat("a", maxUserAgentLength+1),
+			requestID:   strings.Repeat("r", maxRequestIDLength+1),
+			expectedInfo: common.RequestInfo{
+				IPAddress:    "10.0.0.2",
+				UserAgent:    strings.Repeat("a", maxUserAgentLength),
+				DeviceFamily: "other",
+				OSFamily:     "Other",
+				RequestID:    strings.Repeat("r", maxRequestIDLength),
+			},
+		},
+		{
+			name:        "Missing User Agent",
+			ipExtractor: echo.ExtractIPDirect(),
+			userAgent:   "",
+			requestID:   "req-3",
+			expectedInfo: common.RequestInfo{
+				IPAddress: "10.0.0.2",
+				RequestID: "req-3",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			e.IPExtractor = tc.ipExtractor
+			req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+			req.RemoteAddr = "10.0.0.2:4321"
+			req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.7")
+			req.Header.Set("User-Agent", tc.userAgent)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Response().Header().Set(echo.HeaderXRequestID, tc.requestID)
+
+			var info common.RequestInfo
+			err := RequestInfoMiddleware()(func(c echo.Context) error {
+				info = common.RequestInfoFromContext(c.Request().Context())
+				return nil
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedInfo, info)
+		})
+	}
 }
 
 func TestAuthMiddleware(t *testing.T) {
@@ -24,6 +127,9 @@ func TestAuthMiddleware(t *testing.T) {
 		name           string
 		requestPath    string
 		isValidToken   bool
+		isRevoked      bool
+		sessionRevoked bool
+		status         string
 		expectedUserID int64
 		expectError    bool
 		expectedStatus int
@@ -33,11 +139,62 @@ func TestAuthMiddleware(t *testing.T) {
 			name:           "ValidToken",
 			requestPath:    "/profile",
 			isValidToken:   true,
+			status:         common.ACTIVE_STATUS,
 			expectedUserID: 1,
 			expectError:    false,
 			expectedStatus: http.StatusOK,
 			httpMethod:     http.MethodPost,
 		},
+		{
+			name:           "RevokedToken",
+			requestPath:    "/profile",
+			isValidToken:   true,
+			isRevoked:      true,
+			expectedUserID: 1,
+			expectError:    true,
+			expectedStatus: http.StatusForbidden,
+			httpMethod:     http.MethodGet,
+		},
+		{
+			name:           "RevokedSession",
+			requestPath:    "/profile",
+			isValidToken:   true,
+			sessionRevoked: true,
+			expectedUserID: 1,
+			expectError:    true,
+			expectedStatus: http.StatusForbidden,
+			httpMethod:     http.MethodGet,
+		},
+		{
+			name:           "SuspendedAccount",
+			requestPath:    "/profile",
+			isValidToken:   true,
+			status:         common.SUSPENDED_STATUS,
+			expectedUserID: 1,
+			expectError:    false,
+			expectedStatus: http.StatusForbidden,
+			httpMethod:     http.MethodGet,
+		},
+		{
+			name:           "ErasedAccount",
+			requestPath:    "/profile",
+			isValidToken:   true,
+			status:         common.DELETED_STATUS,
+			expectedUserID: 1,
+			expectError:    false,
+			expectedStatus: http.StatusUnauthorized,
+			httpMethod:     http.MethodGet,
+		},
+		{
+			name:           "DeletedUser",
+			requestPath:    "/profile",
+			isValidToken:   true,
+			status:         "",
+			expectedUserID: 1,
+			expectError:    true,
+			expectedStatus: http.StatusForbidden,
+			httpMethod:     http.MethodGet,
+		},
 		{
 			name:           "InvalidToken",
 			requestPath:    "/profile",
@@ -78,11 +235,31 @@ func TestAuthMiddleware(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
 			e := echo.New()
+			store := memory.NewStore()
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
 			req := httptest.NewRequest(tc.httpMethod, tc.requestPath, nil)
 			if tc.isValidToken {
-				token, _ := helper.CreateToken(tc.expectedUserID, time.Duration(1)*time.Hour)
+				if !tc.isRevoked {
+					mockRepo.EXPECT().IsUserSessionActive(gomock.Any(), tc.expectedUserID, int64(1)).Return(!tc.sessionRevoked, nil)
+				}
+				if !tc.isRevoked && !tc.sessionRevoked {
+					mockRepo.EXPECT().GetUserStatus(gomock.Any(), tc.expectedUserID).Return(tc.status, nil)
+				}
+				if tc.status == common.ACTIVE_STATUS {
+					mockRepo.EXPECT().TouchUserSession(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+				}
+
+				token, _ := helper.CreateToken(tc.expectedUserID, 1, nil, nil, time.Duration(1)*time.Hour)
 				req.Header.Set("Authorization", "Bearer "+token)
+
+				if tc.isRevoked {
+					claims, _ := helper.ValidateToken(token)
+					store.RevokeToken(req.Context(), claims.Id, claims.UserID, time.Unix(claims.ExpiresAt, 0))
+				}
 			} else {
 				req.Header.Set("Authorization", "Bear 123")
 			}
@@ -90,10 +267,14 @@ func TestAuthMiddleware(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			err := AuthMiddleware(func(c echo.Context) error {
+			called := false
+			err := AuthMiddleware(store, mockRepo, mockRepo)(func(c echo.Context) error {
+				called = true
 				userID, ok := c.Get("userID").(int64)
 				if ok {
 					assert.Equal(t, tc.expectedUserID, userID)
+					assert.NotEmpty(t, c.Get("tokenID"))
+					assert.Equal(t, int64(1), c.Get(common.SESSION_ID_CTX_KEY))
 				}
 
 				return nil
@@ -106,7 +287,93 @@ func TestAuthMiddleware(t *testing.T) {
 			}
 			if !tc.expectError {
 				assert.Equal(t, tc.expectedStatus, rec.Code)
+				assert.Equal(t, tc.expectedStatus == http.StatusOK, called)
 			}
 		})
 	}
 }
+
+func TestPermissionMiddleware(t *testing.T) {
+	spec, err := generated.GetSwagger()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		routePath   string
+		permissions interface{}
+		expectError bool
+	}{
+		{
+			name:        "Granted Permission",
+			method:      http.MethodGet,
+			routePath:   "/admin/users",
+			permissions: []string{"users:read"},
+			expectError: false,
+		},
+		{
+			name:        "Missing Permission",
+			method:      http.MethodPost,
+			routePath:   "/admin/users/:userId/unlock",
+			permissions: []string{"users:read"},
+			expectError: true,
+		},
+		{
+			name:        "No Permissions Claim",
+			method:      http.MethodGet,
+			routePath:   "/admin/users/:userId",
+			permissions: nil,
+			expectError: true,
+		},
+		{
+			name:        "Operation Without Permissions",
+			method:      http.MethodGet,
+			routePath:   "/profile",
+			permissions: nil,
+			expectError: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mw, err := PermissionMiddleware(spec)
+			assert.NoError(t, err)
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(tc.routePath)
+			c.Set(common.PERMISSIONS_CTX_KEY, tc.permissions)
+
+			called := false
+			err = mw(func(c echo.Context) error {
+				called = true
+				return nil
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, !tc.expectError, called)
+			if tc.expectError {
+				var body generated.ErrorResponse
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, int64(cmnErr.MissingPermissionErrorCode), body.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestPermissionMiddlewareInvalidExtension(t *testing.T) {
+	operation := openapi3.NewOperation()
+	operation.Extensions = map[string]interface{}{permissionsExtension: "users:read"}
+
+	spec := &openapi3.T{Paths: openapi3.Paths{
+		"/admin/users": &openapi3.PathItem{Get: operation},
+	}}
+
+	_, err := PermissionMiddleware(spec)
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) RefreshToken(ctx echo.Context) error {
+	request := new(generated.RefreshTokenRequest)
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.RefreshToken(ctx.Request().Context(), service.RefreshTokenParam{
+		RefreshToken: request.RefreshToken,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.LoginResponse{
+		UserID:       resp.UserID,
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
+	})
+}
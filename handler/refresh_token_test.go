@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestRefreshToken(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.LoginResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                 "Success",
+			requestBody:          `{"refreshToken": "refresh"}`,
+			expectedStatus:       http.StatusOK,
+			expectServiceCall:    true,
+			expectedServiceResp:  &service.LoginResponse{UserID: 1, Token: "token", RefreshToken: "new refresh"},
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "BadRequest",
+			requestBody:          "Invalid JSON",
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    false,
+			expectedServiceResp:  nil,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError Unauthorized",
+			requestBody:          `{"refreshToken": "refresh"}`,
+			expectedStatus:       http.StatusUnauthorized,
+			expectServiceCall:    true,
+			expectedServiceResp:  nil,
+			expectedServiceError: commonErr.NewError("any", commonErr.UnauthorizedErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().RefreshToken(gomock.Any(), service.RefreshTokenParam{RefreshToken: "refresh"}).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.RefreshToken(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
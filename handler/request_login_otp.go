@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) RequestLoginOtp(ctx echo.Context) error {
+	request := new(generated.LoginOTPRequest)
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	errSvc := s.Service.RequestLoginCode(ctx.Request().Context(), service.RequestLoginCodeParam{
+		PhoneNumber: request.PhoneNumber,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+)
+
+func TestResendPhoneVerification(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceError common.Error
+	}{
+		{
+			name:                 "Success",
+			userIDCtxValue:       int64(1),
+			expectedStatus:       http.StatusNoContent,
+			expectServiceCall:    true,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ForbiddenAccess",
+			userIDCtxValue:       "invalid",
+			expectedStatus:       http.StatusForbidden,
+			expectServiceCall:    false,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError",
+			userIDCtxValue:       int64(1),
+			expectedStatus:       http.StatusInternalServerError,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+		{
+			name:                 "ServiceError Too Many Requests",
+			userIDCtxValue:       int64(1),
+			expectedStatus:       http.StatusTooManyRequests,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewError(commonErr.TooManyVerificationCodesErrorMessage, commonErr.TooManyRequestsErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/profile/phone/verify/resend", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().ResendPhoneVerification(gomock.Any(), int64(1)).Return(tc.expectedServiceError)
+			}
+
+			mockServer.ResendPhoneVerification(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) ResetPassword(ctx echo.Context) error {
+	request := new(generated.ResetPasswordRequest)
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	errSvc := s.Service.ResetPassword(ctx.Request().Context(), service.ResetPasswordParam{
+		PhoneNumber: request.PhoneNumber,
+		Code:        request.Code,
+		NewPassword: request.NewPassword,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
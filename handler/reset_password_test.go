@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestResetPassword(t *testing.T) {
+	body := `{"phoneNumber": "+628123456789", "code": "123456", "newPassword": "Maulana1997@"}`
+
+	tests := []struct {
+		name                 string
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceError common.Error
+	}{
+		{
+			name:                 "Success",
+			requestBody:          body,
+			expectedStatus:       http.StatusNoContent,
+			expectServiceCall:    true,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "BadRequest",
+			requestBody:          "Invalid JSON",
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    false,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError Invalid Code",
+			requestBody:          body,
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:                 "ServiceError Too Many Attempts",
+			requestBody:          body,
+			expectedStatus:       http.StatusTooManyRequests,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewError(commonErr.TooManyVerificationAttemptsErrorMessage, commonErr.TooManyRequestsErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/password/reset", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().ResetPassword(gomock.Any(), service.ResetPasswordParam{
+					PhoneNumber: "+628123456789",
+					Code:        "123456",
+					NewPassword: "Maulana1997@",
+				}).Return(tc.expectedServiceError)
+			}
+
+			mockServer.ResetPassword(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+)
+
+func (s *Server) SetupTotp(ctx echo.Context) error {
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		return handleForbiddenAccessJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.SetupTOTP(ctx.Request().Context(), userID)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleSuccessJSON(ctx, &generated.TOTPSetupResponse{
+		Secret:     resp.Secret,
+		OtpauthUri: resp.URI,
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestSetupTotp(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userIDCtxValue       interface{}
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.SetupTOTPResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                 "Success",
+			userIDCtxValue:       int64(1),
+			expectedStatus:       http.StatusOK,
+			expectServiceCall:    true,
+			expectedServiceResp:  &service.SetupTOTPResponse{Secret: "SECRET", URI: "otpauth://totp/SawitPro:1?secret=SECRET"},
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ForbiddenAccess",
+			userIDCtxValue:       "invalid",
+			expectedStatus:       http.StatusForbidden,
+			expectServiceCall:    false,
+			expectedServiceResp:  nil,
+			expectedServiceError: nil,
+		},
+		{
+			name:                 "ServiceError Conflict",
+			userIDCtxValue:       int64(1),
+			expectedStatus:       http.StatusConflict,
+			expectServiceCall:    true,
+			expectedServiceResp:  nil,
+			expectedServiceError: commonErr.NewError("any", commonErr.ConflictErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/profile/mfa/totp", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(common.USER_ID_CTX_KEY, tc.userIDCtxValue)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().SetupTOTP(gomock.Any(), int64(1)).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.SetupTotp(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) SuspendUser(ctx echo.Context, userId generated.UserId) error {
+	request := &generated.SuspendUserRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	errSvc := s.Service.SuspendUser(ctx.Request().Context(), service.SuspendUserParam{
+		UserID: userId,
+		Reason: request.Reason,
+	})
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
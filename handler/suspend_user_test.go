@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestSuspendUser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceError common.Error
+	}{
+		{
+			name:              "Success",
+			requestBody:       `{"reason": "chargeback fraud"}`,
+			expectedStatus:    http.StatusNoContent,
+			expectServiceCall: true,
+		},
+		{
+			name:              "BadRequest JSON",
+			requestBody:       `Invalid JSON`,
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "StatusConflict",
+			requestBody:          `{"reason": "chargeback fraud"}`,
+			expectedStatus:       http.StatusConflict,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.AccountStatusConflictErrorMessage, commonErr.ConflictErrorType, commonErr.AccountStatusConflictErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/1/suspend", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().SuspendUser(gomock.Any(), service.SuspendUserParam{
+					UserID: 1,
+					Reason: "chargeback fraud",
+				}).Return(tc.expectedServiceError)
+			}
+
+			mockServer.SuspendUser(c, 1)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+)
+
+func (s *Server) UnsuspendUser(ctx echo.Context, userId generated.UserId) error {
+	errSvc := s.Service.UnsuspendUser(ctx.Request().Context(), userId)
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	return handleNoContent(ctx)
+}
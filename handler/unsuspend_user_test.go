@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+)
+
+func TestUnsuspendUser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expectedStatus       int
+		expectedServiceError common.Error
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:                 "NotFound",
+			expectedStatus:       http.StatusNotFound,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType, commonErr.UserDataNotFoundErrorCode),
+		},
+		{
+			name:                 "NotSuspended",
+			expectedStatus:       http.StatusConflict,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.AccountStatusConflictErrorMessage, commonErr.ConflictErrorType, commonErr.AccountStatusConflictErrorCode),
+		},
+		{
+			name:                 "ServiceInternalError",
+			expectedStatus:       http.StatusInternalServerError,
+			expectedServiceError: commonErr.NewError("any", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/1/unsuspend", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockService.EXPECT().UnsuspendUser(gomock.Any(), int64(1)).Return(tc.expectedServiceError)
+
+			mockServer.UnsuspendUser(c, 1)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -44,8 +44,14 @@ func (s *Server) UpdateProfile(ctx echo.Context) error {
 		return handleServiceError(ctx, errSvc)
 	}
 
-	return handleSuccessJSON(ctx, &generated.UpdateProfileResponse{
+	response := &generated.UpdateProfileResponse{
 		FullName:    resp.FullName,
 		PhoneNumber: resp.PhoneNumber,
-	})
+	}
+
+	if resp.PendingPhoneNumber != "" {
+		response.PendingPhoneNumber = &resp.PendingPhoneNumber
+	}
+
+	return handleSuccessJSON(ctx, response)
 }
@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/sawitpro/UserService/common"
 	cmnErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/common/i18n"
 	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
 )
 
 func getErrorHttpStatusCode(err common.Error) int {
@@ -19,6 +23,16 @@ func getErrorHttpStatusCode(err common.Error) int {
 		code = http.StatusBadRequest
 	case cmnErr.ConflictErrorType:
 		code = http.StatusConflict
+	case cmnErr.UnauthorizedErrorType:
+		code = http.StatusUnauthorized
+	case cmnErr.TooManyRequestsErrorType:
+		code = http.StatusTooManyRequests
+	case cmnErr.LockedErrorType:
+		code = http.StatusLocked
+	case cmnErr.ForbiddenErrorType:
+		code = http.StatusForbidden
+	case cmnErr.NotFoundErrorType:
+		code = http.StatusNotFound
 	case cmnErr.SystemErrorType:
 		code = http.StatusInternalServerError
 	}
@@ -27,24 +41,57 @@ func getErrorHttpStatusCode(err common.Error) int {
 }
 
 func handleServiceError(ctx echo.Context, err common.Error) error {
+	message, ok := i18n.Translate(requestLanguage(ctx), err.GetErrorCode(), err.GetErrorArgs()...)
+	if !ok {
+		message = err.GetErrorMessage()
+	}
+
 	return ctx.JSON(
 		getErrorHttpStatusCode(err),
-		&generated.ErrorResponse{ErrorMsg: err.GetErrorMessage()},
+		&generated.ErrorResponse{
+			ErrorCode: int64(err.GetErrorCode()),
+			ErrorMsg:  message,
+		},
 	)
 }
 
 func handleBadRequestJSON(ctx echo.Context, err error) error {
-	return ctx.JSON(http.StatusBadRequest, &generated.ErrorResponse{ErrorMsg: err.Error()})
+	ctx.Logger().Debug(err)
+	message, _ := i18n.Translate(requestLanguage(ctx), cmnErr.ValidationErrorCode)
+
+	return ctx.JSON(http.StatusBadRequest, &generated.ErrorResponse{
+		ErrorCode: int64(cmnErr.ValidationErrorCode),
+		ErrorMsg:  message,
+	})
 }
 
 func handleForbiddenAccessJSON(ctx echo.Context, err error) error {
-	return ctx.JSON(http.StatusForbidden, &generated.ErrorResponse{ErrorMsg: err.Error()})
+	ctx.Logger().Debug(err)
+	message, _ := i18n.Translate(requestLanguage(ctx), cmnErr.ForbiddenErrorCode)
+
+	return ctx.JSON(http.StatusForbidden, &generated.ErrorResponse{
+		ErrorCode: int64(cmnErr.ForbiddenErrorCode),
+		ErrorMsg:  message,
+	})
+}
+
+// requestLanguage returns the language resolved by LanguageMiddleware,
+// parsing Accept-Language itself for requests that skipped it.
+func requestLanguage(ctx echo.Context) i18n.Language {
+	if lang, ok := ctx.Get(common.LANGUAGE_CTX_KEY).(i18n.Language); ok {
+		return lang
+	}
+	return i18n.ParseAcceptLanguage(ctx.Request().Header.Get(headerAcceptLanguage), i18n.English)
 }
 
 func handleSuccessJSON(ctx echo.Context, body interface{}) error {
 	return ctx.JSON(http.StatusOK, body)
 }
 
+func handleNoContent(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNoContent)
+}
+
 func getContextUserID(ctx echo.Context) (int64, error) {
 	userID, ok := ctx.Get(common.USER_ID_CTX_KEY).(int64)
 
@@ -55,6 +102,86 @@ func getContextUserID(ctx echo.Context) (int64, error) {
 	return userID, nil
 }
 
+func getContextSessionID(ctx echo.Context) (int64, error) {
+	sessionID, ok := ctx.Get(common.SESSION_ID_CTX_KEY).(int64)
+	if !ok {
+		return 0, errors.New("error session ID Format")
+	}
+
+	return sessionID, nil
+}
+
+func getContextTokenID(ctx echo.Context) (string, time.Time, error) {
+	tokenID, ok := ctx.Get(common.TOKEN_ID_CTX_KEY).(string)
+	if !ok {
+		return "", time.Time{}, errors.New("error token ID Format")
+	}
+
+	expiresAt, ok := ctx.Get(common.TOKEN_EXPIRES_AT_CTX_KEY).(time.Time)
+	if !ok {
+		return "", time.Time{}, errors.New("error token expiry Format")
+	}
+
+	return tokenID, expiresAt, nil
+}
+
+func toAdminUserJSON(user service.AdminUser) generated.AdminUser {
+	return generated.AdminUser{
+		Id:                  user.ID,
+		FullName:            user.FullName,
+		PhoneNumber:         user.PhoneNumber,
+		PhoneVerified:       user.PhoneVerified,
+		LoginCount:          user.LoginCount,
+		FailedLoginCount:    user.FailedLoginCount,
+		LockedUntil:         user.LockedUntil,
+		Status:              generated.AdminUserStatus(user.Status),
+		StatusReason:        user.StatusReason,
+		StatusChangedAt:     user.StatusChangedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}
+}
+
+func toUserActivityJSON(activity service.UserActivity) generated.UserActivity {
+	return generated.UserActivity{
+		ActivityType: activity.ActivityType,
+		IpAddress:    optionalString(activity.IPAddress),
+		UserAgent:    optionalString(activity.UserAgent),
+		DeviceFamily: optionalString(activity.DeviceFamily),
+		OsFamily:     optionalString(activity.OSFamily),
+		RequestId:    optionalString(activity.RequestID),
+		CreatedAt:    activity.CreatedAt,
+	}
+}
+
+func toUserSessionJSON(session service.UserSession) generated.UserSession {
+	return generated.UserSession{
+		Id:         session.ID,
+		DeviceName: session.DeviceName,
+		IpAddress:  optionalString(session.IPAddress),
+		UserAgent:  optionalString(session.UserAgent),
+		LastSeenAt: session.LastSeenAt,
+		RevokedAt:  session.RevokedAt,
+		CreatedAt:  session.CreatedAt,
+	}
+}
+
+// optionalString leaves empty values out of the JSON response.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// decodeBase64URL decodes a binary WebAuthn value, which browsers encode as
+// base64url with or without padding.
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
 func getBearerToken(ctx echo.Context) string {
 	const authPrefix = "Bearer "
 	authHeader := ctx.Request().Header.Get("Authorization")
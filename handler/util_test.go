@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	cmnErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/common/i18n"
+	"github.com/sawitpro/UserService/generated"
+)
+
+func TestHandleServiceError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             common.Error
+		acceptLanguage  string
+		expectedStatus  int
+		expectedCode    common.ErrorCode
+		expectedMessage string
+	}{
+		{
+			name:            "Business Error",
+			err:             cmnErr.NewErrorWithCode(cmnErr.WrongPhonePasswordErrorMessage, cmnErr.BadRequestErrorType, cmnErr.WrongPhonePasswordErrorCode),
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    cmnErr.WrongPhonePasswordErrorCode,
+			expectedMessage: cmnErr.WrongPhonePasswordErrorMessage,
+		},
+		{
+			name:            "Business Error In Indonesian",
+			err:             cmnErr.NewErrorWithCode(cmnErr.WrongPhonePasswordErrorMessage, cmnErr.BadRequestErrorType, cmnErr.WrongPhonePasswordErrorCode),
+			acceptLanguage:  "id-ID",
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    cmnErr.WrongPhonePasswordErrorCode,
+			expectedMessage: "kata sandi atau nomor telepon salah.",
+		},
+		{
+			name:            "Error With Args In Indonesian",
+			err:             cmnErr.NewErrorWithCode(cmnErr.NewPhoneAlreadyUsedErrorMessage("+628232482440"), cmnErr.ConflictErrorType, cmnErr.PhoneAlreadyUsedErrorCode, "+628232482440"),
+			acceptLanguage:  "id",
+			expectedStatus:  http.StatusConflict,
+			expectedCode:    cmnErr.PhoneAlreadyUsedErrorCode,
+			expectedMessage: "nomor telepon +628232482440 sudah digunakan.",
+		},
+		{
+			name: "Error With Localizable Args In Indonesian",
+			err: cmnErr.NewErrorWithCode("password must contain a digit.", cmnErr.BadRequestErrorType, cmnErr.PasswordPolicyErrorCode,
+				i18n.Phrases{i18n.NewPhrase(i18n.PasswordMinLengthPhrase, 6), i18n.NewPhrase(i18n.PasswordDigitPhrase)}),
+			acceptLanguage:  "id",
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    cmnErr.PasswordPolicyErrorCode,
+			expectedMessage: "kata sandi harus terdiri dari minimal 6 karakter, mengandung angka.",
+		},
+		{
+			name:            "Account Locked",
+			err:             cmnErr.NewErrorWithCode(cmnErr.AccountLockedErrorMessage, cmnErr.LockedErrorType, cmnErr.AccountLockedErrorCode),
+			expectedStatus:  http.StatusLocked,
+			expectedCode:    cmnErr.AccountLockedErrorCode,
+			expectedMessage: cmnErr.AccountLockedErrorMessage,
+		},
+		{
+			name:            "System Error",
+			err:             cmnErr.NewError("some error", cmnErr.SystemErrorType),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedCode:    cmnErr.SystemErrorCode,
+			expectedMessage: "something went wrong, please try again later.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handleServiceError(c, tc.err))
+
+			var body generated.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, int64(tc.expectedCode), body.ErrorCode)
+			assert.Equal(t, tc.expectedMessage, body.ErrorMsg)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sawitpro/UserService/generated"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Server) VerifyLoginOtp(ctx echo.Context) error {
+	request := new(generated.LoginOTPVerifyRequest)
+	if err := ctx.Bind(request); err != nil {
+		return handleBadRequestJSON(ctx, err)
+	}
+
+	resp, errSvc := s.Service.VerifyLoginCode(ctx.Request().Context(), service.VerifyLoginCodeParam{
+		PhoneNumber: request.PhoneNumber,
+		Code:        request.Code,
+	})
+
+	if errSvc != nil {
+		return handleServiceError(ctx, errSvc)
+	}
+
+	if resp.MFARequired {
+		return ctx.JSON(http.StatusAccepted, &generated.MFAChallengeResponse{
+			Status:   generated.MfaRequired,
+			MfaToken: resp.MFAToken,
+		})
+	}
+
+	return handleSuccessJSON(ctx, &generated.LoginResponse{
+		UserID:       resp.UserID,
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
+	})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	mock_service "github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestVerifyLoginOtp(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		expectedStatus       int
+		expectServiceCall    bool
+		expectedServiceResp  *service.LoginResponse
+		expectedServiceError common.Error
+	}{
+		{
+			name:                "Success",
+			requestBody:         `{"phoneNumber": "+628123456789", "code": "123456"}`,
+			expectedStatus:      http.StatusOK,
+			expectServiceCall:   true,
+			expectedServiceResp: &service.LoginResponse{UserID: 1, Token: "token", RefreshToken: "refresh"},
+		},
+		{
+			name:                "MFA Required",
+			requestBody:         `{"phoneNumber": "+628123456789", "code": "123456"}`,
+			expectedStatus:      http.StatusAccepted,
+			expectServiceCall:   true,
+			expectedServiceResp: &service.LoginResponse{UserID: 1, MFARequired: true, MFAToken: "mfa token"},
+		},
+		{
+			name:              "BadRequest",
+			requestBody:       "Invalid JSON",
+			expectedStatus:    http.StatusBadRequest,
+			expectServiceCall: false,
+		},
+		{
+			name:                 "InvalidCode",
+			requestBody:          `{"phoneNumber": "+628123456789", "code": "123456"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType, commonErr.InvalidVerificationCodeErrorCode),
+		},
+		{
+			name:                 "TooManyAttempts",
+			requestBody:          `{"phoneNumber": "+628123456789", "code": "123456"}`,
+			expectedStatus:       http.StatusTooManyRequests,
+			expectServiceCall:    true,
+			expectedServiceError: commonErr.NewErrorWithCode(commonErr.TooManyVerificationAttemptsErrorMessage, commonErr.TooManyRequestsErrorType, commonErr.TooManyVerificationAttemptsErrorCode),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_service.NewMockServiceInterface(ctrl)
+			mockServer := &Server{
+				Service: mockService,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/auth/otp/verify", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if tc.expectServiceCall {
+				mockService.EXPECT().VerifyLoginCode(gomock.Any(), service.VerifyLoginCodeParam{PhoneNumber: "+628123456789", Code: "123456"}).Return(tc.expectedServiceResp, tc.expectedServiceError)
+			}
+
+			mockServer.VerifyLoginOtp(c)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
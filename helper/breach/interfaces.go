@@ -0,0 +1,9 @@
+package breach
+
+import "context"
+
+// Checker reports how many times a password appears in known breach
+// corpora. Zero means it was not found.
+type Checker interface {
+	BreachCount(ctx context.Context, password string) (int64, error)
+}
@@ -0,0 +1,72 @@
+package rangefile
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/sawitpro/UserService/helper/breach"
+)
+
+const prefixLength = 5
+
+//go:embed sample/*.txt
+var sample embed.FS
+
+// rangeFileChecker looks passwords up in a local copy of the Have I Been
+// Pwned range files: one file per 5 character SHA-1 prefix, named
+// <PREFIX>.txt, with one SUFFIX:COUNT line per breached hash. Only the
+// prefix file is ever read, so the full dataset never has to fit in memory.
+type rangeFileChecker struct {
+	fsys fs.FS
+}
+
+func NewChecker(fsys fs.FS) breach.Checker {
+	return &rangeFileChecker{
+		fsys: fsys,
+	}
+}
+
+// NewSampleChecker reads a handful of embedded range files, enough for
+// tests and local development without downloading the dataset.
+func NewSampleChecker() breach.Checker {
+	fsys, _ := fs.Sub(sample, "sample")
+	return NewChecker(fsys)
+}
+
+func (c *rangeFileChecker) BreachCount(ctx context.Context, password string) (int64, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	f, err := c.fsys.Open(prefix + ".txt")
+	if err != nil {
+		// A partial dataset, such as the sample, lacks most prefixes.
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineSuffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(lineSuffix, suffix) {
+			continue
+		}
+		return strconv.ParseInt(count, 10, 64)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,64 @@
+package rangefile
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleBreachCount(t *testing.T) {
+	checker := NewSampleChecker()
+
+	testCases := []struct {
+		name          string
+		password      string
+		expectedCount int64
+	}{
+		{"Common password", "Password1!", 2404732},
+		{"Rare breached password", "Sawitpro@123", 3},
+		{"Prefix file missing", "Kebun#Sawit42", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			count, err := checker.BreachCount(context.Background(), tc.password)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestBreachCount(t *testing.T) {
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+	testCases := []struct {
+		name          string
+		content       string
+		expectedCount int64
+		expectError   bool
+	}{
+		{"Found", "003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\r\n", 9659365, false},
+		{"Lowercase suffix", "1e4c9b93f3f0682250b6cf8331b7ee68fd8:10\n", 10, false},
+		{"Not found", "003D68EB55068C33ACE09247EE4C639306B:3\n", 0, false},
+		{"Malformed count", "1E4C9B93F3F0682250B6CF8331B7EE68FD8:many\n", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker(fstest.MapFS{
+				"5BAA6.txt": {Data: []byte(tc.content)},
+			})
+
+			count, err := checker.BreachCount(context.Background(), "password")
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package argon2id
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sawitpro/UserService/helper/hasher"
+	"golang.org/x/crypto/argon2"
+)
+
+const Algorithm = "argon2id"
+
+var (
+	ErrInvalidHash               = errors.New("argon2id: hash is not in the expected format")
+	ErrIncompatibleVersion       = errors.New("argon2id: incompatible version of argon2")
+	ErrMismatchedHashAndPassword = errors.New("argon2id: hashedPassword is not the hash of the given password")
+)
+
+// Params are the argon2id cost parameters. Memory is in KiB.
+type Params struct {
+	Memory      uint32
+	Time        uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultParams follows the OWASP recommendation of 64 MiB of memory and one
+// pass over it.
+func DefaultParams() Params {
+	return Params{
+		Memory:      64 * 1024,
+		Time:        1,
+		Parallelism: 4,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+type argon2idhash struct {
+	params   Params
+	saltFunc func(n uint32) ([]byte, error)
+}
+
+func NewHasher(params Params) hasher.PasswordHasher {
+	return &argon2idhash{
+		params:   params,
+		saltFunc: randomSalt,
+	}
+}
+
+// HashPassword encodes the hash in the PHC string format, for example
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>.
+func (h *argon2idhash) HashPassword(password string) (string, error) {
+	salt, err := h.saltFunc(h.params.SaltLength)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Time, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Algorithm,
+		argon2.Version,
+		h.params.Memory,
+		h.params.Time,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idhash) CompareHashAndPassword(hashedPassword, password []byte) error {
+	params, salt, key, err := decodeHash(string(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	otherKey := argon2.IDKey(password, salt, params.Time, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+
+	return nil
+}
+
+func (h *argon2idhash) NeedsRehash(hashedPassword []byte) bool {
+	params, _, _, err := decodeHash(string(hashedPassword))
+	if err != nil {
+		return true
+	}
+
+	return params.Memory < h.params.Memory ||
+		params.Time < h.params.Time ||
+		params.Parallelism < h.params.Parallelism ||
+		params.SaltLength < h.params.SaltLength ||
+		params.KeyLength < h.params.KeyLength
+}
+
+func decodeHash(encoded string) (Params, []byte, []byte, error) {
+	var params Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != Algorithm {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, ErrIncompatibleVersion
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	params.SaltLength = uint32(len(salt))
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+func randomSalt(n uint32) ([]byte, error) {
+	salt := make([]byte, n)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
@@ -0,0 +1,105 @@
+package argon2id
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testParams keeps the tests fast; production uses DefaultParams.
+var testParams = Params{
+	Memory:      1024,
+	Time:        1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHashPassword(t *testing.T) {
+	testCases := []struct {
+		TestName    string
+		Password    string
+		ExpectError bool
+	}{
+		{"Short password", "short", false},
+		{"Long password", "thisisalongpassword", false},
+		{"Empty password", "", false},
+		{"Salt error", "password123", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			hasher := &argon2idhash{
+				params:   testParams,
+				saltFunc: randomSalt,
+			}
+			if tc.ExpectError {
+				hasher.saltFunc = func(n uint32) ([]byte, error) {
+					return nil, errors.New("sample error")
+				}
+			}
+
+			hash, err := hasher.HashPassword(tc.Password)
+
+			if tc.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+			}
+		})
+	}
+}
+
+func TestCompareHashAndPassword(t *testing.T) {
+	hasher := NewHasher(testParams)
+
+	hash, err := hasher.HashPassword("password123")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		hash          string
+		password      string
+		expectedError error
+	}{
+		{"Matching password", hash, "password123", nil},
+		{"Wrong password", hash, "password124", ErrMismatchedHashAndPassword},
+		{"Bcrypt hash", "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", "password123", ErrInvalidHash},
+		{"Other version", strings.Replace(hash, "v=19", "v=16", 1), "password123", ErrIncompatibleVersion},
+		{"Bad salt", "$argon2id$v=19$m=1024,t=1,p=1$!!!$aGFzaA", "password123", ErrInvalidHash},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := hasher.CompareHashAndPassword([]byte(tc.hash), []byte(tc.password))
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hash, _ := NewHasher(testParams).HashPassword("password123")
+
+	stronger := testParams
+	stronger.Memory = 2048
+
+	testCases := []struct {
+		name     string
+		params   Params
+		hash     string
+		expected bool
+	}{
+		{"Same parameters", testParams, hash, false},
+		{"Weaker than current parameters", stronger, hash, true},
+		{"Not an argon2id hash", testParams, "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewHasher(tc.params).NeedsRehash([]byte(tc.hash)))
+		})
+	}
+}
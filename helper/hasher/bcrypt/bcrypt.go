@@ -5,18 +5,26 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const Algorithm = "bcrypt"
+
 type bcrypthash struct {
 	hashFunc func(password []byte, cost int) ([]byte, error)
+	cost     int
 }
 
 func NewHasher() hasher.PasswordHasher {
+	return NewHasherWithCost(bcrypt.DefaultCost)
+}
+
+func NewHasherWithCost(cost int) hasher.PasswordHasher {
 	return &bcrypthash{
 		hashFunc: bcrypt.GenerateFromPassword,
+		cost:     cost,
 	}
 }
 
 func (h *bcrypthash) HashPassword(password string) (string, error) {
-	hash, err := h.hashFunc([]byte(password), bcrypt.DefaultCost)
+	hash, err := h.hashFunc([]byte(password), h.cost)
 	if err != nil {
 		return "", err
 	}
@@ -26,3 +34,11 @@ func (h *bcrypthash) HashPassword(password string) (string, error) {
 func (h *bcrypthash) CompareHashAndPassword(hashedPassword, password []byte) error {
 	return bcrypt.CompareHashAndPassword(hashedPassword, password)
 }
+
+func (h *bcrypthash) NeedsRehash(hashedPassword []byte) bool {
+	cost, err := bcrypt.Cost(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return cost < h.cost
+}
@@ -25,6 +25,7 @@ func TestHashPassword(t *testing.T) {
 		t.Run(tc.TestName, func(t *testing.T) {
 			hasher := &bcrypthash{
 				hashFunc: bcrypt.GenerateFromPassword,
+				cost:     bcrypt.MinCost,
 			}
 			if tc.ExpectError {
 				hasher.hashFunc = func(password []byte, cost int) ([]byte, error) {
@@ -59,6 +60,7 @@ func TestNewHasher(t *testing.T) {
 	expectedFunc := reflect.ValueOf(bcrypt.GenerateFromPassword)
 	actualFunc := reflect.ValueOf(bcryptHasher.hashFunc)
 	assert.Equal(t, expectedFunc.Pointer(), actualFunc.Pointer())
+	assert.Equal(t, bcrypt.DefaultCost, bcryptHasher.cost)
 }
 
 func TestCompareHashAndPassword(t *testing.T) {
@@ -72,3 +74,26 @@ func TestCompareHashAndPassword(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestNeedsRehash(t *testing.T) {
+	lowCostHash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	defaultCostHash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+
+	testCases := []struct {
+		name     string
+		hash     []byte
+		expected bool
+	}{
+		{"Lower cost", lowCostHash, true},
+		{"Same cost", defaultCostHash, false},
+		{"Not a bcrypt hash", []byte("$argon2id$v=19$m=65536,t=1,p=4$c2FsdA$aGFzaA"), true},
+	}
+
+	hasher := NewHasher()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hasher.NeedsRehash(tc.hash))
+		})
+	}
+}
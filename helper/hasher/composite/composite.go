@@ -0,0 +1,68 @@
+package composite
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sawitpro/UserService/helper/hasher"
+	"github.com/sawitpro/UserService/helper/hasher/argon2id"
+	"github.com/sawitpro/UserService/helper/hasher/bcrypt"
+)
+
+var ErrUnknownAlgorithm = errors.New("composite: hashedPassword uses an unknown algorithm")
+
+type HasherOpts struct {
+	// Primary is the algorithm new hashes are made with.
+	Primary string
+	// Hashers maps an algorithm name, such as bcrypt.Algorithm or
+	// argon2id.Algorithm, to the hasher able to verify it.
+	Hashers map[string]hasher.PasswordHasher
+}
+
+// compositehash hashes with one algorithm and verifies any algorithm it has
+// a hasher for, picked from the prefix of the stored hash.
+type compositehash struct {
+	primary string
+	hashers map[string]hasher.PasswordHasher
+}
+
+func NewHasher(opts HasherOpts) hasher.PasswordHasher {
+	return &compositehash{
+		primary: opts.Primary,
+		hashers: opts.Hashers,
+	}
+}
+
+func (h *compositehash) HashPassword(password string) (string, error) {
+	primary, ok := h.hashers[h.primary]
+	if !ok {
+		return "", ErrUnknownAlgorithm
+	}
+	return primary.HashPassword(password)
+}
+
+func (h *compositehash) CompareHashAndPassword(hashedPassword, password []byte) error {
+	verifier, ok := h.hashers[algorithmOf(hashedPassword)]
+	if !ok {
+		return ErrUnknownAlgorithm
+	}
+	return verifier.CompareHashAndPassword(hashedPassword, password)
+}
+
+func (h *compositehash) NeedsRehash(hashedPassword []byte) bool {
+	if algorithmOf(hashedPassword) != h.primary {
+		return true
+	}
+	return h.hashers[h.primary].NeedsRehash(hashedPassword)
+}
+
+func algorithmOf(hashedPassword []byte) string {
+	hash := string(hashedPassword)
+	switch {
+	case strings.HasPrefix(hash, "$"+argon2id.Algorithm+"$"):
+		return argon2id.Algorithm
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return bcrypt.Algorithm
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package composite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/sawitpro/UserService/helper/hasher"
+	"github.com/sawitpro/UserService/helper/hasher/argon2id"
+	bcrypthasher "github.com/sawitpro/UserService/helper/hasher/bcrypt"
+)
+
+func newTestHasher() hasher.PasswordHasher {
+	return NewHasher(HasherOpts{
+		Primary: argon2id.Algorithm,
+		Hashers: map[string]hasher.PasswordHasher{
+			argon2id.Algorithm:     argon2id.NewHasher(argon2id.Params{Memory: 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}),
+			bcrypthasher.Algorithm: bcrypthasher.NewHasherWithCost(bcrypt.MinCost),
+		},
+	})
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, err := newTestHasher().HashPassword("password123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, argon2id.Algorithm, algorithmOf([]byte(hash)))
+
+	_, err = NewHasher(HasherOpts{Primary: "scrypt"}).HashPassword("password123")
+	assert.Equal(t, ErrUnknownAlgorithm, err)
+}
+
+func TestCompareHashAndPassword(t *testing.T) {
+	h := newTestHasher()
+	argonHash, _ := h.HashPassword("password123")
+	bcryptHash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	testCases := []struct {
+		name        string
+		hash        []byte
+		password    string
+		expectError bool
+	}{
+		{"Argon2id match", []byte(argonHash), "password123", false},
+		{"Argon2id mismatch", []byte(argonHash), "password124", true},
+		{"Bcrypt match", bcryptHash, "password123", false},
+		{"Bcrypt mismatch", bcryptHash, "password124", true},
+		{"Unknown algorithm", []byte("plaintext"), "plaintext", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := h.CompareHashAndPassword(tc.hash, []byte(tc.password))
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	h := newTestHasher()
+	argonHash, _ := h.HashPassword("password123")
+	bcryptHash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	assert.False(t, h.NeedsRehash([]byte(argonHash)))
+	assert.True(t, h.NeedsRehash(bcryptHash))
+	assert.True(t, h.NeedsRehash([]byte("plaintext")))
+}
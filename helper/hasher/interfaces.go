@@ -3,4 +3,7 @@ package hasher
 type PasswordHasher interface {
 	HashPassword(password string) (string, error)
 	CompareHashAndPassword(hashedPassword, password []byte) error
+	// NeedsRehash reports whether a stored hash was made with an algorithm
+	// or parameters weaker than the ones HashPassword uses now.
+	NeedsRehash(hashedPassword []byte) bool
 }
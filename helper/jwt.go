@@ -16,13 +16,34 @@ import (
 
 type Claims struct {
 	UserID int64 `json:"userID"`
+	// Purpose is empty for access tokens. Tokens issued for an intermediate
+	// step, such as a pending two-factor login, name that step instead and
+	// are never accepted as access tokens.
+	Purpose string `json:"purpose,omitempty"`
+	// SessionID is the login session the access token belongs to.
+	SessionID int64 `json:"sid,omitempty"`
+	// Roles and Permissions are the user's access when the token was
+	// issued, so a change applies from the next login or token refresh.
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// Challenge is the WebAuthn challenge of a pending passkey ceremony.
+	Challenge string `json:"challenge,omitempty"`
+	// IssuedAtMicro is the issue time in microseconds. The standard iat
+	// claim only has whole seconds, which cannot tell a token issued just
+	// before revoking all the user's tokens from one issued just after.
+	IssuedAtMicro int64 `json:"iat_us,omitempty"`
 	jwt.StandardClaims
 }
 
+const (
+	mfaTokenPurpose                 = "mfa"
+	passkeyRegistrationTokenPurpose = "passkey_registration"
+	passkeyLoginTokenPurpose        = "passkey_login"
+)
+
 var (
-	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
-	once       sync.Once
+	keyring *Keyring
+	once    sync.Once
 )
 
 func init() {
@@ -30,22 +51,70 @@ func init() {
 }
 
 func initializeKeys() {
+	var err error
+	keyring, err = loadKeyring()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// loadKeyring builds the keyring from the environment. JWT_PRIVATE_KEY and
+// JWT_PUBLIC_KEY hold one base64 encoded keypair, optionally named by
+// JWT_KEY_ID, and JWT_KEYS_DIR points to a directory of PEM files. The key
+// named by JWT_ACTIVE_KEY_ID signs new tokens; by default that is the env
+// keypair, or else the signing key in the directory whose kid sorts last.
+// Without any configured key a random keypair is generated.
+func loadKeyring() (*Keyring, error) {
+	ring := NewKeyring()
+	activeID := os.Getenv("JWT_ACTIVE_KEY_ID")
+
 	privateKeyEnv := os.Getenv("JWT_PRIVATE_KEY")
 	publicKeyEnv := os.Getenv("JWT_PUBLIC_KEY")
 
 	if privateKeyEnv != "" && publicKeyEnv != "" {
-		var err error
-		privateKey, publicKey, err = decodeKeys(privateKeyEnv, publicKeyEnv)
+		privateKey, publicKey, err := decodeKeys(privateKeyEnv, publicKeyEnv)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-	} else {
-		var err error
-		privateKey, publicKey, err = generateKeys()
+
+		kid, err := ring.AddKey(os.Getenv("JWT_KEY_ID"), privateKey, publicKey)
 		if err != nil {
-			panic(err)
+			return nil, err
+		}
+
+		if activeID == "" {
+			activeID = kid
 		}
 	}
+
+	if keysDir := os.Getenv("JWT_KEYS_DIR"); keysDir != "" {
+		signingIDs, err := ring.LoadDir(keysDir)
+		if err != nil {
+			return nil, err
+		}
+
+		if activeID == "" && len(signingIDs) > 0 {
+			activeID = signingIDs[len(signingIDs)-1]
+		}
+	}
+
+	if activeID == "" {
+		privateKey, publicKey, err := generateKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		activeID, err = ring.AddKey("", privateKey, publicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ring.SetActiveKey(activeID); err != nil {
+		return nil, err
+	}
+
+	return ring, nil
 }
 
 func decodeKeys(privateKeyEnv, publicKeyEnv string) (*rsa.PrivateKey, *rsa.PublicKey, error) {
@@ -106,34 +175,108 @@ func generateKeys() (*rsa.PrivateKey, *rsa.PublicKey, error) {
 	return privateKey, publicKey, nil
 }
 
-func CreateToken(userID int64, expiresIn time.Duration) (string, error) {
+func CreateToken(userID, sessionID int64, roles, permissions []string, expiresIn time.Duration) (string, error) {
+	return createToken(userID, sessionID, roles, permissions, "", expiresIn)
+}
+
+// CreateMFAToken issues the challenge token returned by a password login
+// that still needs a second factor.
+func CreateMFAToken(userID int64, expiresIn time.Duration) (string, error) {
+	return createToken(userID, 0, nil, nil, mfaTokenPurpose, expiresIn)
+}
+
+// CreatePasskeyRegistrationToken issues the ceremony token that carries the
+// challenge of a passkey registration started by the user.
+func CreatePasskeyRegistrationToken(userID int64, challenge string, expiresIn time.Duration) (string, error) {
+	return signToken(Claims{
+		UserID:    userID,
+		Purpose:   passkeyRegistrationTokenPurpose,
+		Challenge: challenge,
+	}, expiresIn)
+}
+
+// CreatePasskeyLoginToken issues the ceremony token that carries the
+// challenge of a passkey login. The user is only known once the
+// authenticator has picked a credential.
+func CreatePasskeyLoginToken(challenge string, expiresIn time.Duration) (string, error) {
+	return signToken(Claims{
+		Purpose:   passkeyLoginTokenPurpose,
+		Challenge: challenge,
+	}, expiresIn)
+}
+
+func createToken(userID, sessionID int64, roles, permissions []string, purpose string, expiresIn time.Duration) (string, error) {
+	return signToken(Claims{
+		UserID:      userID,
+		Purpose:     purpose,
+		SessionID:   sessionID,
+		Roles:       roles,
+		Permissions: permissions,
+	}, expiresIn)
+}
+
+// IssuedTime returns when the token was issued, falling back to the whole
+// second of the iat claim for tokens signed without iat_us.
+func (c *Claims) IssuedTime() time.Time {
+	if c.IssuedAtMicro != 0 {
+		return time.UnixMicro(c.IssuedAtMicro)
+	}
+	return time.Unix(c.IssuedAt, 0)
+}
+
+// signToken sets the ID, issue and expiry time of claims and signs them
+// with the active key.
+func signToken(claims Claims, expiresIn time.Duration) (string, error) {
 	now := time.Now()
 	exp := now.Add(expiresIn)
 
-	claims := Claims{
-		UserID: userID,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: exp.Unix(),
-			IssuedAt:  now.Unix(),
-		},
+	tokenID, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	return token.SignedString(privateKey)
+	claims.StandardClaims = jwt.StandardClaims{
+		Id:        tokenID,
+		ExpiresAt: exp.Unix(),
+		IssuedAt:  now.Unix(),
+	}
+	claims.IssuedAtMicro = now.UnixMicro()
+
+	return keyring.Sign(claims)
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return publicKey, nil
-	})
+	return validateToken(tokenString, "")
+}
+
+func ValidateMFAToken(tokenString string) (*Claims, error) {
+	return validateToken(tokenString, mfaTokenPurpose)
+}
+
+func ValidatePasskeyRegistrationToken(tokenString string) (*Claims, error) {
+	return validateToken(tokenString, passkeyRegistrationTokenPurpose)
+}
+
+func ValidatePasskeyLoginToken(tokenString string) (*Claims, error) {
+	return validateToken(tokenString, passkeyLoginTokenPurpose)
+}
+
+func validateToken(tokenString, purpose string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keyring.keyFunc)
 	if err != nil {
 		return nil, err
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.Purpose != purpose {
 		return nil, fmt.Errorf("invalid token")
 	}
 
 	return claims, nil
 }
+
+// GetJWKS returns the public keys trusted for token verification so other
+// services can validate tokens issued by this service.
+func GetJWKS() JWKS {
+	return keyring.JWKS()
+}
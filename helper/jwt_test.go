@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -14,7 +15,7 @@ func TestCreateToken(t *testing.T) {
 	userID := int64(1)
 	expiration := time.Duration(1) * time.Hour
 
-	token, err := CreateToken(userID, expiration)
+	token, err := CreateToken(userID, 1, nil, nil, expiration)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -32,7 +33,7 @@ func TestCreateToken(t *testing.T) {
 func TestValidateToken(t *testing.T) {
 	userID := int64(1)
 	expiration := time.Microsecond
-	tokenString, err := CreateToken(userID, expiration)
+	tokenString, err := CreateToken(userID, 1, nil, nil, expiration)
 	if err != nil {
 		t.Fatalf("Error creating token for testing: %v", err)
 	}
@@ -50,7 +51,7 @@ func TestValidateToken(t *testing.T) {
 	}
 
 	expiration = time.Hour
-	tokenString, err = CreateToken(userID, expiration)
+	tokenString, err = CreateToken(userID, 7, []string{"support"}, []string{"users:read"}, expiration)
 	if err != nil {
 		t.Fatalf("Error creating token for testing: %v", err)
 	}
@@ -68,39 +69,213 @@ func TestValidateToken(t *testing.T) {
 	if claims.UserID != userID {
 		t.Errorf("Expected UserID %d, got %d", userID, claims.UserID)
 	}
+
+	if claims.Id == "" {
+		t.Error("Expected non-empty token ID, got an empty token ID")
+	}
+
+	if claims.SessionID != 7 {
+		t.Errorf("Expected SessionID 7, got %d", claims.SessionID)
+	}
+
+	if len(claims.Roles) != 1 || claims.Roles[0] != "support" {
+		t.Errorf("Expected roles [support], got %v", claims.Roles)
+	}
+
+	if len(claims.Permissions) != 1 || claims.Permissions[0] != "users:read" {
+		t.Errorf("Expected permissions [users:read], got %v", claims.Permissions)
+	}
+
+	if claims.IssuedAtMicro == 0 || claims.IssuedTime().Unix() != claims.IssuedAt {
+		t.Errorf("Expected issue time %d in microseconds, got %d", claims.IssuedAt, claims.IssuedAtMicro)
+	}
+}
+
+func TestClaimsIssuedTime(t *testing.T) {
+	issued := time.UnixMicro(1700000000123456)
+
+	claims := Claims{IssuedAtMicro: issued.UnixMicro()}
+	claims.IssuedAt = issued.Unix()
+	if !claims.IssuedTime().Equal(issued) {
+		t.Errorf("Expected issue time %v, got %v", issued, claims.IssuedTime())
+	}
+
+	claims.IssuedAtMicro = 0
+	if !claims.IssuedTime().Equal(time.Unix(issued.Unix(), 0)) {
+		t.Errorf("Expected issue time %v, got %v", time.Unix(issued.Unix(), 0), claims.IssuedTime())
+	}
+}
+
+func TestValidateMFAToken(t *testing.T) {
+	userID := int64(1)
+
+	mfaToken, err := CreateMFAToken(userID, time.Minute)
+	if err != nil {
+		t.Fatalf("Error creating token for testing: %v", err)
+	}
+
+	claims, err := ValidateMFAToken(mfaToken)
+	if err != nil {
+		t.Errorf("Unexpected error validating token: %v", err)
+	}
+
+	if claims == nil || claims.UserID != userID {
+		t.Errorf("Expected claims for user %d, got %v", userID, claims)
+	}
+
+	if _, err := ValidateToken(mfaToken); err == nil {
+		t.Error("Expected MFA token to be rejected as an access token")
+	}
+
+	accessToken, err := CreateToken(userID, 1, nil, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("Error creating token for testing: %v", err)
+	}
+
+	if _, err := ValidateMFAToken(accessToken); err == nil {
+		t.Error("Expected access token to be rejected as an MFA token")
+	}
+}
+
+func TestValidatePasskeyTokens(t *testing.T) {
+	registrationToken, err := CreatePasskeyRegistrationToken(1, "challenge", time.Minute)
+	if err != nil {
+		t.Fatalf("Error creating token for testing: %v", err)
+	}
+
+	claims, err := ValidatePasskeyRegistrationToken(registrationToken)
+	if err != nil {
+		t.Errorf("Unexpected error validating token: %v", err)
+	}
+
+	if claims == nil || claims.UserID != 1 || claims.Challenge != "challenge" {
+		t.Errorf("Expected claims for user 1 with the challenge, got %v", claims)
+	}
+
+	loginToken, err := CreatePasskeyLoginToken("challenge", time.Minute)
+	if err != nil {
+		t.Fatalf("Error creating token for testing: %v", err)
+	}
+
+	claims, err = ValidatePasskeyLoginToken(loginToken)
+	if err != nil {
+		t.Errorf("Unexpected error validating token: %v", err)
+	}
+
+	if claims == nil || claims.UserID != 0 || claims.Challenge != "challenge" {
+		t.Errorf("Expected claims without a user with the challenge, got %v", claims)
+	}
+
+	if _, err := ValidatePasskeyLoginToken(registrationToken); err == nil {
+		t.Error("Expected registration token to be rejected as a login token")
+	}
+
+	if _, err := ValidateToken(loginToken); err == nil {
+		t.Error("Expected login token to be rejected as an access token")
+	}
 }
 
 func TestInitializeKeys(t *testing.T) {
-	privateKey = nil
-	publicKey = nil
+	defer initializeKeys()
+
+	keyring = nil
 
 	initializeKeys()
 
-	if privateKey == nil || publicKey == nil {
-		t.Error("Expected privateKey and publicKey to be initialized, but one or both are nil")
+	if keyring == nil || keyring.ActiveKeyID() == "" {
+		t.Fatal("Expected keyring with an active key to be initialized, but it is not")
 	}
 
+	activeKey := keyring.keys[keyring.ActiveKeyID()]
+
 	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
 		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+		Bytes: x509.MarshalPKCS1PrivateKey(activeKey.PrivateKey),
 	})
 
-	public, _ := x509.MarshalPKIXPublicKey(publicKey)
+	public, _ := x509.MarshalPKIXPublicKey(activeKey.PublicKey)
 
 	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
 		Type:  "RSA PUBLIC KEY",
 		Bytes: public,
 	})
 
-	os.Setenv("JWT_PRIVATE_KEY", base64.StdEncoding.EncodeToString(privateKeyPEM))
-	os.Setenv("JWT_PUBLIC_KEY", base64.StdEncoding.EncodeToString(publicKeyPEM))
+	t.Setenv("JWT_PRIVATE_KEY", base64.StdEncoding.EncodeToString(privateKeyPEM))
+	t.Setenv("JWT_PUBLIC_KEY", base64.StdEncoding.EncodeToString(publicKeyPEM))
+
+	keyring = nil
+
+	initializeKeys()
+
+	if keyring == nil || keyring.ActiveKeyID() != activeKey.ID {
+		t.Error("Expected keyring to be initialized from environment variables with the same key ID")
+	}
+
+	t.Setenv("JWT_KEY_ID", "env-key")
 
-	privateKey = nil
-	publicKey = nil
+	keyring = nil
 
 	initializeKeys()
 
-	if privateKey == nil || publicKey == nil {
-		t.Error("Expected privateKey and publicKey to be initialized after setting environment variables, but one or both are nil")
+	if keyring == nil || keyring.ActiveKeyID() != "env-key" {
+		t.Error("Expected the environment key to use the key ID from JWT_KEY_ID")
+	}
+}
+
+func TestLoadKeyringFromDir(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyFile(t, dir, "2023-01.pem", true)
+	writeKeyFile(t, dir, "2024-01.pem", true)
+	writeKeyFile(t, dir, "2022-01.pub.pem", false)
+
+	t.Setenv("JWT_PRIVATE_KEY", "")
+	t.Setenv("JWT_PUBLIC_KEY", "")
+	t.Setenv("JWT_KEYS_DIR", dir)
+
+	ring, err := loadKeyring()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if ring.ActiveKeyID() != "2024-01" {
+		t.Errorf("Expected active key 2024-01, got %s", ring.ActiveKeyID())
+	}
+
+	if len(ring.JWKS().Keys) != 3 {
+		t.Errorf("Expected 3 keys in JWKS, got %d", len(ring.JWKS().Keys))
+	}
+
+	t.Setenv("JWT_ACTIVE_KEY_ID", "2023-01")
+
+	ring, err = loadKeyring()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if ring.ActiveKeyID() != "2023-01" {
+		t.Errorf("Expected active key 2023-01, got %s", ring.ActiveKeyID())
+	}
+
+	t.Setenv("JWT_ACTIVE_KEY_ID", "2022-01")
+
+	if _, err := loadKeyring(); err == nil {
+		t.Error("Expected error activating a verification-only key, got nil")
+	}
+}
+
+func writeKeyFile(t *testing.T, dir, name string, withPrivateKey bool) {
+	privateKey, publicKey, err := generateKeys()
+	if err != nil {
+		t.Fatalf("Error generating keys: %v", err)
+	}
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}
+	if !withPrivateKey {
+		public, _ := x509.MarshalPKIXPublicKey(publicKey)
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: public}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatalf("Error writing key file: %v", err)
 	}
 }
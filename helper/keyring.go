@@ -0,0 +1,232 @@
+package helper
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Key is one RSA keypair known to a Keyring. Retired keys only keep their
+// public half and are used to verify tokens issued before a rotation.
+type Key struct {
+	ID         string
+	PrivateKey *rsa.PrivateKey
+	PublicKey  *rsa.PublicKey
+}
+
+// JWK is the public part of a Key in RFC 7517 JSON Web Key format.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Keyring holds every key trusted for verifying tokens, one of which is the
+// active key used to sign new tokens.
+type Keyring struct {
+	mu       sync.RWMutex
+	keys     map[string]*Key
+	order    []string
+	activeID string
+}
+
+func NewKeyring() *Keyring {
+	return &Keyring{
+		keys: map[string]*Key{},
+	}
+}
+
+// AddKey registers a keypair under kid and returns the kid. An empty kid is
+// replaced by the RFC 7638 thumbprint of the public key. privateKey may be
+// nil for retired keys that are only used for verification.
+func (r *Keyring) AddKey(kid string, privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey) (string, error) {
+	if publicKey == nil && privateKey != nil {
+		publicKey = &privateKey.PublicKey
+	}
+	if publicKey == nil {
+		return "", fmt.Errorf("key %q has no public key", kid)
+	}
+
+	if kid == "" {
+		kid = thumbprint(publicKey)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[kid]; ok {
+		return "", fmt.Errorf("duplicate key id %q", kid)
+	}
+
+	r.keys[kid] = &Key{
+		ID:         kid,
+		PrivateKey: privateKey,
+		PublicKey:  publicKey,
+	}
+	r.order = append(r.order, kid)
+
+	return kid, nil
+}
+
+// LoadDir adds every *.pem file in dir to the keyring, using the file name
+// without its extension as the kid. Files holding a private key can sign,
+// files holding only a public key (conventionally named <kid>.pub.pem) are
+// trusted for verification. The kids of signing keys are returned sorted.
+func (r *Keyring) LoadDir(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	signingIDs := []string{}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		block, _ := pem.Decode(content)
+		if block == nil {
+			return nil, fmt.Errorf("%s: no PEM data found", path)
+		}
+
+		kid := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+
+		var privateKey *rsa.PrivateKey
+		var publicKey *rsa.PublicKey
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(content)
+		} else {
+			publicKey, err = jwt.ParseRSAPublicKeyFromPEM(content)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if _, err := r.AddKey(kid, privateKey, publicKey); err != nil {
+			return nil, err
+		}
+
+		if privateKey != nil {
+			signingIDs = append(signingIDs, kid)
+		}
+	}
+
+	sort.Strings(signingIDs)
+
+	return signingIDs, nil
+}
+
+func (r *Keyring) SetActiveKey(kid string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[kid]
+	if !ok {
+		return fmt.Errorf("unknown key id %q", kid)
+	}
+	if key.PrivateKey == nil {
+		return fmt.Errorf("key %q has no private key and cannot sign", kid)
+	}
+
+	r.activeID = kid
+
+	return nil
+}
+
+func (r *Keyring) ActiveKeyID() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.activeID
+}
+
+func (r *Keyring) Sign(claims jwt.Claims) (string, error) {
+	r.mu.RLock()
+	key, ok := r.keys[r.activeID]
+	r.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("no active signing key")
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.PrivateKey)
+}
+
+// keyFunc resolves the verification key from the token's kid header. Tokens
+// issued before kid headers were introduced are checked against the active key.
+func (r *Keyring) keyFunc(token *jwt.Token) (interface{}, error) {
+	if token.Method != jwt.SigningMethodRS256 {
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = r.activeID
+	}
+
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	return key.PublicKey, nil
+}
+
+func (r *Keyring) JWKS() JWKS {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(r.order))}
+	for _, kid := range r.order {
+		key := r.keys[kid]
+		jwks.Keys = append(jwks.Keys, JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			KeyID:     kid,
+			Modulus:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		})
+	}
+
+	return jwks
+}
+
+func thumbprint(publicKey *rsa.PublicKey) string {
+	// RFC 7638 requires the members in lexicographic order with no whitespace,
+	// which is exactly how encoding/json marshals a map.
+	members, _ := json.Marshal(map[string]string{
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		"kty": "RSA",
+		"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+	})
+	sum := sha256.Sum256(members)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
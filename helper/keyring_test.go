@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKeyring(t *testing.T, kids ...string) *Keyring {
+	ring := NewKeyring()
+	for _, kid := range kids {
+		privateKey, publicKey, err := generateKeys()
+		assert.NoError(t, err)
+
+		_, err = ring.AddKey(kid, privateKey, publicKey)
+		assert.NoError(t, err)
+	}
+
+	return ring
+}
+
+func testClaims() Claims {
+	return Claims{
+		UserID: 1,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+}
+
+func TestKeyringAddKey(t *testing.T) {
+	privateKey, publicKey, err := generateKeys()
+	assert.NoError(t, err)
+
+	ring := NewKeyring()
+
+	kid, err := ring.AddKey("", privateKey, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, thumbprint(publicKey), kid)
+
+	_, err = ring.AddKey(kid, nil, publicKey)
+	assert.Error(t, err)
+
+	_, err = ring.AddKey("empty", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestKeyringSetActiveKey(t *testing.T) {
+	ring := newTestKeyring(t, "signing")
+
+	_, publicKey, err := generateKeys()
+	assert.NoError(t, err)
+	_, err = ring.AddKey("retired", nil, publicKey)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ring.SetActiveKey("signing"))
+	assert.Equal(t, "signing", ring.ActiveKeyID())
+	assert.Error(t, ring.SetActiveKey("retired"))
+	assert.Error(t, ring.SetActiveKey("unknown"))
+}
+
+func TestKeyringSignAndVerify(t *testing.T) {
+	ring := newTestKeyring(t, "old", "new")
+
+	_, err := ring.Sign(testClaims())
+	assert.Error(t, err)
+
+	assert.NoError(t, ring.SetActiveKey("old"))
+	oldToken, err := ring.Sign(testClaims())
+	assert.NoError(t, err)
+
+	assert.NoError(t, ring.SetActiveKey("new"))
+	newToken, err := ring.Sign(testClaims())
+	assert.NoError(t, err)
+
+	for _, tokenString := range []string{oldToken, newToken} {
+		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, ring.keyFunc)
+		assert.NoError(t, err)
+		assert.True(t, token.Valid)
+	}
+
+	token, _ := jwt.ParseWithClaims(newToken, &Claims{}, ring.keyFunc)
+	assert.Equal(t, "new", token.Header["kid"])
+
+	otherRing := newTestKeyring(t, "other")
+	_, err = jwt.ParseWithClaims(newToken, &Claims{}, otherRing.keyFunc)
+	assert.Error(t, err)
+}
+
+func TestKeyringVerifyWithoutKid(t *testing.T) {
+	ring := newTestKeyring(t, "active")
+	assert.NoError(t, ring.SetActiveKey("active"))
+
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, testClaims()).SignedString(ring.keys["active"].PrivateKey)
+	assert.NoError(t, err)
+
+	_, err = jwt.ParseWithClaims(tokenString, &Claims{}, ring.keyFunc)
+	assert.NoError(t, err)
+}
+
+func TestKeyringRejectsOtherSigningMethods(t *testing.T) {
+	ring := newTestKeyring(t, "active")
+	assert.NoError(t, ring.SetActiveKey("active"))
+
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims()).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	_, err = jwt.ParseWithClaims(tokenString, &Claims{}, ring.keyFunc)
+	assert.Error(t, err)
+}
+
+func TestKeyringJWKS(t *testing.T) {
+	ring := newTestKeyring(t, "first", "second")
+
+	jwks := ring.JWKS()
+
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "first", jwks.Keys[0].KeyID)
+	assert.Equal(t, "second", jwks.Keys[1].KeyID)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "sig", jwks.Keys[0].Use)
+	assert.Equal(t, "AQAB", jwks.Keys[0].Exponent)
+	assert.NotEmpty(t, jwks.Keys[0].Modulus)
+}
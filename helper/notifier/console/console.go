@@ -0,0 +1,35 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sawitpro/UserService/helper/notifier"
+)
+
+// consoleNotifier writes every message to the given writer instead of delivering
+// it, which is enough for local development and tests.
+type consoleNotifier struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+func NewNotifier(w io.Writer) notifier.Notifier {
+	return &consoleNotifier{
+		w:   w,
+		now: time.Now,
+	}
+}
+
+func (n *consoleNotifier) Send(ctx context.Context, phoneNumber, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err := fmt.Fprintf(n.w, "%s to=%s message=%q\n", n.now().Format(time.RFC3339), phoneNumber, message)
+
+	return err
+}
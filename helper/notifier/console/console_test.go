@@ -0,0 +1,23 @@
+package console
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewNotifier(&buf).(*consoleNotifier)
+	n.now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	err := n.Send(context.Background(), "+628123456789", "your code is 123456")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02T03:04:05Z to=+628123456789 message=\"your code is 123456\"\n", buf.String())
+}
@@ -0,0 +1,9 @@
+package notifier
+
+import "context"
+
+// Notifier delivers short text messages, such as verification codes, to a
+// user's phone number over SMS, WhatsApp or any other channel.
+type Notifier interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+type Message struct {
+	PhoneNumber string
+	Text        string
+}
+
+// Notifier keeps every message in memory so tests can inspect what would
+// have been delivered.
+type Notifier struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewNotifier() *Notifier {
+	return &Notifier{}
+}
+
+func (n *Notifier) Send(ctx context.Context, phoneNumber, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.messages = append(n.messages, Message{PhoneNumber: phoneNumber, Text: message})
+
+	return nil
+}
+
+// Messages returns a copy of every message sent so far, oldest first.
+func (n *Notifier) Messages() []Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	messages := make([]Message, len(n.messages))
+	copy(messages, n.messages)
+
+	return messages
+}
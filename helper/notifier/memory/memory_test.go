@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	n := NewNotifier()
+	assert.Empty(t, n.Messages())
+
+	assert.NoError(t, n.Send(context.Background(), "+628123456789", "first"))
+	assert.NoError(t, n.Send(context.Background(), "+628987654321", "second"))
+
+	assert.Equal(t, []Message{
+		{PhoneNumber: "+628123456789", Text: "first"},
+		{PhoneNumber: "+628987654321", Text: "second"},
+	}, n.Messages())
+}
@@ -0,0 +1,17 @@
+package revocation
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps track of access tokens that must no longer be accepted even
+// though their signature and expiry are still valid.
+type Store interface {
+	RevokeToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) error
+	// ConsumeToken revokes a single-use token, reporting false when it was
+	// already revoked, so that only one of concurrent callers may use it.
+	ConsumeToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) (bool, error)
+	RevokeAllUserTokens(ctx context.Context, userID int64, revokedBefore time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error)
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sawitpro/UserService/helper/revocation"
+)
+
+type store struct {
+	mu            sync.RWMutex
+	tokens        map[string]time.Time
+	revokedBefore map[int64]time.Time
+	now           func() time.Time
+}
+
+func NewStore() revocation.Store {
+	return &store{
+		tokens:        map[string]time.Time{},
+		revokedBefore: map[int64]time.Time{},
+		now:           time.Now,
+	}
+}
+
+func (s *store) RevokeToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) error {
+	_, err := s.ConsumeToken(ctx, tokenID, userID, expiresAt)
+
+	return err
+}
+
+func (s *store) ConsumeToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneExpired()
+	if _, ok := s.tokens[tokenID]; ok {
+		return false, nil
+	}
+
+	s.tokens[tokenID] = expiresAt
+
+	return true, nil
+}
+
+// pruneExpired forgets the revoked tokens that have expired anyway. The
+// caller holds the lock.
+func (s *store) pruneExpired() {
+	now := s.now()
+	for id, exp := range s.tokens {
+		if exp.Before(now) {
+			delete(s.tokens, id)
+		}
+	}
+}
+
+func (s *store) RevokeAllUserTokens(ctx context.Context, userID int64, revokedBefore time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.revokedBefore[userID] = revokedBefore
+
+	return nil
+}
+
+func (s *store) IsTokenRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.tokens[tokenID]; ok {
+		return true, nil
+	}
+
+	revokedBefore, ok := s.revokedBefore[userID]
+
+	return ok && revokedBefore.After(issuedAt), nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewStore()
+
+	revoked, err := s.IsTokenRevoked(ctx, "token-1", 1, now)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	assert.NoError(t, s.RevokeToken(ctx, "token-1", 1, now.Add(time.Hour)))
+
+	revoked, err = s.IsTokenRevoked(ctx, "token-1", 1, now)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = s.IsTokenRevoked(ctx, "token-2", 1, now)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestRevokeTokenPrunesExpiredTokens(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewStore().(*store)
+
+	assert.NoError(t, s.RevokeToken(ctx, "expired", 1, now.Add(-time.Minute)))
+	assert.NoError(t, s.RevokeToken(ctx, "active", 1, now.Add(time.Hour)))
+
+	assert.NotContains(t, s.tokens, "expired")
+	assert.Contains(t, s.tokens, "active")
+}
+
+func TestConsumeToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewStore()
+
+	consumed, err := s.ConsumeToken(ctx, "token-1", 1, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, consumed)
+
+	consumed, err = s.ConsumeToken(ctx, "token-1", 1, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, consumed)
+
+	revoked, err := s.IsTokenRevoked(ctx, "token-1", 1, now)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRevokeAllUserTokens(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+	s := NewStore()
+
+	assert.NoError(t, s.RevokeAllUserTokens(ctx, 1, now))
+
+	revoked, err := s.IsTokenRevoked(ctx, "old", 1, now.Add(-time.Second))
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = s.IsTokenRevoked(ctx, "same second", 1, now.Add(-time.Microsecond))
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = s.IsTokenRevoked(ctx, "new", 1, now)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = s.IsTokenRevoked(ctx, "other user", 2, now.Add(-time.Second))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateOpaqueToken(t *testing.T) {
+	first, err := GenerateOpaqueToken()
+	assert.NoError(t, err)
+	assert.Len(t, first, 43)
+
+	second, err := GenerateOpaqueToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestHashOpaqueToken(t *testing.T) {
+	hash := HashOpaqueToken("refresh-token")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashOpaqueToken("refresh-token"))
+	assert.NotEqual(t, hash, HashOpaqueToken("another-token"))
+}
+
+func TestGenerateNumericCode(t *testing.T) {
+	code, err := GenerateNumericCode(6)
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9]{6}$`), code)
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	first, err := GenerateRecoveryCode()
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[a-z2-9]{5}-[a-z2-9]{5}$`), first)
+
+	second, err := GenerateRecoveryCode()
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
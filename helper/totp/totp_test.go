@@ -0,0 +1,81 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA1 seed from RFC 6238 appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := Code(rfcSecret, time.Unix(tc.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	step, ok := Validate(rfcSecret, "005924", now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234567890/Period), step)
+
+	previous, err := Code(rfcSecret, now.Add(-Period*time.Second))
+	assert.NoError(t, err)
+	step, ok = Validate(rfcSecret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234567890/Period-1), step)
+
+	stale, err := Code(rfcSecret, now.Add(-2*Period*time.Second))
+	assert.NoError(t, err)
+	_, ok = Validate(rfcSecret, stale, now)
+	assert.False(t, ok)
+
+	_, ok = Validate(rfcSecret, "12345", now)
+	assert.False(t, ok)
+
+	_, ok = Validate("not base32!", "005924", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	code, err := Code(secret, time.Now())
+	assert.NoError(t, err)
+	_, ok := Validate(secret, code, time.Now())
+	assert.True(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("SawitPro", "+628123456789", "JBSWY3DPEHPK3PXP")
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/SawitPro:+628123456789", parsed.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "SawitPro", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+}
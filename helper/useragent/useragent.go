@@ -0,0 +1,86 @@
+// Package useragent sorts User-Agent headers into coarse device and
+// operating system families, enough for users and support staff to tell
+// devices apart. It does not try to identify browsers or versions.
+package useragent
+
+import "strings"
+
+const (
+	DesktopDevice = "desktop"
+	MobileDevice  = "mobile"
+	TabletDevice  = "tablet"
+	BotDevice     = "bot"
+	OtherDevice   = "other"
+
+	WindowsOS = "Windows"
+	MacOS     = "macOS"
+	IOS       = "iOS"
+	AndroidOS = "Android"
+	ChromeOS  = "ChromeOS"
+	LinuxOS   = "Linux"
+	OtherOS   = "Other"
+)
+
+// botMarkers identify search engine and other crawlers. HTTP client libraries
+// such as okhttp are left out, as the mobile apps send requests through them.
+var botMarkers = []string{"bot", "crawler", "spider", "slurp"}
+
+// Agent is the device and operating system family of a User-Agent.
+type Agent struct {
+	Device string
+	OS     string
+}
+
+// Parse returns the families of a User-Agent header. Anything it does not
+// recognise falls into OtherDevice and OtherOS. An empty User-Agent yields
+// empty families, so that the missing header is stored as such.
+func Parse(userAgent string) Agent {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return Agent{}
+	}
+
+	os := parseOS(ua)
+	for _, marker := range botMarkers {
+		if strings.Contains(ua, marker) {
+			return Agent{Device: BotDevice, OS: os}
+		}
+	}
+
+	return Agent{Device: parseDevice(ua, os), OS: os}
+}
+
+func parseOS(ua string) string {
+	switch {
+	case strings.Contains(ua, "windows"):
+		return WindowsOS
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return IOS
+	case strings.Contains(ua, "android"):
+		return AndroidOS
+	case strings.Contains(ua, "cros "):
+		return ChromeOS
+	case strings.Contains(ua, "mac os x"), strings.Contains(ua, "macintosh"):
+		return MacOS
+	case strings.Contains(ua, "linux"):
+		return LinuxOS
+	default:
+		return OtherOS
+	}
+}
+
+func parseDevice(ua, os string) string {
+	switch {
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"):
+		return TabletDevice
+	// Android tablets leave "Mobile" out of their User-Agent.
+	case os == AndroidOS && !strings.Contains(ua, "mobile"):
+		return TabletDevice
+	case strings.Contains(ua, "mobile"), strings.Contains(ua, "iphone"), strings.Contains(ua, "ipod"):
+		return MobileDevice
+	case os == WindowsOS, os == MacOS, os == LinuxOS, os == ChromeOS:
+		return DesktopDevice
+	default:
+		return OtherDevice
+	}
+}
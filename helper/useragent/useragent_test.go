@@ -0,0 +1,82 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		userAgent string
+		expected  Agent
+	}{
+		{
+			name:      "Chrome On Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected:  Agent{Device: DesktopDevice, OS: WindowsOS},
+		},
+		{
+			name:      "Safari On Mac",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15",
+			expected:  Agent{Device: DesktopDevice, OS: MacOS},
+		},
+		{
+			name:      "Safari On iPhone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			expected:  Agent{Device: MobileDevice, OS: IOS},
+		},
+		{
+			name:      "Safari On iPad",
+			userAgent: "Mozilla/5.0 (iPad; CPU OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			expected:  Agent{Device: TabletDevice, OS: IOS},
+		},
+		{
+			name:      "Chrome On Android Phone",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			expected:  Agent{Device: MobileDevice, OS: AndroidOS},
+		},
+		{
+			name:      "Chrome On Android Tablet",
+			userAgent: "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected:  Agent{Device: TabletDevice, OS: AndroidOS},
+		},
+		{
+			name:      "Firefox On Linux",
+			userAgent: "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:120.0) Gecko/20100101 Firefox/120.0",
+			expected:  Agent{Device: DesktopDevice, OS: LinuxOS},
+		},
+		{
+			name:      "Chromebook",
+			userAgent: "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected:  Agent{Device: DesktopDevice, OS: ChromeOS},
+		},
+		{
+			name:      "Crawler",
+			userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected:  Agent{Device: BotDevice, OS: OtherOS},
+		},
+		{
+			name:      "Android App",
+			userAgent: "okhttp/4.12.0",
+			expected:  Agent{Device: OtherDevice, OS: OtherOS},
+		},
+		{
+			name:      "Go Client",
+			userAgent: "Go-http-client/1.1",
+			expected:  Agent{Device: OtherDevice, OS: OtherOS},
+		},
+		{
+			name:      "Empty",
+			userAgent: "",
+			expected:  Agent{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Parse(tc.userAgent))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package webauthn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []byte
+		expected interface{}
+		rest     []byte
+	}{
+		{name: "Small Int", input: []byte{0x17}, expected: int64(23)},
+		{name: "One Byte Int", input: []byte{0x18, 0x64}, expected: int64(100)},
+		{name: "Two Byte Int", input: []byte{0x19, 0x03, 0xe8}, expected: int64(1000)},
+		{name: "Negative Int", input: []byte{0x38, 0x63}, expected: int64(-100)},
+		{name: "Byte String", input: []byte{0x43, 0x01, 0x02, 0x03}, expected: []byte{1, 2, 3}},
+		{name: "Text String", input: []byte{0x64, 'I', 'E', 'T', 'F'}, expected: "IETF"},
+		{name: "Array", input: []byte{0x83, 0x01, 0x02, 0x03}, expected: []interface{}{int64(1), int64(2), int64(3)}},
+		{
+			name:     "Map",
+			input:    []byte{0xa2, 0x61, 'a', 0x01, 0x20, 0xf5},
+			expected: map[interface{}]interface{}{"a": int64(1), int64(-1): true},
+		},
+		{name: "Tag", input: []byte{0xc2, 0x41, 0x01}, expected: []byte{1}},
+		{name: "Null", input: []byte{0xf6}, expected: nil},
+		{name: "Trailing Data", input: []byte{0xf4, 0x01}, expected: false, rest: []byte{0x01}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, rest, err := decodeCBOR(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+			assert.Equal(t, len(tc.rest), len(rest))
+		})
+	}
+}
+
+func TestDecodeCBORInvalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Empty", input: []byte{}},
+		{name: "Truncated Argument", input: []byte{0x19, 0x03}},
+		{name: "Truncated String", input: []byte{0x43, 0x01}},
+		{name: "Indefinite Length", input: []byte{0x5f, 0x41, 0x01, 0xff}},
+		{name: "Float", input: []byte{0xf9, 0x3c, 0x00}},
+		{name: "Duplicate Map Key", input: []byte{0xa2, 0x01, 0x01, 0x01, 0x02}},
+		{name: "Array Map Key", input: []byte{0xa1, 0x80, 0x01}},
+		{name: "Oversized Array", input: []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "Too Deep", input: []byte{0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x00}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := decodeCBOR(tc.input)
+			assert.Equal(t, errInvalidCBOR, err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// COSE algorithms of the credential public keys accepted, in order of
+// preference.
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms lists the COSE algorithms offered to authenticators
+// when registering a credential.
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE_Key labels and values used by the supported key types, from RFC 9053.
+const (
+	coseKeyType   int64 = 1
+	coseAlgorithm int64 = 3
+	coseCurve     int64 = -1
+	coseX         int64 = -2
+	coseY         int64 = -3
+	coseRSAN      int64 = -1
+	coseRSAE      int64 = -2
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+
+	minRSAKeyBits = 2048
+)
+
+var errUnsupportedKey = errors.New("webauthn: unsupported credential public key")
+
+// verifier checks the signature of data with a credential public key.
+type verifier func(data, signature []byte) bool
+
+// parsePublicKey decodes a COSE_Key encoded credential public key.
+func parsePublicKey(coseKey []byte) (verifier, error) {
+	value, rest, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := value.(map[interface{}]interface{})
+	if !ok || len(rest) != 0 {
+		return nil, errUnsupportedKey
+	}
+
+	keyType, _ := key[coseKeyType].(int64)
+	alg, _ := key[coseAlgorithm].(int64)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && alg == AlgES256:
+		curve, _ := key[coseCurve].(int64)
+		x, _ := key[coseX].([]byte)
+		y, _ := key[coseY].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errUnsupportedKey
+		}
+
+		publicKey := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !publicKey.Curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, errUnsupportedKey
+		}
+
+		return func(data, signature []byte) bool {
+			digest := sha256.Sum256(data)
+			return ecdsa.VerifyASN1(publicKey, digest[:], signature)
+		}, nil
+	case keyType == coseKeyTypeOKP && alg == AlgEdDSA:
+		curve, _ := key[coseCurve].(int64)
+		x, _ := key[coseX].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, errUnsupportedKey
+		}
+
+		publicKey := ed25519.PublicKey(x)
+
+		return func(data, signature []byte) bool {
+			return ed25519.Verify(publicKey, data, signature)
+		}, nil
+	case keyType == coseKeyTypeRSA && alg == AlgRS256:
+		n, _ := key[coseRSAN].([]byte)
+		e, _ := key[coseRSAE].([]byte)
+		if len(e) == 0 || len(e) > 4 {
+			return nil, errUnsupportedKey
+		}
+
+		exponent := int(new(big.Int).SetBytes(e).Int64())
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}
+		if publicKey.N.BitLen() < minRSAKeyBits || exponent < 3 || exponent%2 == 0 {
+			return nil, errUnsupportedKey
+		}
+
+		return func(data, signature []byte) bool {
+			digest := sha256.Sum256(data)
+			return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature) == nil
+		}, nil
+	default:
+		return nil, errUnsupportedKey
+	}
+}
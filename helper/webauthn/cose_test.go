@@ -0,0 +1,158 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeCOSEKey encodes a COSE_Key whose values are integers or byte
+// strings. Labels are written in ascending order of their absolute value,
+// which is enough for the decoder.
+func encodeCOSEKey(labels []int64, values map[int64]interface{}) []byte {
+	out := appendTestHead(nil, 5, uint64(len(labels)))
+	for _, label := range labels {
+		out = appendTestInt(out, label)
+		switch value := values[label].(type) {
+		case int64:
+			out = appendTestInt(out, value)
+		case []byte:
+			out = append(appendTestHead(out, 2, uint64(len(value))), value...)
+		}
+	}
+	return out
+}
+
+func appendTestHead(out []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(out, major<<5|byte(arg))
+	case arg <= 0xff:
+		return append(out, major<<5|24, byte(arg))
+	default:
+		return binary.BigEndian.AppendUint16(append(out, major<<5|25), uint16(arg))
+	}
+}
+
+func appendTestInt(out []byte, n int64) []byte {
+	if n < 0 {
+		return appendTestHead(out, 1, uint64(-1-n))
+	}
+	return appendTestHead(out, 0, uint64(n))
+}
+
+func ec2Key(key *ecdsa.PublicKey) []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+
+	return encodeCOSEKey([]int64{1, 3, -1, -2, -3}, map[int64]interface{}{
+		1: coseKeyTypeEC2, 3: AlgES256, -1: coseCurveP256, -2: x, -3: y,
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	data := []byte("signed data")
+	digest := sha256.Sum256(data)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecSignature, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	assert.NoError(t, err)
+
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		key       []byte
+		signature []byte
+	}{
+		{
+			name:      "ES256",
+			key:       ec2Key(&ecKey.PublicKey),
+			signature: ecSignature,
+		},
+		{
+			name: "EdDSA",
+			key: encodeCOSEKey([]int64{1, 3, -1, -2}, map[int64]interface{}{
+				1: coseKeyTypeOKP, 3: AlgEdDSA, -1: coseCurveEd25519, -2: []byte(edPublic),
+			}),
+			signature: ed25519.Sign(edKey, data),
+		},
+		{
+			name: "RS256",
+			key: encodeCOSEKey([]int64{1, 3, -1, -2}, map[int64]interface{}{
+				1: coseKeyTypeRSA, 3: AlgRS256, -1: rsaKey.N.Bytes(), -2: big.NewInt(int64(rsaKey.E)).Bytes(),
+			}),
+			signature: rsaSignature,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verify, err := parsePublicKey(tc.key)
+			assert.NoError(t, err)
+			assert.True(t, verify(data, tc.signature))
+			assert.False(t, verify([]byte("other data"), tc.signature))
+		})
+	}
+}
+
+func TestParsePublicKeyUnsupported(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		key  []byte
+	}{
+		{
+			name: "Unknown Algorithm",
+			key: encodeCOSEKey([]int64{1, 3}, map[int64]interface{}{
+				1: coseKeyTypeEC2, 3: int64(-35),
+			}),
+		},
+		{
+			name: "Point Not On Curve",
+			key: encodeCOSEKey([]int64{1, 3, -1, -2, -3}, map[int64]interface{}{
+				1: coseKeyTypeEC2, 3: AlgES256, -1: coseCurveP256, -2: make([]byte, 32), -3: make([]byte, 32),
+			}),
+		},
+		{
+			name: "Short RSA Key",
+			key: encodeCOSEKey([]int64{1, 3, -1, -2}, map[int64]interface{}{
+				1: coseKeyTypeRSA, 3: AlgRS256, -1: smallRSAKey.N.Bytes(), -2: big.NewInt(int64(smallRSAKey.E)).Bytes(),
+			}),
+		},
+		{
+			name: "Trailing Data",
+			key:  append(ec2Key(&ecKey.PublicKey), 0x00),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parsePublicKey(tc.key)
+			assert.Equal(t, errUnsupportedKey, err)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Package softauthn is an in-memory WebAuthn authenticator, so registration
+// and login ceremonies can be tested without a browser or security key. It
+// creates ES256 discoverable credentials and attests them with the "none"
+// attestation format.
+package softauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+)
+
+// Authenticator data flags.
+const (
+	flagUserPresent  byte = 0x01
+	flagUserVerified byte = 0x04
+	flagAttestedData byte = 0x40
+)
+
+var ErrUnknownCredential = errors.New("softauthn: unknown credential")
+
+// Authenticator holds credentials in memory. Origin is reported as the
+// origin of every ceremony, and UserVerified sets the user verified flag.
+type Authenticator struct {
+	Origin       string
+	UserVerified bool
+
+	credentials map[string]*credential
+}
+
+type credential struct {
+	rpID       string
+	userHandle []byte
+	key        *ecdsa.PrivateKey
+	signCount  uint32
+}
+
+// Attestation is the response to a registration ceremony.
+type Attestation struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// Assertion is the response to an authentication ceremony.
+type Assertion struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
+
+func New(origin string) *Authenticator {
+	return &Authenticator{
+		Origin:       origin,
+		UserVerified: true,
+		credentials:  map[string]*credential{},
+	}
+}
+
+// Register creates a credential for the relying party and user handle and
+// attests it in response to challenge.
+func (a *Authenticator) Register(rpID, challenge string, userHandle []byte) (*Attestation, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+
+	a.credentials[string(credentialID)] = &credential{
+		rpID:       rpID,
+		userHandle: append([]byte(nil), userHandle...),
+		key:        key,
+	}
+
+	clientDataJSON, err := a.clientData("webauthn.create", challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	authData := a.authenticatorData(rpID, flagAttestedData, 0)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(credentialID)))
+	authData = append(authData, credentialID...)
+	authData = append(authData, encodePublicKey(&key.PublicKey)...)
+
+	var attestationObject []byte
+	attestationObject = appendHead(attestationObject, 5, 3)
+	attestationObject = appendText(attestationObject, "fmt")
+	attestationObject = appendText(attestationObject, "none")
+	attestationObject = appendText(attestationObject, "attStmt")
+	attestationObject = appendHead(attestationObject, 5, 0)
+	attestationObject = appendText(attestationObject, "authData")
+	attestationObject = appendBytes(attestationObject, authData)
+
+	return &Attestation{
+		CredentialID:      credentialID,
+		ClientDataJSON:    clientDataJSON,
+		AttestationObject: attestationObject,
+	}, nil
+}
+
+// Login signs challenge with a credential created by Register.
+func (a *Authenticator) Login(rpID, challenge string, credentialID []byte) (*Assertion, error) {
+	cred, ok := a.credentials[string(credentialID)]
+	if !ok || cred.rpID != rpID {
+		return nil, ErrUnknownCredential
+	}
+
+	clientDataJSON, err := a.clientData("webauthn.get", challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	cred.signCount++
+	authData := a.authenticatorData(rpID, 0, cred.signCount)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, cred.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Assertion{
+		CredentialID:      append([]byte(nil), credentialID...),
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         signature,
+		UserHandle:        cred.userHandle,
+	}, nil
+}
+
+func (a *Authenticator) clientData(ceremonyType, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":        ceremonyType,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+}
+
+func (a *Authenticator) authenticatorData(rpID string, flags byte, signCount uint32) []byte {
+	flags |= flagUserPresent
+	if a.UserVerified {
+		flags |= flagUserVerified
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, signCount)
+}
+
+// encodePublicKey encodes an ES256 public key as a COSE_Key.
+func encodePublicKey(key *ecdsa.PublicKey) []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+
+	var out []byte
+	out = appendHead(out, 5, 5)
+	out = appendInt(out, 1) // kty: EC2
+	out = appendInt(out, 2)
+	out = appendInt(out, 3) // alg: ES256
+	out = appendInt(out, -7)
+	out = appendInt(out, -1) // crv: P-256
+	out = appendInt(out, 1)
+	out = appendInt(out, -2) // x
+	out = appendBytes(out, x)
+	out = appendInt(out, -3) // y
+	out = appendBytes(out, y)
+	return out
+}
+
+// appendHead appends the initial byte and argument of a CBOR data item.
+func appendHead(out []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(out, major<<5|byte(arg))
+	case arg <= 0xff:
+		return append(out, major<<5|24, byte(arg))
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(out, major<<5|25), uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(out, major<<5|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(out, major<<5|27), arg)
+	}
+}
+
+func appendInt(out []byte, n int64) []byte {
+	if n < 0 {
+		return appendHead(out, 1, uint64(-1-n))
+	}
+	return appendHead(out, 0, uint64(n))
+}
+
+func appendBytes(out, b []byte) []byte {
+	return append(appendHead(out, 2, uint64(len(b))), b...)
+}
+
+func appendText(out []byte, s string) []byte {
+	return append(appendHead(out, 3, uint64(len(s))), s...)
+}
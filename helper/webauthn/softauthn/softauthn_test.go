@@ -0,0 +1,31 @@
+package softauthn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticator(t *testing.T) {
+	authenticator := New("https://example.com")
+
+	attestation, err := authenticator.Register("example.com", "challenge", []byte{1})
+	assert.NoError(t, err)
+	assert.Len(t, attestation.CredentialID, 16)
+	assert.Contains(t, string(attestation.ClientDataJSON), `"type":"webauthn.create"`)
+
+	first, err := authenticator.Login("example.com", "challenge", attestation.CredentialID)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1}, first.UserHandle)
+	assert.Equal(t, []byte{0, 0, 0, 1}, first.AuthenticatorData[33:37])
+
+	second, err := authenticator.Login("example.com", "challenge", attestation.CredentialID)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 2}, second.AuthenticatorData[33:37])
+
+	_, err = authenticator.Login("other.example", "challenge", attestation.CredentialID)
+	assert.Equal(t, ErrUnknownCredential, err)
+
+	_, err = authenticator.Login("example.com", "challenge", []byte("unknown"))
+	assert.Equal(t, ErrUnknownCredential, err)
+}
@@ -0,0 +1,287 @@
+// Package webauthn verifies the registration and authentication ceremonies of
+// WebAuthn credentials (passkeys) for a relying party. Attestation statements
+// are not checked, like the "none" attestation conveyance the relying party
+// requests, so any authenticator may register.
+package webauthn
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+)
+
+// Client data types of the two ceremonies.
+const (
+	createType = "webauthn.create"
+	getType    = "webauthn.get"
+)
+
+// Authenticator data flags.
+const (
+	flagUserPresent      byte = 0x01
+	flagUserVerified     byte = 0x04
+	flagAttestedData     byte = 0x40
+	flagExtensionData    byte = 0x80
+	authenticatorDataLen      = 37
+	maxCredentialIDLen        = 1023
+)
+
+// challengeSize is the number of random bytes in a challenge.
+const challengeSize = 32
+
+var (
+	ErrInvalidClientData        = errors.New("webauthn: invalid client data")
+	ErrChallengeMismatch        = errors.New("webauthn: challenge mismatch")
+	ErrOriginMismatch           = errors.New("webauthn: origin not allowed")
+	ErrInvalidAuthenticatorData = errors.New("webauthn: invalid authenticator data")
+	ErrRelyingPartyMismatch     = errors.New("webauthn: relying party ID mismatch")
+	ErrUserNotVerified          = errors.New("webauthn: user presence and verification required")
+	ErrInvalidAttestation       = errors.New("webauthn: invalid attestation object")
+	ErrInvalidSignature         = errors.New("webauthn: invalid signature")
+	ErrSignCountRegressed       = errors.New("webauthn: signature counter did not increase")
+)
+
+// RelyingParty identifies the service credentials are scoped to. ID is the
+// effective domain of the origins, and Origins the exact origins, such as
+// https://example.com, ceremonies may run on.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// Credential is a verified public key credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE_Key encoded credential public key.
+	PublicKey []byte
+	SignCount uint32
+}
+
+// AttestationResponse is the response of an authenticator to a registration
+// ceremony.
+type AttestationResponse struct {
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// AssertionResponse is the response of an authenticator to an
+// authentication ceremony.
+type AssertionResponse struct {
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+	// credential is only set when the attested credential data flag is.
+	credential *Credential
+}
+
+// GenerateChallenge returns a random base64url encoded challenge for a
+// ceremony.
+func GenerateChallenge() (string, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(challenge), nil
+}
+
+// VerifyRegistration checks the response to a registration ceremony started
+// with challenge and returns the new credential. User verification is
+// required.
+func (rp RelyingParty) VerifyRegistration(challenge string, resp AttestationResponse) (*Credential, error) {
+	err := rp.verifyClientData(resp.ClientDataJSON, createType, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	value, rest, err := decodeCBOR(resp.AttestationObject)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrInvalidAttestation
+	}
+
+	attestation, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrInvalidAttestation
+	}
+
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, ErrInvalidAttestation
+	}
+
+	authData, err := rp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+
+	if authData.credential == nil {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	return authData.credential, nil
+}
+
+// VerifyAssertion checks the response to an authentication ceremony started
+// with challenge against a registered credential and returns the new
+// signature counter of the credential. User verification is required.
+func (rp RelyingParty) VerifyAssertion(challenge string, cred Credential, resp AssertionResponse) (uint32, error) {
+	err := rp.verifyClientData(resp.ClientDataJSON, getType, challenge)
+	if err != nil {
+		return 0, err
+	}
+
+	authData, err := rp.verifyAuthenticatorData(resp.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	verify, err := parsePublicKey(cred.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+	signed := append(append([]byte(nil), resp.AuthenticatorData...), clientDataHash[:]...)
+	if !verify(signed, resp.Signature) {
+		return 0, ErrInvalidSignature
+	}
+
+	// Authenticators without a counter always report zero. Otherwise a
+	// counter that did not increase hints at a cloned authenticator.
+	if (authData.signCount != 0 || cred.SignCount != 0) && authData.signCount <= cred.SignCount {
+		return 0, ErrSignCountRegressed
+	}
+
+	return authData.signCount, nil
+}
+
+func (rp RelyingParty) verifyClientData(raw []byte, ceremonyType, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return ErrInvalidClientData
+	}
+
+	if data.Type != ceremonyType || data.CrossOrigin {
+		return ErrInvalidClientData
+	}
+
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return ErrChallengeMismatch
+	}
+
+	for _, origin := range rp.Origins {
+		if data.Origin == origin {
+			return nil
+		}
+	}
+
+	return ErrOriginMismatch
+}
+
+func (rp RelyingParty) verifyAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	authData, err := parseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(authData.rpIDHash, rpIDHash[:]) != 1 {
+		return nil, ErrRelyingPartyMismatch
+	}
+
+	if authData.flags&flagUserPresent == 0 || authData.flags&flagUserVerified == 0 {
+		return nil, ErrUserNotVerified
+	}
+
+	return authData, nil
+}
+
+// parseAuthenticatorData splits authenticator data into its fields. The
+// credential public key of attested credential data is checked to be a
+// supported key.
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < authenticatorDataLen {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	authData := &authenticatorData{
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	rest := raw[authenticatorDataLen:]
+
+	if authData.flags&flagAttestedData != 0 {
+		// The AAGUID precedes the length of the credential ID.
+		if len(rest) < 18 {
+			return nil, ErrInvalidAuthenticatorData
+		}
+
+		idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLen == 0 || idLen > maxCredentialIDLen || idLen > len(rest) {
+			return nil, ErrInvalidAuthenticatorData
+		}
+
+		credentialID := append([]byte(nil), rest[:idLen]...)
+		rest = rest[idLen:]
+
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, ErrInvalidAuthenticatorData
+		}
+
+		publicKey := append([]byte(nil), rest[:len(rest)-len(after)]...)
+		if _, err := parsePublicKey(publicKey); err != nil {
+			return nil, err
+		}
+
+		authData.credential = &Credential{
+			ID:        credentialID,
+			PublicKey: publicKey,
+			SignCount: authData.signCount,
+		}
+		rest = after
+	}
+
+	if authData.flags&flagExtensionData != 0 {
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, ErrInvalidAuthenticatorData
+		}
+		rest = after
+	}
+
+	if len(rest) != 0 {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	return authData, nil
+}
+
+// UserHandle returns the user handle credentials of a user are registered
+// with, which an authenticator returns when a discoverable credential is
+// used to log in.
+func UserHandle(userID int64) []byte {
+	handle := make([]byte, 8)
+	binary.BigEndian.PutUint64(handle, uint64(userID))
+	return handle
+}
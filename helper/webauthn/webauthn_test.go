@@ -0,0 +1,184 @@
+package webauthn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/helper/webauthn/softauthn"
+)
+
+var testRelyingParty = RelyingParty{
+	ID:      "example.com",
+	Name:    "Example",
+	Origins: []string{"https://example.com"},
+}
+
+func register(t *testing.T, authenticator *softauthn.Authenticator) *Credential {
+	challenge, err := GenerateChallenge()
+	assert.NoError(t, err)
+
+	attestation, err := authenticator.Register(testRelyingParty.ID, challenge, UserHandle(1))
+	assert.NoError(t, err)
+
+	cred, err := testRelyingParty.VerifyRegistration(challenge, AttestationResponse{
+		ClientDataJSON:    attestation.ClientDataJSON,
+		AttestationObject: attestation.AttestationObject,
+	})
+	assert.NoError(t, err)
+	return cred
+}
+
+func TestGenerateChallenge(t *testing.T) {
+	first, err := GenerateChallenge()
+	assert.NoError(t, err)
+	assert.Len(t, first, 43)
+
+	second, err := GenerateChallenge()
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	testCases := []struct {
+		name          string
+		origin        string
+		rpID          string
+		userVerified  bool
+		mutate        func(challenge string, attestation *softauthn.Attestation) string
+		expectedError error
+	}{
+		{
+			name:         "Successful Registration",
+			origin:       "https://example.com",
+			rpID:         "example.com",
+			userVerified: true,
+		},
+		{
+			name:          "Wrong Origin",
+			origin:        "https://evil.example",
+			rpID:          "example.com",
+			userVerified:  true,
+			expectedError: ErrOriginMismatch,
+		},
+		{
+			name:          "Wrong Relying Party",
+			origin:        "https://example.com",
+			rpID:          "evil.example",
+			userVerified:  true,
+			expectedError: ErrRelyingPartyMismatch,
+		},
+		{
+			name:          "User Not Verified",
+			origin:        "https://example.com",
+			rpID:          "example.com",
+			expectedError: ErrUserNotVerified,
+		},
+		{
+			name:         "Wrong Challenge",
+			origin:       "https://example.com",
+			rpID:         "example.com",
+			userVerified: true,
+			mutate: func(challenge string, attestation *softauthn.Attestation) string {
+				other, _ := GenerateChallenge()
+				return other
+			},
+			expectedError: ErrChallengeMismatch,
+		},
+		{
+			name:         "Malformed Attestation Object",
+			origin:       "https://example.com",
+			rpID:         "example.com",
+			userVerified: true,
+			mutate: func(challenge string, attestation *softauthn.Attestation) string {
+				attestation.AttestationObject = attestation.AttestationObject[:len(attestation.AttestationObject)-1]
+				return challenge
+			},
+			expectedError: ErrInvalidAttestation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authenticator := softauthn.New(tc.origin)
+			authenticator.UserVerified = tc.userVerified
+
+			challenge, err := GenerateChallenge()
+			assert.NoError(t, err)
+
+			attestation, err := authenticator.Register(tc.rpID, challenge, UserHandle(1))
+			assert.NoError(t, err)
+
+			if tc.mutate != nil {
+				challenge = tc.mutate(challenge, attestation)
+			}
+
+			cred, err := testRelyingParty.VerifyRegistration(challenge, AttestationResponse{
+				ClientDataJSON:    attestation.ClientDataJSON,
+				AttestationObject: attestation.AttestationObject,
+			})
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, cred)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, attestation.CredentialID, cred.ID)
+				assert.NotEmpty(t, cred.PublicKey)
+				assert.Equal(t, uint32(0), cred.SignCount)
+			}
+		})
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	authenticator := softauthn.New("https://example.com")
+	cred := register(t, authenticator)
+
+	challenge, err := GenerateChallenge()
+	assert.NoError(t, err)
+
+	assertion, err := authenticator.Login(testRelyingParty.ID, challenge, cred.ID)
+	assert.NoError(t, err)
+
+	resp := AssertionResponse{
+		ClientDataJSON:    assertion.ClientDataJSON,
+		AuthenticatorData: assertion.AuthenticatorData,
+		Signature:         assertion.Signature,
+	}
+
+	signCount, err := testRelyingParty.VerifyAssertion(challenge, *cred, resp)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), signCount)
+
+	// A replayed assertion carries a counter that did not increase.
+	replayed := *cred
+	replayed.SignCount = signCount
+	_, err = testRelyingParty.VerifyAssertion(challenge, replayed, resp)
+	assert.Equal(t, ErrSignCountRegressed, err)
+
+	_, err = testRelyingParty.VerifyAssertion("other", *cred, resp)
+	assert.Equal(t, ErrChallengeMismatch, err)
+
+	tampered := resp
+	tampered.Signature = append([]byte(nil), resp.Signature...)
+	tampered.Signature[len(tampered.Signature)-1] ^= 0xff
+	_, err = testRelyingParty.VerifyAssertion(challenge, *cred, tampered)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	// The client data of a registration cannot be replayed as a login.
+	attestation, err := authenticator.Register(testRelyingParty.ID, challenge, UserHandle(1))
+	assert.NoError(t, err)
+	wrongType := resp
+	wrongType.ClientDataJSON = attestation.ClientDataJSON
+	_, err = testRelyingParty.VerifyAssertion(challenge, *cred, wrongType)
+	assert.Equal(t, ErrInvalidClientData, err)
+
+	other := register(t, softauthn.New("https://example.com"))
+	_, err = testRelyingParty.VerifyAssertion(challenge, Credential{ID: cred.ID, PublicKey: other.PublicKey}, resp)
+	assert.Equal(t, ErrInvalidSignature, err)
+}
+
+func TestUserHandle(t *testing.T) {
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0x01, 0x02}, UserHandle(258))
+}
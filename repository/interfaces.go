@@ -6,6 +6,7 @@ package repository
 
 import (
 	"context"
+	"time"
 )
 
 type RepositoryInterface interface {
@@ -13,7 +14,71 @@ type RepositoryInterface interface {
 	InsertUser(ctx context.Context, user *User) (*User, error)
 	GetUserByPhone(ctx context.Context, phone string) (*User, error)
 	GetUserByID(ctx context.Context, userID int64) (*User, error)
+	ListUsers(ctx context.Context, filter UserFilter) ([]*User, error)
+	GetUserAccess(ctx context.Context, userID int64) (*UserAccess, error)
+	GetRoleByName(ctx context.Context, name string) (*Role, error)
+	AssignUserRole(ctx context.Context, userID, roleID int64) error
+	RemoveUserRole(ctx context.Context, userID, roleID int64) error
 	IncrementLoginCount(ctx context.Context, userID int64) error
 	InsertUserActivityLog(ctx context.Context, userID int64, activityType string) error
+	ListRecentUserActivityLogs(ctx context.Context, userID int64, limit int) ([]*UserActivityLog, error)
+	ListUserActivityLogs(ctx context.Context, filter UserActivityLogFilter) ([]*UserActivityLog, error)
+	CountUserActivitySince(ctx context.Context, userID int64, activityType string, since time.Time) (int64, error)
+	StreamUserActivityLogs(ctx context.Context, userID int64, fn func(*UserActivityLog) error) error
+	StreamUserSessions(ctx context.Context, userID int64, fn func(*UserSession) error) error
+	StreamUserVerificationCodes(ctx context.Context, userID int64, fn func(*VerificationCode) error) error
+	InsertFailedLoginAttempt(ctx context.Context, ipAddress string) error
+	CountFailedLoginAttemptsSince(ctx context.Context, ipAddress string, since time.Time) (int64, error)
+	IncrementFailedLoginCount(ctx context.Context, userID int64) (int64, error)
+	LockUser(ctx context.Context, userID int64, lockedUntil time.Time) error
+	ClearLoginLockout(ctx context.Context, userID int64) error
+	GetUserStatus(ctx context.Context, userID int64) (string, error)
+	UpdateUserStatus(ctx context.Context, userID int64, status, reason string) error
+	ScheduleUserDeletion(ctx context.Context, userID int64, deleteAfter time.Time) error
+	CancelUserDeletion(ctx context.Context, userID int64) error
+	ListUsersDueForErasure(ctx context.Context, before time.Time, limit int) ([]int64, error)
+	AnonymizeUser(ctx context.Context, userID int64) (bool, error)
+	ScrubUserActivityLogs(ctx context.Context, userID int64) error
+	DeleteUserVerificationCodes(ctx context.Context, userID int64) error
+	DeleteUserTOTP(ctx context.Context, userID int64) error
 	UpdateUser(ctx context.Context, userID int64, fullName, phoneNumber string) error
+	UpdateUserPassword(ctx context.Context, userID int64, hashedPassword string) error
+	SetPendingPhone(ctx context.Context, userID int64, phoneNumber string) error
+	MarkPhoneVerified(ctx context.Context, userID int64) error
+	InsertUserSession(ctx context.Context, session *UserSession) (*UserSession, error)
+	ListUserSessions(ctx context.Context, userID int64, activeSince time.Time) ([]*UserSession, error)
+	IsUserSessionActive(ctx context.Context, userID, sessionID int64) (bool, error)
+	TouchUserSession(ctx context.Context, sessionID int64, seenBefore time.Time) error
+	RevokeUserSession(ctx context.Context, userID, sessionID int64) (bool, error)
+	RevokeAllUserSessions(ctx context.Context, userID int64) error
+	ScrubUserSessions(ctx context.Context, userID int64) error
+	RevokeSessionRefreshTokens(ctx context.Context, sessionID int64) error
+	InsertRefreshToken(ctx context.Context, token *RefreshToken) (*RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tokenID int64) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int64) error
+	RevokeToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) error
+	ConsumeToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) (bool, error)
+	RevokeAllUserTokens(ctx context.Context, userID int64, revokedBefore time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error)
+	InsertVerificationCode(ctx context.Context, code *VerificationCode) (*VerificationCode, error)
+	GetLatestVerificationCode(ctx context.Context, userID int64, purpose string) (*VerificationCode, error)
+	CountVerificationCodesSince(ctx context.Context, userID int64, purpose string, since time.Time) (int64, error)
+	SpendVerificationCodeAttempt(ctx context.Context, codeID int64, maxAttempts int) (bool, error)
+	ConsumeVerificationCode(ctx context.Context, codeID int64) (bool, error)
+	UpsertUserTOTP(ctx context.Context, userID int64, secret string) error
+	GetUserTOTP(ctx context.Context, userID int64) (*UserTOTP, error)
+	ConfirmUserTOTP(ctx context.Context, userID int64) error
+	UpdateTOTPLastUsedStep(ctx context.Context, userID int64, step int64) (bool, error)
+	DeleteRecoveryCodes(ctx context.Context, userID int64) error
+	InsertRecoveryCode(ctx context.Context, userID int64, codeHash string) error
+	GetUnusedRecoveryCodes(ctx context.Context, userID int64) ([]*RecoveryCode, error)
+	UseRecoveryCode(ctx context.Context, codeID int64) (bool, error)
+	InsertWebAuthnCredential(ctx context.Context, credential *WebAuthnCredential) (*WebAuthnCredential, error)
+	GetWebAuthnCredential(ctx context.Context, credentialID []byte) (*WebAuthnCredential, error)
+	ListUserWebAuthnCredentials(ctx context.Context, userID int64) ([]*WebAuthnCredential, error)
+	StreamUserWebAuthnCredentials(ctx context.Context, userID int64, fn func(*WebAuthnCredential) error) error
+	UpdateWebAuthnCredentialSignCount(ctx context.Context, id, signCount int64) (bool, error)
+	DeleteUserWebAuthnCredentials(ctx context.Context, userID int64) error
 }
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+// AnonymizeUser replaces the personal data of an account scheduled for
+// deletion and marks it deleted. It reports false when the account is no
+// longer scheduled for deletion, for example because the deletion was
+// cancelled meanwhile.
+func (c *Client) AnonymizeUser(ctx context.Context, userID int64) (bool, error) {
+	query := `UPDATE users SET full_name = 'Deleted User', phone = NULL, pending_phone = NULL, hashed_password = '', phone_verified_at = NULL, status = 'deleted', status_reason = NULL, status_changed_at = CURRENT_TIMESTAMP, deletion_scheduled_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'pending_deletion'`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymizeUser(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE users SET full_name = 'Deleted User', phone = NULL, pending_phone = NULL, hashed_password = '', phone_verified_at = NULL, status = 'deleted', status_reason = NULL, status_changed_at = CURRENT_TIMESTAMP, deletion_scheduled_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'pending_deletion'`)
+
+	testCases := []struct {
+		name               string
+		userID             int64
+		expectedAnonymized bool
+		expectedError      error
+		transactionCtx     bool
+	}{
+		{
+			name:               "Successful Anonymize",
+			userID:             1,
+			expectedAnonymized: true,
+			expectedError:      nil,
+			transactionCtx:     false,
+		},
+		{
+			name:               "Deletion Cancelled",
+			userID:             2,
+			expectedAnonymized: false,
+			expectedError:      nil,
+			transactionCtx:     false,
+		},
+		{
+			name:               "Error Executing Query",
+			userID:             3,
+			expectedAnonymized: false,
+			expectedError:      errors.New("some error"),
+			transactionCtx:     false,
+		},
+		{
+			name:               "Successful Anonymize with Transaction",
+			userID:             4,
+			expectedAnonymized: true,
+			expectedError:      nil,
+			transactionCtx:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Anonymize":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Deletion Cancelled":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnError(errors.New("some error"))
+			case "Successful Anonymize with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var anonymized bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					anonymized, err = repo.AnonymizeUser(ctx, tc.userID)
+					return err
+				})
+			} else {
+				anonymized, err = repo.AnonymizeUser(ctx, tc.userID)
+			}
+
+			assert.Equal(t, tc.expectedAnonymized, anonymized)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
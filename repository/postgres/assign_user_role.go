@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+func (c *Client) AssignUserRole(ctx context.Context, userID, roleID int64) error {
+
+	query := `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, userID, roleID)
+
+	return err
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignUserRole(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`)
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Exec without Transaction",
+			userID:         1,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			userID:         2,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Exec with Transaction",
+			userID:         3,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Exec without Transaction":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID, int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID, int64(5)).WillReturnError(errors.New("some error"))
+			case "Successful Exec with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID, int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.AssignUserRole(ctx, tc.userID, 5)
+				})
+			} else {
+				err = repo.AssignUserRole(ctx, tc.userID, 5)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
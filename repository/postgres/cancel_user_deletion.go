@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+// CancelUserDeletion makes an account scheduled for deletion active again.
+func (c *Client) CancelUserDeletion(ctx context.Context, userID int64) error {
+
+	query := `UPDATE users SET status = 'active', status_reason = NULL, status_changed_at = CURRENT_TIMESTAMP, deletion_scheduled_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'pending_deletion'`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, userID)
+
+	return err
+}
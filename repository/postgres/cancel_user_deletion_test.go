@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelUserDeletion(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE users SET status = 'active', status_reason = NULL, status_changed_at = CURRENT_TIMESTAMP, deletion_scheduled_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'pending_deletion'`)
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Cancel without Transaction",
+			userID:         1,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			userID:         2,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Cancel with Transaction",
+			userID:         3,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Cancel without Transaction":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnError(errors.New("some error"))
+			case "Successful Cancel with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.CancelUserDeletion(ctx, tc.userID)
+				})
+			} else {
+				err = repo.CancelUserDeletion(ctx, tc.userID)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
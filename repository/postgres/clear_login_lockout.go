@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+func (c *Client) ClearLoginLockout(ctx context.Context, userID int64) error {
+
+	query := `UPDATE users SET failed_login_count = 0, locked_until = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, userID)
+
+	return err
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumeToken(t *testing.T) {
+	expiresAt := time.Now().Add(5 * time.Minute)
+	query := regexp.QuoteMeta(`INSERT INTO revoked_tokens (jti, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING`)
+
+	testCases := []struct {
+		name             string
+		tokenID          string
+		expectedConsumed bool
+		expectedError    error
+		transactionCtx   bool
+	}{
+		{
+			name:             "Successful Consume",
+			tokenID:          "token-1",
+			expectedConsumed: true,
+			expectedError:    nil,
+			transactionCtx:   false,
+		},
+		{
+			name:             "Already Consumed",
+			tokenID:          "token-2",
+			expectedConsumed: false,
+			expectedError:    nil,
+			transactionCtx:   false,
+		},
+		{
+			name:             "Error Executing Query",
+			tokenID:          "token-3",
+			expectedConsumed: false,
+			expectedError:    errors.New("some error"),
+			transactionCtx:   false,
+		},
+		{
+			name:             "Successful Consume with Transaction",
+			tokenID:          "token-4",
+			expectedConsumed: true,
+			expectedError:    nil,
+			transactionCtx:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Consume":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID, int64(1), expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Already Consumed":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID, int64(1), expiresAt).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID, int64(1), expiresAt).WillReturnError(errors.New("some error"))
+			case "Successful Consume with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID, int64(1), expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var consumed bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					consumed, err = repo.ConsumeToken(ctx, tc.tokenID, 1, expiresAt)
+					return err
+				})
+			} else {
+				consumed, err = repo.ConsumeToken(ctx, tc.tokenID, 1, expiresAt)
+			}
+
+			assert.Equal(t, tc.expectedConsumed, consumed)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
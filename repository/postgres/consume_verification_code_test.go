@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumeVerificationCode(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE verification_codes SET consumed_at = CURRENT_TIMESTAMP WHERE id = $1 AND consumed_at IS NULL`)
+
+	testCases := []struct {
+		name             string
+		codeID           int64
+		expectedConsumed bool
+		expectedError    error
+		transactionCtx   bool
+	}{
+		{
+			name:             "Successful Consume",
+			codeID:           1,
+			expectedConsumed: true,
+			expectedError:    nil,
+			transactionCtx:   false,
+		},
+		{
+			name:             "Already Consumed",
+			codeID:           2,
+			expectedConsumed: false,
+			expectedError:    nil,
+			transactionCtx:   false,
+		},
+		{
+			name:             "Error Executing Query",
+			codeID:           3,
+			expectedConsumed: false,
+			expectedError:    errors.New("some error"),
+			transactionCtx:   false,
+		},
+		{
+			name:             "Successful Consume with Transaction",
+			codeID:           4,
+			expectedConsumed: true,
+			expectedError:    nil,
+			transactionCtx:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Consume":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Already Consumed":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID).WillReturnError(errors.New("some error"))
+			case "Successful Consume with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var consumed bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					consumed, err = repo.ConsumeVerificationCode(ctx, tc.codeID)
+					return err
+				})
+			} else {
+				consumed, err = repo.ConsumeVerificationCode(ctx, tc.codeID)
+			}
+
+			assert.Equal(t, tc.expectedConsumed, consumed)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func (c *Client) CountFailedLoginAttemptsSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM failed_login_attempts
+		WHERE ip_address = $1 AND created_at >= $2
+	`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, ipAddress, since).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
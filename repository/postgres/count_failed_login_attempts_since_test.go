@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountFailedLoginAttemptsSince(t *testing.T) {
+	since := time.Now().Add(-15 * time.Minute)
+	query := regexp.QuoteMeta(`SELECT COUNT(*) FROM failed_login_attempts WHERE ip_address = $1 AND created_at >= $2`)
+
+	testCases := []struct {
+		name          string
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "Successful Count",
+			expectedCount: 7,
+			expectedError: nil,
+		},
+		{
+			name:          "Error Executing Query",
+			expectedCount: 0,
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Successful Count":
+				mock.ExpectQuery(query).WithArgs("10.0.0.1", since).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs("10.0.0.1", since).WillReturnError(errors.New("some error"))
+			}
+
+			count, err := repo.CountFailedLoginAttemptsSince(context.Background(), "10.0.0.1", since)
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
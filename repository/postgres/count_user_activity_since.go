@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func (c *Client) CountUserActivitySince(ctx context.Context, userID int64, activityType string, since time.Time) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_activity_logs
+		WHERE user_id = $1 AND activity_type = $2 AND created_at >= $3
+	`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, userID, activityType, since).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
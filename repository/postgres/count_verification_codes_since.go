@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func (c *Client) CountVerificationCodesSince(ctx context.Context, userID int64, purpose string, since time.Time) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM verification_codes
+		WHERE user_id = $1 AND purpose = $2 AND created_at >= $3
+	`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, userID, purpose, since).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
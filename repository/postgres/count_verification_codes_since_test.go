@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountVerificationCodesSince(t *testing.T) {
+	since := time.Now().Add(-time.Hour)
+	query := regexp.QuoteMeta(`SELECT COUNT(*) FROM verification_codes WHERE user_id = $1 AND purpose = $2 AND created_at >= $3`)
+
+	testCases := []struct {
+		name          string
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "Successful Count",
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name:          "Error Executing Query",
+			expectedCount: 0,
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Successful Count":
+				mock.ExpectQuery(query).WithArgs(int64(1), "password_reset", since).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1), "password_reset", since).WillReturnError(errors.New("some error"))
+			}
+
+			count, err := repo.CountVerificationCodesSince(context.Background(), 1, "password_reset", since)
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
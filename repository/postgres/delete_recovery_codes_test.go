@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRecoveryCodes(t *testing.T) {
+	query := regexp.QuoteMeta(`DELETE FROM recovery_codes WHERE user_id = $1`)
+
+	testCases := []struct {
+		name           string
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Exec without Transaction",
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Exec with Transaction",
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Exec without Transaction":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Successful Exec with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.DeleteRecoveryCodes(ctx, 1)
+				})
+			} else {
+				err = repo.DeleteRecoveryCodes(ctx, 1)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
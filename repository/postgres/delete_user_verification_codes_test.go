@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteUserVerificationCodes(t *testing.T) {
+	query := regexp.QuoteMeta(`DELETE FROM verification_codes WHERE user_id = $1`)
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Delete without Transaction",
+			userID:         1,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			userID:         2,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Delete with Transaction",
+			userID:         3,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Delete without Transaction":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnError(errors.New("some error"))
+			case "Successful Delete with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.DeleteUserVerificationCodes(ctx, tc.userID)
+				})
+			} else {
+				err = repo.DeleteUserVerificationCodes(ctx, tc.userID)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) GetLatestVerificationCode(ctx context.Context, userID int64, purpose string) (*repository.VerificationCode, error) {
+	query := `
+		SELECT id, user_id, purpose, target, code_hash, attempts, expires_at, consumed_at, created_at
+		FROM verification_codes
+		WHERE user_id = $1 AND purpose = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var code repository.VerificationCode
+	err = stmt.QueryRowContext(ctx, userID, purpose).Scan(
+		&code.ID,
+		&code.UserID,
+		&code.Purpose,
+		&code.Target,
+		&code.CodeHash,
+		&code.Attempts,
+		&code.ExpiresAt,
+		&code.ConsumedAt,
+		&code.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &code, nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLatestVerificationCode(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, user_id, purpose, target, code_hash, attempts, expires_at, consumed_at, created_at FROM verification_codes WHERE user_id = $1 AND purpose = $2 ORDER BY created_at DESC, id DESC LIMIT 1`)
+	columns := []string{"id", "user_id", "purpose", "target", "code_hash", "attempts", "expires_at", "consumed_at", "created_at"}
+
+	testCases := []struct {
+		name          string
+		expectedCode  *repository.VerificationCode
+		expectedError error
+	}{
+		{
+			name: "Code Exists",
+			expectedCode: &repository.VerificationCode{
+				ID:         1,
+				UserID:     1,
+				Purpose:    "password_reset",
+				Target:     "+628123456789",
+				CodeHash:   "hash",
+				Attempts:   2,
+				ExpiresAt:  now,
+				ConsumedAt: &now,
+				CreatedAt:  now,
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Code Not Found",
+			expectedCode:  nil,
+			expectedError: nil,
+		},
+		{
+			name:          "Error Executing Query",
+			expectedCode:  nil,
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Code Exists":
+				rows := sqlmock.NewRows(columns).AddRow(1, 1, "password_reset", "+628123456789", "hash", 2, now, now, now)
+				mock.ExpectQuery(query).WithArgs(int64(1), "password_reset").WillReturnRows(rows)
+			case "Code Not Found":
+				mock.ExpectQuery(query).WithArgs(int64(1), "password_reset").WillReturnError(sql.ErrNoRows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1), "password_reset").WillReturnError(errors.New("some error"))
+			}
+
+			code, err := repo.GetLatestVerificationCode(context.Background(), 1, "password_reset")
+
+			assert.Equal(t, tc.expectedCode, code)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRefreshTokenByHash(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, user_id, session_id, family_id, token_hash, expires_at, revoked_at, created_at FROM refresh_tokens WHERE token_hash = $1 LIMIT 1`)
+	columns := []string{"id", "user_id", "session_id", "family_id", "token_hash", "expires_at", "revoked_at", "created_at"}
+
+	testCases := []struct {
+		name          string
+		tokenHash     string
+		expectedToken *repository.RefreshToken
+		expectedError error
+	}{
+		{
+			name:      "Token Exists",
+			tokenHash: "hash",
+			expectedToken: &repository.RefreshToken{
+				ID:        1,
+				UserID:    1,
+				SessionID: 7,
+				FamilyID:  "family",
+				TokenHash: "hash",
+				ExpiresAt: now,
+				CreatedAt: now,
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "Revoked Token Exists",
+			tokenHash: "revoked",
+			expectedToken: &repository.RefreshToken{
+				ID:        2,
+				UserID:    1,
+				SessionID: 7,
+				FamilyID:  "family",
+				TokenHash: "revoked",
+				ExpiresAt: now,
+				RevokedAt: &now,
+				CreatedAt: now,
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Token Not Found",
+			tokenHash:     "missing",
+			expectedToken: nil,
+			expectedError: nil,
+		},
+		{
+			name:          "Error Executing Query",
+			tokenHash:     "error",
+			expectedToken: nil,
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Token Exists":
+				rows := sqlmock.NewRows(columns).AddRow(1, 1, 7, "family", "hash", now, nil, now)
+				mock.ExpectQuery(query).WithArgs(tc.tokenHash).WillReturnRows(rows)
+			case "Revoked Token Exists":
+				rows := sqlmock.NewRows(columns).AddRow(2, 1, 7, "family", "revoked", now, now, now)
+				mock.ExpectQuery(query).WithArgs(tc.tokenHash).WillReturnRows(rows)
+			case "Token Not Found":
+				mock.ExpectQuery(query).WithArgs(tc.tokenHash).WillReturnError(sql.ErrNoRows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(tc.tokenHash).WillReturnError(errors.New("some error"))
+			}
+
+			token, err := repo.GetRefreshTokenByHash(context.Background(), tc.tokenHash)
+
+			assert.Equal(t, tc.expectedToken, token)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
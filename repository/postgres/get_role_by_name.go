@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) GetRoleByName(ctx context.Context, name string) (*repository.Role, error) {
+	query := `SELECT id, name, created_at FROM roles WHERE name = $1 LIMIT 1`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var role repository.Role
+	err = stmt.QueryRowContext(ctx, name).Scan(
+		&role.ID,
+		&role.Name,
+		&role.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
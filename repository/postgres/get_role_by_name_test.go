@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRoleByName(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, name, created_at FROM roles WHERE name = $1 LIMIT 1`)
+
+	testCases := []struct {
+		name          string
+		roleName      string
+		expectedRole  *repository.Role
+		expectedError error
+	}{
+		{
+			name:          "Role Exists",
+			roleName:      "admin",
+			expectedRole:  &repository.Role{ID: 1, Name: "admin", CreatedAt: now},
+			expectedError: nil,
+		},
+		{
+			name:          "Role Not Found",
+			roleName:      "owner",
+			expectedRole:  nil,
+			expectedError: nil,
+		},
+		{
+			name:          "Error Executing Query",
+			roleName:      "admin",
+			expectedRole:  nil,
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Role Exists":
+				rows := sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(1, "admin", now)
+				mock.ExpectQuery(query).WithArgs(tc.roleName).WillReturnRows(rows)
+			case "Role Not Found":
+				mock.ExpectQuery(query).WithArgs(tc.roleName).WillReturnError(sql.ErrNoRows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(tc.roleName).WillReturnError(errors.New("some error"))
+			}
+
+			role, err := repo.GetRoleByName(context.Background(), tc.roleName)
+
+			assert.Equal(t, tc.expectedRole, role)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) GetUnusedRecoveryCodes(ctx context.Context, userID int64) ([]*repository.RecoveryCode, error) {
+	query := `SELECT id, user_id, code_hash, used_at, created_at FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL ORDER BY id`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := []*repository.RecoveryCode{}
+	for rows.Next() {
+		var code repository.RecoveryCode
+		err = rows.Scan(
+			&code.ID,
+			&code.UserID,
+			&code.CodeHash,
+			&code.UsedAt,
+			&code.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, &code)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
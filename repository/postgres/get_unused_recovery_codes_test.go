@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUnusedRecoveryCodes(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, user_id, code_hash, used_at, created_at FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL ORDER BY id`)
+	columns := []string{"id", "user_id", "code_hash", "used_at", "created_at"}
+
+	testCases := []struct {
+		name          string
+		expectedCodes []*repository.RecoveryCode
+		expectedError error
+	}{
+		{
+			name: "Codes Exist",
+			expectedCodes: []*repository.RecoveryCode{
+				{ID: 1, UserID: 1, CodeHash: "hash 1", CreatedAt: now},
+				{ID: 2, UserID: 1, CodeHash: "hash 2", CreatedAt: now},
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "No Codes",
+			expectedCodes: []*repository.RecoveryCode{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error Executing Query",
+			expectedCodes: nil,
+			expectedError: errors.New("some error"),
+		},
+		{
+			name:          "Error Scanning Row",
+			expectedCodes: nil,
+			expectedError: errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Codes Exist":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "hash 1", nil, now).
+					AddRow(2, 1, "hash 2", nil, now)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "No Codes":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows(columns))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "hash 1", nil, now).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			}
+
+			codes, err := repo.GetUnusedRecoveryCodes(context.Background(), 1)
+
+			assert.Equal(t, tc.expectedCodes, codes)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
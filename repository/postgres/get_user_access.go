@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) GetUserAccess(ctx context.Context, userID int64) (*repository.UserAccess, error) {
+	query := `SELECT r.name, p.name FROM user_roles ur JOIN roles r ON r.id = ur.role_id LEFT JOIN role_permissions rp ON rp.role_id = r.id LEFT JOIN permissions p ON p.id = rp.permission_id WHERE ur.user_id = $1 ORDER BY r.name, p.name`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	access := &repository.UserAccess{
+		Roles:       []string{},
+		Permissions: []string{},
+	}
+	roles := map[string]struct{}{}
+	permissions := map[string]struct{}{}
+
+	for rows.Next() {
+		var role string
+		var permission sql.NullString
+		err = rows.Scan(&role, &permission)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := roles[role]; !ok {
+			roles[role] = struct{}{}
+			access.Roles = append(access.Roles, role)
+		}
+
+		// Roles share permissions, so each one is only listed once.
+		if _, ok := permissions[permission.String]; permission.Valid && !ok {
+			permissions[permission.String] = struct{}{}
+			access.Permissions = append(access.Permissions, permission.String)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(access.Permissions)
+
+	return access, nil
+}
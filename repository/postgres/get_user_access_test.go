@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserAccess(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT r.name, p.name FROM user_roles ur JOIN roles r ON r.id = ur.role_id LEFT JOIN role_permissions rp ON rp.role_id = r.id LEFT JOIN permissions p ON p.id = rp.permission_id WHERE ur.user_id = $1 ORDER BY r.name, p.name`)
+	columns := []string{"role", "permission"}
+
+	testCases := []struct {
+		name           string
+		expectedAccess *repository.UserAccess
+		expectedError  error
+	}{
+		{
+			name: "Roles With Shared Permissions",
+			expectedAccess: &repository.UserAccess{
+				Roles:       []string{"admin", "auditor", "support"},
+				Permissions: []string{"roles:assign", "users:read", "users:unlock"},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "No Roles",
+			expectedAccess: &repository.UserAccess{
+				Roles:       []string{},
+				Permissions: []string{},
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error Executing Query",
+			expectedAccess: nil,
+			expectedError:  errors.New("some error"),
+		},
+		{
+			name:           "Error Scanning Row",
+			expectedAccess: nil,
+			expectedError:  errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Roles With Shared Permissions":
+				rows := sqlmock.NewRows(columns).
+					AddRow("admin", "roles:assign").
+					AddRow("admin", "users:read").
+					AddRow("admin", "users:unlock").
+					AddRow("auditor", nil).
+					AddRow("support", "users:read").
+					AddRow("support", "users:unlock")
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "No Roles":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows(columns))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow("admin", "users:read").
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			}
+
+			access, err := repo.GetUserAccess(context.Background(), 1)
+
+			assert.Equal(t, tc.expectedAccess, access)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
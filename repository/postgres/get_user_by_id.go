@@ -10,7 +10,7 @@ import (
 )
 
 func (c *Client) GetUserByID(ctx context.Context, userID int64) (*repository.User, error) {
-	query := `SELECT id, full_name, hashed_password, phone, login_count, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`
+	query := `SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`
 
 	stmt, err := c.DB.PrepareContext(ctx, query)
 	if err != nil {
@@ -24,7 +24,15 @@ func (c *Client) GetUserByID(ctx context.Context, userID int64) (*repository.Use
 		&user.FullName,
 		&user.HashedPassword,
 		&user.Phone,
+		&user.PhoneVerifiedAt,
+		&user.PendingPhone,
 		&user.LoginCount,
+		&user.FailedLoginCount,
+		&user.LockedUntil,
+		&user.Status,
+		&user.StatusReason,
+		&user.StatusChangedAt,
+		&user.DeletionScheduledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -25,13 +25,15 @@ func TestGetUserByID(t *testing.T) {
 			name:   "User Exists",
 			userID: 1,
 			expectedUser: &repository.User{
-				ID:             1,
-				FullName:       "maulana aji satrio",
-				HashedPassword: "Maulana1996@",
-				Phone:          "+628232482440",
-				LoginCount:     1,
-				CreatedAt:      now,
-				UpdatedAt:      now,
+				ID:              1,
+				FullName:        "maulana aji satrio",
+				HashedPassword:  "Maulana1996@",
+				Phone:           "+628232482440",
+				PhoneVerifiedAt: &now,
+				LoginCount:      1,
+				Status:          "active",
+				CreatedAt:       now,
+				UpdatedAt:       now,
 			},
 			expectedError: nil,
 		},
@@ -61,16 +63,16 @@ func TestGetUserByID(t *testing.T) {
 				DB: mockDB,
 			}
 
-			mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, phone, login_count, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`))
+			mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`))
 			switch tc.name {
 			case "User Exists":
-				rows := sqlmock.NewRows([]string{"id", "full_name", "hashed_password", "phone", "login_count", "created_at", "updated_at"}).
-					AddRow(1, "maulana aji satrio", "Maulana1996@", "+628232482440", 1, now, now)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, phone, login_count, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`)).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "full_name", "hashed_password", "phone", "phone_verified_at", "pending_phone", "login_count", "failed_login_count", "locked_until", "status", "status_reason", "status_changed_at", "deletion_scheduled_at", "created_at", "updated_at"}).
+					AddRow(1, "maulana aji satrio", "Maulana1996@", "+628232482440", now, nil, 1, 0, nil, "active", nil, nil, nil, now, now)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`)).WillReturnRows(rows)
 			case "User Not Found":
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, phone, login_count, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`)).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`)).WillReturnError(sql.ErrNoRows)
 			case "Error Executing Query":
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, phone, login_count, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`)).WillReturnError(errors.New("some error"))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at FROM users WHERE id = $1 LIMIT 1`)).WillReturnError(errors.New("some error"))
 			}
 
 			user, err := repo.GetUserByID(context.Background(), tc.userID)
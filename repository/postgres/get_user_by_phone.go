@@ -11,7 +11,7 @@ import (
 
 func (c *Client) GetUserByPhone(ctx context.Context, phoneNumber string) (*repository.User, error) {
 	query := `
-		SELECT id, full_name, hashed_password, phone, login_count, created_at, updated_at
+		SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at
 		FROM users
 		WHERE phone = $1
 		LIMIT 1
@@ -29,7 +29,15 @@ func (c *Client) GetUserByPhone(ctx context.Context, phoneNumber string) (*repos
 		&user.FullName,
 		&user.HashedPassword,
 		&user.Phone,
+		&user.PhoneVerifiedAt,
+		&user.PendingPhone,
 		&user.LoginCount,
+		&user.FailedLoginCount,
+		&user.LockedUntil,
+		&user.Status,
+		&user.StatusReason,
+		&user.StatusChangedAt,
+		&user.DeletionScheduledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+)
+
+// GetUserStatus returns the account status of the user, or an empty string
+// when the user does not exist.
+func (c *Client) GetUserStatus(ctx context.Context, userID int64) (string, error) {
+	query := `SELECT status FROM users WHERE id = $1`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+
+	var status string
+	err = stmt.QueryRowContext(ctx, userID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return status, nil
+}
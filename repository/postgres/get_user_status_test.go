@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserStatus(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT status FROM users WHERE id = $1`)
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name:           "User Exists",
+			userID:         1,
+			expectedStatus: "suspended",
+			expectedError:  nil,
+		},
+		{
+			name:           "User Not Found",
+			userID:         2,
+			expectedStatus: "",
+			expectedError:  nil,
+		},
+		{
+			name:           "Error Executing Query",
+			userID:         3,
+			expectedStatus: "",
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "User Exists":
+				rows := sqlmock.NewRows([]string{"status"}).AddRow("suspended")
+				mock.ExpectQuery(query).WithArgs(tc.userID).WillReturnRows(rows)
+			case "User Not Found":
+				mock.ExpectQuery(query).WithArgs(tc.userID).WillReturnError(sql.ErrNoRows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(tc.userID).WillReturnError(errors.New("some error"))
+			}
+
+			status, err := repo.GetUserStatus(context.Background(), tc.userID)
+
+			assert.Equal(t, tc.expectedStatus, status)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
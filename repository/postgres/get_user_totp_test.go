@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserTOTP(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT user_id, secret, confirmed_at, last_used_step, created_at FROM user_totp WHERE user_id = $1 LIMIT 1`)
+
+	testCases := []struct {
+		name          string
+		expectedTOTP  *repository.UserTOTP
+		expectedError error
+	}{
+		{
+			name: "TOTP Exists",
+			expectedTOTP: &repository.UserTOTP{
+				UserID:       1,
+				Secret:       "SECRET",
+				ConfirmedAt:  &now,
+				LastUsedStep: 100,
+				CreatedAt:    now,
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "TOTP Not Found",
+			expectedTOTP:  nil,
+			expectedError: nil,
+		},
+		{
+			name:          "Error Executing Query",
+			expectedTOTP:  nil,
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "TOTP Exists":
+				rows := sqlmock.NewRows([]string{"user_id", "secret", "confirmed_at", "last_used_step", "created_at"}).
+					AddRow(1, "SECRET", now, 100, now)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "TOTP Not Found":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			}
+
+			totp, err := repo.GetUserTOTP(context.Background(), 1)
+
+			assert.Equal(t, tc.expectedTOTP, totp)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) GetWebAuthnCredential(ctx context.Context, credentialID []byte) (*repository.WebAuthnCredential, error) {
+	query := `SELECT id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at FROM webauthn_credentials WHERE credential_id = $1 LIMIT 1`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var credential repository.WebAuthnCredential
+	var transports string
+	err = stmt.QueryRowContext(ctx, credentialID).Scan(
+		&credential.ID,
+		&credential.UserID,
+		&credential.CredentialID,
+		&credential.PublicKey,
+		&credential.SignCount,
+		&transports,
+		&credential.LastUsedAt,
+		&credential.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	credential.Transports = splitTransports(transports)
+
+	return &credential, nil
+}
+
+// splitTransports reads the comma separated transports column.
+func splitTransports(transports string) []string {
+	if transports == "" {
+		return nil
+	}
+
+	return strings.Split(transports, ",")
+}
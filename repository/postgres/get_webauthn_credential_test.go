@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWebAuthnCredential(t *testing.T) {
+	now := time.Now()
+	credentialID := []byte{1, 2, 3}
+	query := regexp.QuoteMeta(`SELECT id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at FROM webauthn_credentials WHERE credential_id = $1 LIMIT 1`)
+
+	testCases := []struct {
+		name               string
+		expectedCredential *repository.WebAuthnCredential
+		expectedError      error
+	}{
+		{
+			name: "Credential Exists",
+			expectedCredential: &repository.WebAuthnCredential{
+				ID:           1,
+				UserID:       2,
+				CredentialID: credentialID,
+				PublicKey:    []byte{4},
+				SignCount:    7,
+				Transports:   []string{"usb", "nfc"},
+				LastUsedAt:   &now,
+				CreatedAt:    now,
+			},
+			expectedError: nil,
+		},
+		{
+			name:               "Credential Not Found",
+			expectedCredential: nil,
+			expectedError:      nil,
+		},
+		{
+			name:               "Error Executing Query",
+			expectedCredential: nil,
+			expectedError:      errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Credential Exists":
+				rows := sqlmock.NewRows([]string{"id", "user_id", "credential_id", "public_key", "sign_count", "transports", "last_used_at", "created_at"}).
+					AddRow(1, 2, credentialID, []byte{4}, 7, "usb,nfc", now, now)
+				mock.ExpectQuery(query).WithArgs(credentialID).WillReturnRows(rows)
+			case "Credential Not Found":
+				mock.ExpectQuery(query).WithArgs(credentialID).WillReturnError(sql.ErrNoRows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(credentialID).WillReturnError(errors.New("some error"))
+			}
+
+			credential, err := repo.GetWebAuthnCredential(context.Background(), credentialID)
+
+			assert.Equal(t, tc.expectedCredential, credential)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
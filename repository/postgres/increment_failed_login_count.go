@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+// IncrementFailedLoginCount returns the count after the increment, so the
+// caller can decide on a lockout without a second read.
+func (c *Client) IncrementFailedLoginCount(ctx context.Context, userID int64) (int64, error) {
+	query := `
+		UPDATE users
+		SET failed_login_count = failed_login_count + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING failed_login_count
+	`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
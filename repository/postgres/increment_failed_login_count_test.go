@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncrementFailedLoginCount(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE users SET failed_login_count = failed_login_count + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING failed_login_count`)
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		expectedCount  int64
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Update without Transaction",
+			userID:         1,
+			expectedCount:  3,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			userID:         2,
+			expectedCount:  0,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Update with Transaction",
+			userID:         3,
+			expectedCount:  5,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Update without Transaction":
+				mock.ExpectPrepare(query)
+				mock.ExpectQuery(query).WithArgs(tc.userID).WillReturnRows(sqlmock.NewRows([]string{"failed_login_count"}).AddRow(3))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectQuery(query).WithArgs(tc.userID).WillReturnError(errors.New("some error"))
+			case "Successful Update with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectQuery(query).WithArgs(tc.userID).WillReturnRows(sqlmock.NewRows([]string{"failed_login_count"}).AddRow(5))
+				mock.ExpectCommit()
+			}
+
+			var count int64
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					count, err = repo.IncrementFailedLoginCount(ctx, tc.userID)
+					return err
+				})
+			} else {
+				count, err = repo.IncrementFailedLoginCount(ctx, tc.userID)
+			}
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertFailedLoginAttempt(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO failed_login_attempts (ip_address) VALUES ($1)`)
+
+	testCases := []struct {
+		name           string
+		ipAddress      string
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Insert without Transaction",
+			ipAddress:      "10.0.0.1",
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			ipAddress:      "10.0.0.2",
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Insert with Transaction",
+			ipAddress:      "10.0.0.3",
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Insert without Transaction":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.ipAddress).WillReturnResult(sqlmock.NewResult(1, 1))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.ipAddress).WillReturnError(errors.New("some error"))
+			case "Successful Insert with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.ipAddress).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.InsertFailedLoginAttempt(ctx, tc.ipAddress)
+				})
+			} else {
+				err = repo.InsertFailedLoginAttempt(ctx, tc.ipAddress)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
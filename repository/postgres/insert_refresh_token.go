@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) InsertRefreshToken(ctx context.Context, token *repository.RefreshToken) (*repository.RefreshToken, error) {
+	query := `
+		INSERT INTO refresh_tokens (user_id, session_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, session_id, family_id, token_hash, expires_at, revoked_at, created_at
+	`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	insertedToken := &repository.RefreshToken{}
+	err = stmt.QueryRowContext(ctx, token.UserID, token.SessionID, token.FamilyID, token.TokenHash, token.ExpiresAt).Scan(
+		&insertedToken.ID,
+		&insertedToken.UserID,
+		&insertedToken.SessionID,
+		&insertedToken.FamilyID,
+		&insertedToken.TokenHash,
+		&insertedToken.ExpiresAt,
+		&insertedToken.RevokedAt,
+		&insertedToken.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return insertedToken, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertRefreshToken(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`INSERT INTO refresh_tokens (user_id, session_id, family_id, token_hash, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, session_id, family_id, token_hash, expires_at, revoked_at, created_at`)
+	columns := []string{"id", "user_id", "session_id", "family_id", "token_hash", "expires_at", "revoked_at", "created_at"}
+
+	testCases := []struct {
+		name           string
+		token          *repository.RefreshToken
+		expectedToken  *repository.RefreshToken
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:  "Successful Insert without Transaction",
+			token: &repository.RefreshToken{UserID: 1, SessionID: 7, FamilyID: "family", TokenHash: "hash", ExpiresAt: now},
+			expectedToken: &repository.RefreshToken{
+				ID:        1,
+				UserID:    1,
+				SessionID: 7,
+				FamilyID:  "family",
+				TokenHash: "hash",
+				ExpiresAt: now,
+				CreatedAt: now,
+			},
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:  "Successful Insert with Transaction",
+			token: &repository.RefreshToken{UserID: 2, SessionID: 7, FamilyID: "family", TokenHash: "hash", ExpiresAt: now},
+			expectedToken: &repository.RefreshToken{
+				ID:        2,
+				UserID:    2,
+				SessionID: 7,
+				FamilyID:  "family",
+				TokenHash: "hash",
+				ExpiresAt: now,
+				CreatedAt: now,
+			},
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+		{
+			name:           "Error Executing Query",
+			token:          &repository.RefreshToken{UserID: 3, SessionID: 7, FamilyID: "family", TokenHash: "hash", ExpiresAt: now},
+			expectedToken:  nil,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			if tc.transactionCtx {
+				mock.ExpectBegin()
+			}
+			mock.ExpectPrepare(query)
+			expectedQuery := mock.ExpectQuery(query).WithArgs(tc.token.UserID, tc.token.SessionID, tc.token.FamilyID, tc.token.TokenHash, tc.token.ExpiresAt)
+			if tc.expectedError != nil {
+				expectedQuery.WillReturnError(tc.expectedError)
+			} else {
+				expectedQuery.WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(tc.expectedToken.ID, tc.expectedToken.UserID, tc.expectedToken.SessionID, tc.expectedToken.FamilyID, tc.expectedToken.TokenHash, tc.expectedToken.ExpiresAt, nil, tc.expectedToken.CreatedAt))
+			}
+			if tc.transactionCtx {
+				mock.ExpectCommit()
+			}
+
+			var token *repository.RefreshToken
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					token, err = repo.InsertRefreshToken(ctx, tc.token)
+					return err
+				})
+			} else {
+				token, err = repo.InsertRefreshToken(ctx, tc.token)
+			}
+
+			assert.Equal(t, tc.expectedToken, token)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
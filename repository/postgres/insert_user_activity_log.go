@@ -10,10 +10,12 @@ import (
 	"github.com/sawitpro/UserService/common"
 )
 
+// InsertUserActivityLog records the activity together with the client
+// details carried by ctx, leaving them NULL when there are none.
 func (c *Client) InsertUserActivityLog(ctx context.Context, userID int64, activityType string) error {
 	query := `
-		INSERT INTO user_activity_logs (user_id, activity_type)
-		VALUES ($1, $2)
+		INSERT INTO user_activity_logs (user_id, activity_type, ip_address, user_agent, device_family, os_family, request_id)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''))
 		RETURNING id
 	`
 	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
@@ -29,8 +31,10 @@ func (c *Client) InsertUserActivityLog(ctx context.Context, userID int64, activi
 	}
 	defer stmt.Close()
 
+	info := common.RequestInfoFromContext(ctx)
+
 	var result sql.Result
-	result, err = stmt.ExecContext(ctx, userID, activityType)
+	result, err = stmt.ExecContext(ctx, userID, activityType, info.IPAddress, info.UserAgent, info.DeviceFamily, info.OSFamily, info.RequestID)
 	if err != nil {
 		return err
 	}
@@ -7,10 +7,20 @@ import (
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/common"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestInsertUserActivityLog(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type, ip_address, user_agent, device_family, os_family, request_id) VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, '')) RETURNING id`)
+	info := common.RequestInfo{
+		IPAddress:    "203.0.113.7",
+		UserAgent:    "Mozilla/5.0 (Linux; Android 14; Pixel 8) Mobile",
+		DeviceFamily: "mobile",
+		OSFamily:     "android",
+		RequestID:    "req-1",
+	}
+
 	testCases := []struct {
 		name           string
 		userID         int64
@@ -39,6 +49,13 @@ func TestInsertUserActivityLog(t *testing.T) {
 			expectedError:  errors.New("no rows affected"),
 			transactionCtx: false,
 		},
+		{
+			name:           "Successful Insert with Request Info",
+			userID:         5,
+			activityType:   "login",
+			expectedError:  nil,
+			transactionCtx: false,
+		},
 		{
 			name:           "Successful Insert with Transaction",
 			userID:         4,
@@ -64,25 +81,31 @@ func TestInsertUserActivityLog(t *testing.T) {
 
 			switch tc.name {
 			case "Successful Insert":
-				mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`))
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`)).
-					WithArgs(tc.userID, tc.activityType).
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).
+					WithArgs(tc.userID, tc.activityType, "", "", "", "", "").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			case "Error Executing Query":
-				mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`))
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`)).
-					WithArgs(tc.userID, tc.activityType).
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).
+					WithArgs(tc.userID, tc.activityType, "", "", "", "", "").
 					WillReturnError(errors.New("some error"))
 			case "No Rows Affected":
-				mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`))
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`)).
-					WithArgs(tc.userID, tc.activityType).
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).
+					WithArgs(tc.userID, tc.activityType, "", "", "", "", "").
 					WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Successful Insert with Request Info":
+				ctx = context.WithValue(ctx, common.REQUEST_INFO_CTX_KEY, info)
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).
+					WithArgs(tc.userID, tc.activityType, info.IPAddress, info.UserAgent, info.DeviceFamily, info.OSFamily, info.RequestID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 			case "Successful Insert with Transaction":
 				mock.ExpectBegin()
-				mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`))
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_activity_logs (user_id, activity_type) VALUES ($1, $2) RETURNING id`)).
-					WithArgs(tc.userID, tc.activityType).
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).
+					WithArgs(tc.userID, tc.activityType, "", "", "", "", "").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) InsertUserSession(ctx context.Context, session *repository.UserSession) (*repository.UserSession, error) {
+	query := `
+		INSERT INTO user_sessions (user_id, device_name, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, device_name, ip_address, user_agent, last_seen_at, revoked_at, created_at
+	`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	insertedSession := &repository.UserSession{}
+	err = stmt.QueryRowContext(ctx, session.UserID, session.DeviceName, session.IPAddress, session.UserAgent).Scan(
+		&insertedSession.ID,
+		&insertedSession.UserID,
+		&insertedSession.DeviceName,
+		&insertedSession.IPAddress,
+		&insertedSession.UserAgent,
+		&insertedSession.LastSeenAt,
+		&insertedSession.RevokedAt,
+		&insertedSession.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return insertedSession, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertUserSession(t *testing.T) {
+	now := time.Now()
+	ip := "203.0.113.7"
+	userAgent := "Mozilla/5.0 (iPhone)"
+	query := regexp.QuoteMeta(`INSERT INTO user_sessions (user_id, device_name, ip_address, user_agent) VALUES ($1, $2, $3, $4) RETURNING id, user_id, device_name, ip_address, user_agent, last_seen_at, revoked_at, created_at`)
+	columns := []string{"id", "user_id", "device_name", "ip_address", "user_agent", "last_seen_at", "revoked_at", "created_at"}
+
+	testCases := []struct {
+		name            string
+		session         *repository.UserSession
+		expectedSession *repository.UserSession
+		expectedError   error
+		transactionCtx  bool
+	}{
+		{
+			name:    "Successful Insert without Transaction",
+			session: &repository.UserSession{UserID: 1, DeviceName: "iOS mobile", IPAddress: &ip, UserAgent: &userAgent},
+			expectedSession: &repository.UserSession{
+				ID:         1,
+				UserID:     1,
+				DeviceName: "iOS mobile",
+				IPAddress:  &ip,
+				UserAgent:  &userAgent,
+				LastSeenAt: now,
+				CreatedAt:  now,
+			},
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:    "Successful Insert with Transaction",
+			session: &repository.UserSession{UserID: 2, DeviceName: "Unknown device"},
+			expectedSession: &repository.UserSession{
+				ID:         2,
+				UserID:     2,
+				DeviceName: "Unknown device",
+				LastSeenAt: now,
+				CreatedAt:  now,
+			},
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+		{
+			name:            "Error Executing Query",
+			session:         &repository.UserSession{UserID: 3, DeviceName: "Unknown device"},
+			expectedSession: nil,
+			expectedError:   errors.New("some error"),
+			transactionCtx:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			if tc.transactionCtx {
+				mock.ExpectBegin()
+			}
+			mock.ExpectPrepare(query)
+			expectedQuery := mock.ExpectQuery(query).WithArgs(tc.session.UserID, tc.session.DeviceName, tc.session.IPAddress, tc.session.UserAgent)
+			if tc.expectedError != nil {
+				expectedQuery.WillReturnError(tc.expectedError)
+			} else {
+				expectedQuery.WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(tc.expectedSession.ID, tc.expectedSession.UserID, tc.expectedSession.DeviceName, tc.expectedSession.IPAddress, tc.expectedSession.UserAgent, now, nil, now))
+			}
+			if tc.transactionCtx {
+				mock.ExpectCommit()
+			}
+
+			var session *repository.UserSession
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					session, err = repo.InsertUserSession(ctx, tc.session)
+					return err
+				})
+			} else {
+				session, err = repo.InsertUserSession(ctx, tc.session)
+			}
+
+			assert.Equal(t, tc.expectedSession, session)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) InsertVerificationCode(ctx context.Context, code *repository.VerificationCode) (*repository.VerificationCode, error) {
+	query := `
+		INSERT INTO verification_codes (user_id, purpose, target, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, purpose, target, code_hash, attempts, expires_at, consumed_at, created_at
+	`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	insertedCode := &repository.VerificationCode{}
+	err = stmt.QueryRowContext(ctx, code.UserID, code.Purpose, code.Target, code.CodeHash, code.ExpiresAt).Scan(
+		&insertedCode.ID,
+		&insertedCode.UserID,
+		&insertedCode.Purpose,
+		&insertedCode.Target,
+		&insertedCode.CodeHash,
+		&insertedCode.Attempts,
+		&insertedCode.ExpiresAt,
+		&insertedCode.ConsumedAt,
+		&insertedCode.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return insertedCode, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertVerificationCode(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`INSERT INTO verification_codes (user_id, purpose, target, code_hash, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, purpose, target, code_hash, attempts, expires_at, consumed_at, created_at`)
+	columns := []string{"id", "user_id", "purpose", "target", "code_hash", "attempts", "expires_at", "consumed_at", "created_at"}
+
+	testCases := []struct {
+		name           string
+		code           *repository.VerificationCode
+		expectedCode   *repository.VerificationCode
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name: "Successful Insert without Transaction",
+			code: &repository.VerificationCode{UserID: 1, Purpose: "password_reset", Target: "+628123456789", CodeHash: "hash", ExpiresAt: now},
+			expectedCode: &repository.VerificationCode{
+				ID:        1,
+				UserID:    1,
+				Purpose:   "password_reset",
+				Target:    "+628123456789",
+				CodeHash:  "hash",
+				ExpiresAt: now,
+				CreatedAt: now,
+			},
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name: "Successful Insert with Transaction",
+			code: &repository.VerificationCode{UserID: 2, Purpose: "password_reset", Target: "+628123456789", CodeHash: "hash", ExpiresAt: now},
+			expectedCode: &repository.VerificationCode{
+				ID:        2,
+				UserID:    2,
+				Purpose:   "password_reset",
+				Target:    "+628123456789",
+				CodeHash:  "hash",
+				ExpiresAt: now,
+				CreatedAt: now,
+			},
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+		{
+			name:           "Error Executing Query",
+			code:           &repository.VerificationCode{UserID: 3, Purpose: "password_reset", Target: "+628123456789", CodeHash: "hash", ExpiresAt: now},
+			expectedCode:   nil,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			if tc.transactionCtx {
+				mock.ExpectBegin()
+			}
+			mock.ExpectPrepare(query)
+			expectedQuery := mock.ExpectQuery(query).WithArgs(tc.code.UserID, tc.code.Purpose, tc.code.Target, tc.code.CodeHash, tc.code.ExpiresAt)
+			if tc.expectedError != nil {
+				expectedQuery.WillReturnError(tc.expectedError)
+			} else {
+				expectedQuery.WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(tc.expectedCode.ID, tc.expectedCode.UserID, tc.expectedCode.Purpose, tc.expectedCode.Target, tc.expectedCode.CodeHash, 0, tc.expectedCode.ExpiresAt, nil, tc.expectedCode.CreatedAt))
+			}
+			if tc.transactionCtx {
+				mock.ExpectCommit()
+			}
+
+			var code *repository.VerificationCode
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					code, err = repo.InsertVerificationCode(ctx, tc.code)
+					return err
+				})
+			} else {
+				code, err = repo.InsertVerificationCode(ctx, tc.code)
+			}
+
+			assert.Equal(t, tc.expectedCode, code)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
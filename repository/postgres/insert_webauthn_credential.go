@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) InsertWebAuthnCredential(ctx context.Context, credential *repository.WebAuthnCredential) (*repository.WebAuthnCredential, error) {
+	query := `
+		INSERT INTO webauthn_credentials (user_id, credential_id, public_key, sign_count, transports)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at
+	`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	insertedCredential := &repository.WebAuthnCredential{}
+	var transports string
+	err = stmt.QueryRowContext(ctx, credential.UserID, credential.CredentialID, credential.PublicKey, credential.SignCount, strings.Join(credential.Transports, ",")).Scan(
+		&insertedCredential.ID,
+		&insertedCredential.UserID,
+		&insertedCredential.CredentialID,
+		&insertedCredential.PublicKey,
+		&insertedCredential.SignCount,
+		&transports,
+		&insertedCredential.LastUsedAt,
+		&insertedCredential.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	insertedCredential.Transports = splitTransports(transports)
+
+	return insertedCredential, nil
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertWebAuthnCredential(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`INSERT INTO webauthn_credentials (user_id, credential_id, public_key, sign_count, transports) VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at`)
+	columns := []string{"id", "user_id", "credential_id", "public_key", "sign_count", "transports", "last_used_at", "created_at"}
+
+	testCases := []struct {
+		name               string
+		credential         *repository.WebAuthnCredential
+		transports         string
+		expectedCredential *repository.WebAuthnCredential
+		expectedError      error
+		transactionCtx     bool
+	}{
+		{
+			name:       "Successful Insert without Transaction",
+			credential: &repository.WebAuthnCredential{UserID: 1, CredentialID: []byte{1}, PublicKey: []byte{2}, Transports: []string{"internal", "hybrid"}},
+			transports: "internal,hybrid",
+			expectedCredential: &repository.WebAuthnCredential{
+				ID:           1,
+				UserID:       1,
+				CredentialID: []byte{1},
+				PublicKey:    []byte{2},
+				Transports:   []string{"internal", "hybrid"},
+				CreatedAt:    now,
+			},
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:       "Successful Insert with Transaction",
+			credential: &repository.WebAuthnCredential{UserID: 2, CredentialID: []byte{3}, PublicKey: []byte{4}, SignCount: 5},
+			transports: "",
+			expectedCredential: &repository.WebAuthnCredential{
+				ID:           2,
+				UserID:       2,
+				CredentialID: []byte{3},
+				PublicKey:    []byte{4},
+				SignCount:    5,
+				CreatedAt:    now,
+			},
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+		{
+			name:               "Error Executing Query",
+			credential:         &repository.WebAuthnCredential{UserID: 3, CredentialID: []byte{5}, PublicKey: []byte{6}},
+			transports:         "",
+			expectedCredential: nil,
+			expectedError:      errors.New("some error"),
+			transactionCtx:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			if tc.transactionCtx {
+				mock.ExpectBegin()
+			}
+			mock.ExpectPrepare(query)
+			expectedQuery := mock.ExpectQuery(query).WithArgs(tc.credential.UserID, tc.credential.CredentialID, tc.credential.PublicKey, tc.credential.SignCount, tc.transports)
+			if tc.expectedError != nil {
+				expectedQuery.WillReturnError(tc.expectedError)
+			} else {
+				expectedQuery.WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(tc.expectedCredential.ID, tc.expectedCredential.UserID, tc.expectedCredential.CredentialID, tc.expectedCredential.PublicKey, tc.expectedCredential.SignCount, tc.transports, nil, now))
+			}
+			if tc.transactionCtx {
+				mock.ExpectCommit()
+			}
+
+			var credential *repository.WebAuthnCredential
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					credential, err = repo.InsertWebAuthnCredential(ctx, tc.credential)
+					return err
+				})
+			} else {
+				credential, err = repo.InsertWebAuthnCredential(ctx, tc.credential)
+			}
+
+			assert.Equal(t, tc.expectedCredential, credential)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func (c *Client) IsTokenRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)
+			OR EXISTS (SELECT 1 FROM user_token_revocations WHERE user_id = $2 AND revoked_before > $3)
+	`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	var revoked bool
+	err = stmt.QueryRowContext(ctx, tokenID, userID, issuedAt).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTokenRevoked(t *testing.T) {
+	issuedAt := time.Now()
+	query := regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1) OR EXISTS (SELECT 1 FROM user_token_revocations WHERE user_id = $2 AND revoked_before > $3)`)
+
+	testCases := []struct {
+		name            string
+		tokenID         string
+		expectedRevoked bool
+		expectedError   error
+	}{
+		{
+			name:            "Token Revoked",
+			tokenID:         "token-1",
+			expectedRevoked: true,
+			expectedError:   nil,
+		},
+		{
+			name:            "Token Not Revoked",
+			tokenID:         "token-2",
+			expectedRevoked: false,
+			expectedError:   nil,
+		},
+		{
+			name:            "Error Executing Query",
+			tokenID:         "token-3",
+			expectedRevoked: false,
+			expectedError:   errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Token Revoked":
+				mock.ExpectQuery(query).WithArgs(tc.tokenID, int64(1), issuedAt).WillReturnRows(sqlmock.NewRows([]string{"revoked"}).AddRow(true))
+			case "Token Not Revoked":
+				mock.ExpectQuery(query).WithArgs(tc.tokenID, int64(1), issuedAt).WillReturnRows(sqlmock.NewRows([]string{"revoked"}).AddRow(false))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(tc.tokenID, int64(1), issuedAt).WillReturnError(errors.New("some error"))
+			}
+
+			revoked, err := repo.IsTokenRevoked(context.Background(), tc.tokenID, 1, issuedAt)
+
+			assert.Equal(t, tc.expectedRevoked, revoked)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+)
+
+// IsUserSessionActive reports whether the session exists, belongs to the user
+// and has not been revoked.
+func (c *Client) IsUserSessionActive(ctx context.Context, userID, sessionID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM user_sessions WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL)`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	var active bool
+	err = stmt.QueryRowContext(ctx, sessionID, userID).Scan(&active)
+	if err != nil {
+		return false, err
+	}
+
+	return active, nil
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUserSessionActive(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM user_sessions WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL)`)
+
+	testCases := []struct {
+		name           string
+		sessionID      int64
+		expectedActive bool
+		expectedError  error
+	}{
+		{
+			name:           "Session Active",
+			sessionID:      1,
+			expectedActive: true,
+			expectedError:  nil,
+		},
+		{
+			name:           "Session Revoked",
+			sessionID:      2,
+			expectedActive: false,
+			expectedError:  nil,
+		},
+		{
+			name:           "Error Executing Query",
+			sessionID:      3,
+			expectedActive: false,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Session Active":
+				mock.ExpectQuery(query).WithArgs(tc.sessionID, int64(1)).WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(true))
+			case "Session Revoked":
+				mock.ExpectQuery(query).WithArgs(tc.sessionID, int64(1)).WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(tc.sessionID, int64(1)).WillReturnError(errors.New("some error"))
+			}
+
+			active, err := repo.IsUserSessionActive(context.Background(), 1, tc.sessionID)
+
+			assert.Equal(t, tc.expectedActive, active)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
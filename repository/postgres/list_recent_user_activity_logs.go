@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) ListRecentUserActivityLogs(ctx context.Context, userID int64, limit int) ([]*repository.UserActivityLog, error) {
+	query := `SELECT id, user_id, activity_type, ip_address, user_agent, device_family, os_family, request_id, created_at FROM user_activity_logs WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*repository.UserActivityLog{}
+	for rows.Next() {
+		var log repository.UserActivityLog
+		err = rows.Scan(
+			&log.ID,
+			&log.UserID,
+			&log.ActivityType,
+			&log.IPAddress,
+			&log.UserAgent,
+			&log.DeviceFamily,
+			&log.OSFamily,
+			&log.RequestID,
+			&log.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, &log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
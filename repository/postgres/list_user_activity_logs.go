@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) ListUserActivityLogs(ctx context.Context, filter repository.UserActivityLogFilter) ([]*repository.UserActivityLog, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	addCondition(`user_id = $%d`, filter.UserID)
+	if filter.ActivityType != "" {
+		addCondition(`activity_type = $%d`, filter.ActivityType)
+	}
+	if filter.CreatedFrom != nil {
+		addCondition(`created_at >= $%d`, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition(`created_at < $%d`, *filter.CreatedTo)
+	}
+	if filter.AfterID > 0 {
+		addCondition(`id < $%d`, filter.AfterID)
+	}
+
+	args = append(args, filter.Limit)
+	query := `SELECT id, user_id, activity_type, ip_address, user_agent, device_family, os_family, request_id, created_at FROM user_activity_logs WHERE ` +
+		strings.Join(conditions, ` AND `) +
+		fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*repository.UserActivityLog{}
+	for rows.Next() {
+		var log repository.UserActivityLog
+		err = rows.Scan(
+			&log.ID,
+			&log.UserID,
+			&log.ActivityType,
+			&log.IPAddress,
+			&log.UserAgent,
+			&log.DeviceFamily,
+			&log.OSFamily,
+			&log.RequestID,
+			&log.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, &log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListUserActivityLogs(t *testing.T) {
+	now := time.Now()
+	from := now.Add(-24 * time.Hour)
+	ip := "203.0.113.7"
+	columns := []string{"id", "user_id", "activity_type", "ip_address", "user_agent", "device_family", "os_family", "request_id", "created_at"}
+	selectLogs := `SELECT id, user_id, activity_type, ip_address, user_agent, device_family, os_family, request_id, created_at FROM user_activity_logs WHERE user_id = $1`
+
+	testCases := []struct {
+		name          string
+		filter        repository.UserActivityLogFilter
+		query         string
+		args          []driver.Value
+		rows          *sqlmock.Rows
+		queryError    error
+		expectedLogs  []*repository.UserActivityLog
+		expectedError error
+	}{
+		{
+			name:   "No Filter",
+			filter: repository.UserActivityLogFilter{UserID: 1, Limit: 2},
+			query:  selectLogs + ` ORDER BY id DESC LIMIT $2`,
+			args:   []driver.Value{int64(1), 2},
+			rows: sqlmock.NewRows(columns).
+				AddRow(2, 1, "login_failed", ip, nil, nil, nil, nil, now).
+				AddRow(1, 1, "login", nil, nil, nil, nil, nil, now),
+			expectedLogs: []*repository.UserActivityLog{
+				{ID: 2, UserID: 1, ActivityType: "login_failed", IPAddress: &ip, CreatedAt: now},
+				{ID: 1, UserID: 1, ActivityType: "login", CreatedAt: now},
+			},
+		},
+		{
+			name: "Every Filter",
+			filter: repository.UserActivityLogFilter{
+				UserID:       1,
+				ActivityType: "login",
+				CreatedFrom:  &from,
+				CreatedTo:    &now,
+				AfterID:      10,
+				Limit:        20,
+			},
+			query:        selectLogs + ` AND activity_type = $2 AND created_at >= $3 AND created_at < $4 AND id < $5 ORDER BY id DESC LIMIT $6`,
+			args:         []driver.Value{int64(1), "login", from, now, int64(10), 20},
+			rows:         sqlmock.NewRows(columns),
+			expectedLogs: []*repository.UserActivityLog{},
+		},
+		{
+			name:          "Error Executing Query",
+			filter:        repository.UserActivityLogFilter{UserID: 1, Limit: 20},
+			query:         selectLogs + ` ORDER BY id DESC LIMIT $2`,
+			args:          []driver.Value{int64(1), 20},
+			queryError:    errors.New("some error"),
+			expectedError: errors.New("some error"),
+		},
+		{
+			name:   "Error Scanning Row",
+			filter: repository.UserActivityLogFilter{UserID: 1, Limit: 20},
+			query:  selectLogs + ` ORDER BY id DESC LIMIT $2`,
+			args:   []driver.Value{int64(1), 20},
+			rows: sqlmock.NewRows(columns).
+				AddRow(1, 1, "login", nil, nil, nil, nil, nil, now).
+				RowError(0, errors.New("scan error")),
+			expectedError: errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			query := regexp.QuoteMeta(tc.query)
+			mock.ExpectPrepare(query)
+			if tc.queryError != nil {
+				mock.ExpectQuery(query).WithArgs(tc.args...).WillReturnError(tc.queryError)
+			} else {
+				mock.ExpectQuery(query).WithArgs(tc.args...).WillReturnRows(tc.rows)
+			}
+
+			logs, err := repo.ListUserActivityLogs(context.Background(), tc.filter)
+
+			assert.Equal(t, tc.expectedLogs, logs)
+			assert.Equal(t, tc.expectedError, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
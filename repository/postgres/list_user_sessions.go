@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+// ListUserSessions returns the sessions of the user that are not revoked and
+// were used after activeSince, most recently used first.
+func (c *Client) ListUserSessions(ctx context.Context, userID int64, activeSince time.Time) ([]*repository.UserSession, error) {
+	query := `SELECT id, user_id, device_name, ip_address, user_agent, last_seen_at, revoked_at, created_at FROM user_sessions WHERE user_id = $1 AND revoked_at IS NULL AND last_seen_at > $2 ORDER BY last_seen_at DESC, id DESC`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID, activeSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*repository.UserSession{}
+	for rows.Next() {
+		var session repository.UserSession
+		err = rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.DeviceName,
+			&session.IPAddress,
+			&session.UserAgent,
+			&session.LastSeenAt,
+			&session.RevokedAt,
+			&session.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, &session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListUserSessions(t *testing.T) {
+	now := time.Now()
+	activeSince := now.Add(-time.Hour)
+	ip := "203.0.113.7"
+	query := regexp.QuoteMeta(`SELECT id, user_id, device_name, ip_address, user_agent, last_seen_at, revoked_at, created_at FROM user_sessions WHERE user_id = $1 AND revoked_at IS NULL AND last_seen_at > $2 ORDER BY last_seen_at DESC, id DESC`)
+	columns := []string{"id", "user_id", "device_name", "ip_address", "user_agent", "last_seen_at", "revoked_at", "created_at"}
+
+	testCases := []struct {
+		name             string
+		expectedSessions []*repository.UserSession
+		expectedError    error
+	}{
+		{
+			name: "Sessions Exist",
+			expectedSessions: []*repository.UserSession{
+				{ID: 2, UserID: 1, DeviceName: "Android mobile", IPAddress: &ip, LastSeenAt: now, CreatedAt: now},
+				{ID: 1, UserID: 1, DeviceName: "Windows desktop", LastSeenAt: now, CreatedAt: now},
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "No Sessions",
+			expectedSessions: []*repository.UserSession{},
+			expectedError:    nil,
+		},
+		{
+			name:             "Error Executing Query",
+			expectedSessions: nil,
+			expectedError:    errors.New("some error"),
+		},
+		{
+			name:             "Error Scanning Row",
+			expectedSessions: nil,
+			expectedError:    errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Sessions Exist":
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, "Android mobile", ip, nil, now, nil, now).
+					AddRow(1, 1, "Windows desktop", nil, nil, now, nil, now)
+				mock.ExpectQuery(query).WithArgs(int64(1), activeSince).WillReturnRows(rows)
+			case "No Sessions":
+				mock.ExpectQuery(query).WithArgs(int64(1), activeSince).WillReturnRows(sqlmock.NewRows(columns))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1), activeSince).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "Windows desktop", nil, nil, now, nil, now).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1), activeSince).WillReturnRows(rows)
+			}
+
+			sessions, err := repo.ListUserSessions(context.Background(), 1, activeSince)
+
+			assert.Equal(t, tc.expectedSessions, sessions)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+// ListUserWebAuthnCredentials returns the passkeys of the user, oldest
+// first.
+func (c *Client) ListUserWebAuthnCredentials(ctx context.Context, userID int64) ([]*repository.WebAuthnCredential, error) {
+	query := `SELECT id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at FROM webauthn_credentials WHERE user_id = $1 ORDER BY id`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	credentials := []*repository.WebAuthnCredential{}
+	for rows.Next() {
+		var credential repository.WebAuthnCredential
+		var transports string
+		err = rows.Scan(
+			&credential.ID,
+			&credential.UserID,
+			&credential.CredentialID,
+			&credential.PublicKey,
+			&credential.SignCount,
+			&transports,
+			&credential.LastUsedAt,
+			&credential.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		credential.Transports = splitTransports(transports)
+		credentials = append(credentials, &credential)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
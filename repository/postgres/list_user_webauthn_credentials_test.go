@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListUserWebAuthnCredentials(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at FROM webauthn_credentials WHERE user_id = $1 ORDER BY id`)
+	columns := []string{"id", "user_id", "credential_id", "public_key", "sign_count", "transports", "last_used_at", "created_at"}
+
+	testCases := []struct {
+		name                string
+		expectedCredentials []*repository.WebAuthnCredential
+		expectedError       error
+	}{
+		{
+			name: "Credentials Exist",
+			expectedCredentials: []*repository.WebAuthnCredential{
+				{ID: 1, UserID: 1, CredentialID: []byte{1}, PublicKey: []byte{2}, Transports: []string{"internal"}, CreatedAt: now},
+				{ID: 2, UserID: 1, CredentialID: []byte{3}, PublicKey: []byte{4}, SignCount: 3, LastUsedAt: &now, CreatedAt: now},
+			},
+			expectedError: nil,
+		},
+		{
+			name:                "No Credentials",
+			expectedCredentials: []*repository.WebAuthnCredential{},
+			expectedError:       nil,
+		},
+		{
+			name:                "Error Executing Query",
+			expectedCredentials: nil,
+			expectedError:       errors.New("some error"),
+		},
+		{
+			name:                "Error Scanning Row",
+			expectedCredentials: nil,
+			expectedError:       errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Credentials Exist":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, []byte{1}, []byte{2}, 0, "internal", nil, now).
+					AddRow(2, 1, []byte{3}, []byte{4}, 3, "", now, now)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "No Credentials":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows(columns))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, []byte{1}, []byte{2}, 0, "", nil, now).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			}
+
+			credentials, err := repo.ListUserWebAuthnCredentials(context.Background(), 1)
+
+			assert.Equal(t, tc.expectedCredentials, credentials)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+func (c *Client) ListUsers(ctx context.Context, filter repository.UserFilter) ([]*repository.User, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.PhonePrefix != "" {
+		addCondition(`phone LIKE $%d || '%%'`, escapeLike(filter.PhonePrefix))
+	}
+	if filter.NameFragment != "" {
+		addCondition(`full_name ILIKE '%%' || $%d || '%%'`, escapeLike(filter.NameFragment))
+	}
+	if filter.CreatedFrom != nil {
+		addCondition(`created_at >= $%d`, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition(`created_at < $%d`, *filter.CreatedTo)
+	}
+	if filter.AfterID > 0 {
+		addCondition(`id < $%d`, filter.AfterID)
+	}
+
+	query := `SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at FROM users`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*repository.User{}
+	for rows.Next() {
+		var user repository.User
+		err = rows.Scan(
+			&user.ID,
+			&user.FullName,
+			&user.HashedPassword,
+			&user.Phone,
+			&user.PhoneVerifiedAt,
+			&user.PendingPhone,
+			&user.LoginCount,
+			&user.FailedLoginCount,
+			&user.LockedUntil,
+			&user.Status,
+			&user.StatusReason,
+			&user.StatusChangedAt,
+			&user.DeletionScheduledAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// escapeLike makes the wildcards in s match literally in a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// ListUsersDueForErasure returns the ids of accounts whose deletion grace
+// period ended at or before the given time, oldest account first.
+func (c *Client) ListUsersDueForErasure(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	query := `SELECT id FROM users WHERE status = 'pending_deletion' AND deletion_scheduled_at <= $1 ORDER BY id LIMIT $2`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListUsersDueForErasure(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id FROM users WHERE status = 'pending_deletion' AND deletion_scheduled_at <= $1 ORDER BY id LIMIT $2`)
+	columns := []string{"id"}
+
+	testCases := []struct {
+		name            string
+		expectedUserIDs []int64
+		expectedError   error
+	}{
+		{
+			name:            "Users Due",
+			expectedUserIDs: []int64{3, 7},
+			expectedError:   nil,
+		},
+		{
+			name:            "No Users Due",
+			expectedUserIDs: []int64{},
+			expectedError:   nil,
+		},
+		{
+			name:            "Error Executing Query",
+			expectedUserIDs: nil,
+			expectedError:   errors.New("some error"),
+		},
+		{
+			name:            "Error Scanning Row",
+			expectedUserIDs: nil,
+			expectedError:   errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Users Due":
+				rows := sqlmock.NewRows(columns).AddRow(3).AddRow(7)
+				mock.ExpectQuery(query).WithArgs(now, 100).WillReturnRows(rows)
+			case "No Users Due":
+				mock.ExpectQuery(query).WithArgs(now, 100).WillReturnRows(sqlmock.NewRows(columns))
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(now, 100).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(3).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(now, 100).WillReturnRows(rows)
+			}
+
+			userIDs, err := repo.ListUsersDueForErasure(context.Background(), now, 100)
+
+			assert.Equal(t, tc.expectedUserIDs, userIDs)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListUsers(t *testing.T) {
+	now := time.Now()
+	from := now.Add(-24 * time.Hour)
+	columns := []string{"id", "full_name", "hashed_password", "phone", "phone_verified_at", "pending_phone", "login_count", "failed_login_count", "locked_until", "status", "status_reason", "status_changed_at", "deletion_scheduled_at", "created_at", "updated_at"}
+	selectUsers := `SELECT id, full_name, hashed_password, COALESCE(phone, ''), phone_verified_at, pending_phone, login_count, failed_login_count, locked_until, status, status_reason, status_changed_at, deletion_scheduled_at, created_at, updated_at FROM users`
+
+	testCases := []struct {
+		name          string
+		filter        repository.UserFilter
+		query         string
+		args          []driver.Value
+		rows          *sqlmock.Rows
+		queryError    error
+		expectedUsers []*repository.User
+		expectedError error
+	}{
+		{
+			name:   "No Filter",
+			filter: repository.UserFilter{Limit: 2},
+			query:  selectUsers + ` ORDER BY id DESC LIMIT $1`,
+			args:   []driver.Value{2},
+			rows: sqlmock.NewRows(columns).
+				AddRow(2, "maulana aji satrio", "hash", "+628232482440", nil, nil, 3, 0, nil, "active", nil, nil, nil, now, now).
+				AddRow(1, "budi santoso", "hash", "+628111111111", now, nil, 0, 0, nil, "active", nil, nil, nil, now, now),
+			expectedUsers: []*repository.User{
+				{ID: 2, FullName: "maulana aji satrio", HashedPassword: "hash", Phone: "+628232482440", LoginCount: 3, Status: "active", CreatedAt: now, UpdatedAt: now},
+				{ID: 1, FullName: "budi santoso", HashedPassword: "hash", Phone: "+628111111111", PhoneVerifiedAt: &now, Status: "active", CreatedAt: now, UpdatedAt: now},
+			},
+		},
+		{
+			name: "Every Filter",
+			filter: repository.UserFilter{
+				PhonePrefix:  "+62823",
+				NameFragment: "aji",
+				CreatedFrom:  &from,
+				CreatedTo:    &now,
+				AfterID:      10,
+				Limit:        20,
+			},
+			query:         selectUsers + ` WHERE phone LIKE $1 || '%' AND full_name ILIKE '%' || $2 || '%' AND created_at >= $3 AND created_at < $4 AND id < $5 ORDER BY id DESC LIMIT $6`,
+			args:          []driver.Value{"+62823", "aji", from, now, int64(10), 20},
+			rows:          sqlmock.NewRows(columns),
+			expectedUsers: []*repository.User{},
+		},
+		{
+			name:          "Wildcards Are Escaped",
+			filter:        repository.UserFilter{NameFragment: `50%_off\`, Limit: 20},
+			query:         selectUsers + ` WHERE full_name ILIKE '%' || $1 || '%' ORDER BY id DESC LIMIT $2`,
+			args:          []driver.Value{`50\%\_off\\`, 20},
+			rows:          sqlmock.NewRows(columns),
+			expectedUsers: []*repository.User{},
+		},
+		{
+			name:          "Error Executing Query",
+			filter:        repository.UserFilter{Limit: 20},
+			query:         selectUsers + ` ORDER BY id DESC LIMIT $1`,
+			args:          []driver.Value{20},
+			queryError:    errors.New("some error"),
+			expectedError: errors.New("some error"),
+		},
+		{
+			name:   "Error Scanning Row",
+			filter: repository.UserFilter{Limit: 20},
+			query:  selectUsers + ` ORDER BY id DESC LIMIT $1`,
+			args:   []driver.Value{20},
+			rows: sqlmock.NewRows(columns).
+				AddRow(1, "budi santoso", "hash", "+628111111111", nil, nil, 0, 0, nil, "active", nil, nil, nil, now, now).
+				RowError(0, errors.New("scan error")),
+			expectedError: errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			query := regexp.QuoteMeta(tc.query)
+			mock.ExpectPrepare(query)
+			if tc.queryError != nil {
+				mock.ExpectQuery(query).WithArgs(tc.args...).WillReturnError(tc.queryError)
+			} else {
+				mock.ExpectQuery(query).WithArgs(tc.args...).WillReturnRows(tc.rows)
+			}
+
+			users, err := repo.ListUsers(context.Background(), tc.filter)
+
+			assert.Equal(t, tc.expectedUsers, users)
+			assert.Equal(t, tc.expectedError, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
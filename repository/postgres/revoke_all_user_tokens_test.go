@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeAllUserTokens(t *testing.T) {
+	revokedBefore := time.Now()
+	query := regexp.QuoteMeta(`INSERT INTO user_token_revocations (user_id, revoked_before) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET revoked_before = EXCLUDED.revoked_before`)
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Revoke",
+			userID:         1,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			userID:         2,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Revoke with Transaction",
+			userID:         3,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Revoke":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID, revokedBefore).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID, revokedBefore).WillReturnError(errors.New("some error"))
+			case "Successful Revoke with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.userID, revokedBefore).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.RevokeAllUserTokens(ctx, tc.userID, revokedBefore)
+				})
+			} else {
+				err = repo.RevokeAllUserTokens(ctx, tc.userID, revokedBefore)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeRefreshTokenFamily(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = $1 AND revoked_at IS NULL`)
+
+	testCases := []struct {
+		name           string
+		familyID       string
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Revoke",
+			familyID:       "family-1",
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			familyID:       "family-2",
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Revoke with Transaction",
+			familyID:       "family-3",
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Revoke":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.familyID).WillReturnResult(sqlmock.NewResult(0, 2))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.familyID).WillReturnError(errors.New("some error"))
+			case "Successful Revoke with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.familyID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.RevokeRefreshTokenFamily(ctx, tc.familyID)
+				})
+			} else {
+				err = repo.RevokeRefreshTokenFamily(ctx, tc.familyID)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
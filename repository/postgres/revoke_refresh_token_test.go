@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeRefreshToken(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL`)
+
+	testCases := []struct {
+		name            string
+		tokenID         int64
+		expectedRevoked bool
+		expectedError   error
+		transactionCtx  bool
+	}{
+		{
+			name:            "Successful Revoke",
+			tokenID:         1,
+			expectedRevoked: true,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Already Revoked",
+			tokenID:         2,
+			expectedRevoked: false,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Error Executing Query",
+			tokenID:         3,
+			expectedRevoked: false,
+			expectedError:   errors.New("some error"),
+			transactionCtx:  false,
+		},
+		{
+			name:            "Successful Revoke with Transaction",
+			tokenID:         4,
+			expectedRevoked: true,
+			expectedError:   nil,
+			transactionCtx:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Revoke":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Already Revoked":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID).WillReturnError(errors.New("some error"))
+			case "Successful Revoke with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.tokenID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var revoked bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					revoked, err = repo.RevokeRefreshToken(ctx, tc.tokenID)
+					return err
+				})
+			} else {
+				revoked, err = repo.RevokeRefreshToken(ctx, tc.tokenID)
+			}
+
+			assert.Equal(t, tc.expectedRevoked, revoked)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
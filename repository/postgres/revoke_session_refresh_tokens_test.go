@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeSessionRefreshTokens(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE session_id = $1 AND revoked_at IS NULL`)
+
+	testCases := []struct {
+		name           string
+		sessionID      int64
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Revoke",
+			sessionID:      1,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			sessionID:      2,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Revoke with Transaction",
+			sessionID:      3,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Revoke":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID).WillReturnResult(sqlmock.NewResult(0, 3))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID).WillReturnError(errors.New("some error"))
+			case "Successful Revoke with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.RevokeSessionRefreshTokens(ctx, tc.sessionID)
+				})
+			} else {
+				err = repo.RevokeSessionRefreshTokens(ctx, tc.sessionID)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
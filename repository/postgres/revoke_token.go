@@ -0,0 +1,13 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+// RevokeToken revokes a token, whether or not it had already been revoked.
+func (c *Client) RevokeToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) error {
+	_, err := c.ConsumeToken(ctx, tokenID, userID, expiresAt)
+
+	return err
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeUserSession(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE user_sessions SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`)
+
+	testCases := []struct {
+		name            string
+		sessionID       int64
+		expectedRevoked bool
+		expectedError   error
+		transactionCtx  bool
+	}{
+		{
+			name:            "Successful Revoke",
+			sessionID:       1,
+			expectedRevoked: true,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Session Not Found",
+			sessionID:       2,
+			expectedRevoked: false,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Error Executing Query",
+			sessionID:       3,
+			expectedRevoked: false,
+			expectedError:   errors.New("some error"),
+			transactionCtx:  false,
+		},
+		{
+			name:            "Successful Revoke with Transaction",
+			sessionID:       4,
+			expectedRevoked: true,
+			expectedError:   nil,
+			transactionCtx:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Revoke":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Session Not Found":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID, int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID, int64(1)).WillReturnError(errors.New("some error"))
+			case "Successful Revoke with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var revoked bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					revoked, err = repo.RevokeUserSession(ctx, 1, tc.sessionID)
+					return err
+				})
+			} else {
+				revoked, err = repo.RevokeUserSession(ctx, 1, tc.sessionID)
+			}
+
+			assert.Equal(t, tc.expectedRevoked, revoked)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+func (c *Client) ScheduleUserDeletion(ctx context.Context, userID int64, deleteAfter time.Time) error {
+
+	query := `UPDATE users SET status = 'pending_deletion', status_reason = NULL, status_changed_at = CURRENT_TIMESTAMP, deletion_scheduled_at = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, userID, deleteAfter)
+
+	return err
+}
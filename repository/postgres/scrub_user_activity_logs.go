@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+// ScrubUserActivityLogs removes the client IP address, User-Agent and
+// request ID from the activity of the user, the request ID because it links
+// the row to the request logs. The activity types and times, and the coarse
+// device and OS families, are kept for auditing.
+func (c *Client) ScrubUserActivityLogs(ctx context.Context, userID int64) error {
+
+	query := `UPDATE user_activity_logs SET ip_address = NULL, user_agent = NULL, request_id = NULL WHERE user_id = $1`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, userID)
+
+	return err
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpendVerificationCodeAttempt(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE verification_codes SET attempts = attempts + 1 WHERE id = $1 AND attempts < $2 AND consumed_at IS NULL`)
+
+	testCases := []struct {
+		name           string
+		codeID         int64
+		expectedSpent  bool
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Spend",
+			codeID:         1,
+			expectedSpent:  true,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "No Attempts Left",
+			codeID:         2,
+			expectedSpent:  false,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			codeID:         3,
+			expectedSpent:  false,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Spend with Transaction",
+			codeID:         4,
+			expectedSpent:  true,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Spend":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "No Attempts Left":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID, 5).WillReturnError(errors.New("some error"))
+			case "Successful Spend with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.codeID, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var spent bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					spent, err = repo.SpendVerificationCodeAttempt(ctx, tc.codeID, 5)
+					return err
+				})
+			} else {
+				spent, err = repo.SpendVerificationCodeAttempt(ctx, tc.codeID, 5)
+			}
+
+			assert.Equal(t, tc.expectedSpent, spent)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
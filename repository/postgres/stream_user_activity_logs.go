@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+// StreamUserActivityLogs calls fn with every activity of the user, oldest
+// first, one row at a time. It stops at the first error fn returns.
+func (c *Client) StreamUserActivityLogs(ctx context.Context, userID int64, fn func(*repository.UserActivityLog) error) error {
+	query := `SELECT id, user_id, activity_type, ip_address, user_agent, device_family, os_family, request_id, created_at FROM user_activity_logs WHERE user_id = $1 ORDER BY created_at, id`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var log repository.UserActivityLog
+		err = rows.Scan(
+			&log.ID,
+			&log.UserID,
+			&log.ActivityType,
+			&log.IPAddress,
+			&log.UserAgent,
+			&log.DeviceFamily,
+			&log.OSFamily,
+			&log.RequestID,
+			&log.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&log)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamUserActivityLogs(t *testing.T) {
+	now := time.Now()
+	ip := "203.0.113.7"
+	query := regexp.QuoteMeta(`SELECT id, user_id, activity_type, ip_address, user_agent, device_family, os_family, request_id, created_at FROM user_activity_logs WHERE user_id = $1 ORDER BY created_at, id`)
+	columns := []string{"id", "user_id", "activity_type", "ip_address", "user_agent", "device_family", "os_family", "request_id", "created_at"}
+
+	testCases := []struct {
+		name          string
+		fnError       error
+		expectedLogs  []*repository.UserActivityLog
+		expectedError error
+	}{
+		{
+			name: "Logs Streamed",
+			expectedLogs: []*repository.UserActivityLog{
+				{ID: 1, UserID: 1, ActivityType: "login", CreatedAt: now},
+				{ID: 2, UserID: 1, ActivityType: "login_failed", IPAddress: &ip, CreatedAt: now},
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Callback Error",
+			fnError: errors.New("write error"),
+			expectedLogs: []*repository.UserActivityLog{
+				{ID: 1, UserID: 1, ActivityType: "login", CreatedAt: now},
+			},
+			expectedError: errors.New("write error"),
+		},
+		{
+			name:          "Error Executing Query",
+			expectedError: errors.New("some error"),
+		},
+		{
+			name:          "Error Scanning Row",
+			expectedError: errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Logs Streamed", "Callback Error":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "login", nil, nil, nil, nil, nil, now).
+					AddRow(2, 1, "login_failed", ip, nil, nil, nil, nil, now)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "login", nil, nil, nil, nil, nil, now).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			}
+
+			var logs []*repository.UserActivityLog
+			err = repo.StreamUserActivityLogs(context.Background(), 1, func(log *repository.UserActivityLog) error {
+				logs = append(logs, log)
+				return tc.fnError
+			})
+
+			assert.Equal(t, tc.expectedLogs, logs)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
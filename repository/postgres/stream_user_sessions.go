@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+// StreamUserSessions calls fn with every session of the user, revoked ones
+// included, oldest first.
+func (c *Client) StreamUserSessions(ctx context.Context, userID int64, fn func(*repository.UserSession) error) error {
+	query := `SELECT id, user_id, device_name, ip_address, user_agent, last_seen_at, revoked_at, created_at FROM user_sessions WHERE user_id = $1 ORDER BY created_at, id`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var session repository.UserSession
+		err = rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.DeviceName,
+			&session.IPAddress,
+			&session.UserAgent,
+			&session.LastSeenAt,
+			&session.RevokedAt,
+			&session.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&session)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamUserSessions(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, user_id, device_name, ip_address, user_agent, last_seen_at, revoked_at, created_at FROM user_sessions WHERE user_id = $1 ORDER BY created_at, id`)
+	columns := []string{"id", "user_id", "device_name", "ip_address", "user_agent", "last_seen_at", "revoked_at", "created_at"}
+
+	testCases := []struct {
+		name             string
+		fnError          error
+		expectedSessions []*repository.UserSession
+		expectedError    error
+	}{
+		{
+			name: "Sessions Streamed",
+			expectedSessions: []*repository.UserSession{
+				{ID: 1, UserID: 1, DeviceName: "Windows desktop", LastSeenAt: now, RevokedAt: &now, CreatedAt: now},
+				{ID: 2, UserID: 1, DeviceName: "iOS mobile", LastSeenAt: now, CreatedAt: now},
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Callback Error",
+			fnError: errors.New("write error"),
+			expectedSessions: []*repository.UserSession{
+				{ID: 1, UserID: 1, DeviceName: "Windows desktop", LastSeenAt: now, RevokedAt: &now, CreatedAt: now},
+			},
+			expectedError: errors.New("write error"),
+		},
+		{
+			name:          "Error Executing Query",
+			expectedError: errors.New("some error"),
+		},
+		{
+			name:          "Error Scanning Row",
+			expectedError: errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Sessions Streamed", "Callback Error":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "Windows desktop", nil, nil, now, now, now).
+					AddRow(2, 1, "iOS mobile", nil, nil, now, nil, now)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "Windows desktop", nil, nil, now, nil, now).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			}
+
+			var sessions []*repository.UserSession
+			err = repo.StreamUserSessions(context.Background(), 1, func(session *repository.UserSession) error {
+				sessions = append(sessions, session)
+				return tc.fnError
+			})
+
+			assert.Equal(t, tc.expectedSessions, sessions)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+// StreamUserVerificationCodes calls fn with every verification code sent to
+// the user, oldest first.
+func (c *Client) StreamUserVerificationCodes(ctx context.Context, userID int64, fn func(*repository.VerificationCode) error) error {
+	query := `SELECT id, user_id, purpose, target, code_hash, attempts, expires_at, consumed_at, created_at FROM verification_codes WHERE user_id = $1 ORDER BY created_at, id`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code repository.VerificationCode
+		err = rows.Scan(
+			&code.ID,
+			&code.UserID,
+			&code.Purpose,
+			&code.Target,
+			&code.CodeHash,
+			&code.Attempts,
+			&code.ExpiresAt,
+			&code.ConsumedAt,
+			&code.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&code)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamUserVerificationCodes(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(10 * time.Minute)
+	query := regexp.QuoteMeta(`SELECT id, user_id, purpose, target, code_hash, attempts, expires_at, consumed_at, created_at FROM verification_codes WHERE user_id = $1 ORDER BY created_at, id`)
+	columns := []string{"id", "user_id", "purpose", "target", "code_hash", "attempts", "expires_at", "consumed_at", "created_at"}
+
+	testCases := []struct {
+		name          string
+		fnError       error
+		expectedCodes []*repository.VerificationCode
+		expectedError error
+	}{
+		{
+			name: "Codes Streamed",
+			expectedCodes: []*repository.VerificationCode{
+				{ID: 1, UserID: 1, Purpose: "phone_verification", Target: "+628232482440", CodeHash: "hash-1", ExpiresAt: expiresAt, ConsumedAt: &now, CreatedAt: now},
+				{ID: 2, UserID: 1, Purpose: "password_reset", Target: "+628232482440", CodeHash: "hash-2", Attempts: 2, ExpiresAt: expiresAt, CreatedAt: now},
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Callback Error",
+			fnError: errors.New("write error"),
+			expectedCodes: []*repository.VerificationCode{
+				{ID: 1, UserID: 1, Purpose: "phone_verification", Target: "+628232482440", CodeHash: "hash-1", ExpiresAt: expiresAt, ConsumedAt: &now, CreatedAt: now},
+			},
+			expectedError: errors.New("write error"),
+		},
+		{
+			name:          "Error Executing Query",
+			expectedError: errors.New("some error"),
+		},
+		{
+			name:          "Error Scanning Row",
+			expectedError: errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Codes Streamed", "Callback Error":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "phone_verification", "+628232482440", "hash-1", 0, expiresAt, now, now).
+					AddRow(2, 1, "password_reset", "+628232482440", "hash-2", 2, expiresAt, nil, now)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "phone_verification", "+628232482440", "hash-1", 0, expiresAt, now, now).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			}
+
+			var codes []*repository.VerificationCode
+			err = repo.StreamUserVerificationCodes(context.Background(), 1, func(code *repository.VerificationCode) error {
+				codes = append(codes, code)
+				return tc.fnError
+			})
+
+			assert.Equal(t, tc.expectedCodes, codes)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/repository"
+)
+
+// StreamUserWebAuthnCredentials calls fn with every passkey of the user,
+// oldest first.
+func (c *Client) StreamUserWebAuthnCredentials(ctx context.Context, userID int64, fn func(*repository.WebAuthnCredential) error) error {
+	query := `SELECT id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at FROM webauthn_credentials WHERE user_id = $1 ORDER BY id`
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var credential repository.WebAuthnCredential
+		var transports string
+		err = rows.Scan(
+			&credential.ID,
+			&credential.UserID,
+			&credential.CredentialID,
+			&credential.PublicKey,
+			&credential.SignCount,
+			&transports,
+			&credential.LastUsedAt,
+			&credential.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		credential.Transports = splitTransports(transports)
+		err = fn(&credential)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamUserWebAuthnCredentials(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, user_id, credential_id, public_key, sign_count, transports, last_used_at, created_at FROM webauthn_credentials WHERE user_id = $1 ORDER BY id`)
+	columns := []string{"id", "user_id", "credential_id", "public_key", "sign_count", "transports", "last_used_at", "created_at"}
+
+	testCases := []struct {
+		name                string
+		fnError             error
+		expectedCredentials []*repository.WebAuthnCredential
+		expectedError       error
+	}{
+		{
+			name: "Credentials Streamed",
+			expectedCredentials: []*repository.WebAuthnCredential{
+				{ID: 1, UserID: 1, CredentialID: []byte{1}, PublicKey: []byte{2}, SignCount: 3, Transports: []string{"internal", "hybrid"}, LastUsedAt: &now, CreatedAt: now},
+				{ID: 2, UserID: 1, CredentialID: []byte{4}, PublicKey: []byte{5}, CreatedAt: now},
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Callback Error",
+			fnError: errors.New("write error"),
+			expectedCredentials: []*repository.WebAuthnCredential{
+				{ID: 1, UserID: 1, CredentialID: []byte{1}, PublicKey: []byte{2}, SignCount: 3, Transports: []string{"internal", "hybrid"}, LastUsedAt: &now, CreatedAt: now},
+			},
+			expectedError: errors.New("write error"),
+		},
+		{
+			name:          "Error Executing Query",
+			expectedError: errors.New("some error"),
+		},
+		{
+			name:          "Error Scanning Row",
+			expectedError: errors.New("scan error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			mock.ExpectPrepare(query)
+			switch tc.name {
+			case "Credentials Streamed", "Callback Error":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, []byte{1}, []byte{2}, 3, "internal,hybrid", now, now).
+					AddRow(2, 1, []byte{4}, []byte{5}, 0, "", nil, now)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			case "Error Executing Query":
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Error Scanning Row":
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, []byte{1}, []byte{2}, 3, "", nil, now).
+					RowError(0, errors.New("scan error"))
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			}
+
+			var credentials []*repository.WebAuthnCredential
+			err = repo.StreamUserWebAuthnCredentials(context.Background(), 1, func(credential *repository.WebAuthnCredential) error {
+				credentials = append(credentials, credential)
+				return tc.fnError
+			})
+
+			assert.Equal(t, tc.expectedCredentials, credentials)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
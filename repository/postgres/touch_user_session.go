@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+// TouchUserSession moves the last seen time of the session to now, unless it
+// was already seen at or after seenBefore. Skipping recent sessions keeps
+// busy clients from writing the row on every request.
+func (c *Client) TouchUserSession(ctx context.Context, sessionID int64, seenBefore time.Time) error {
+	query := `UPDATE user_sessions SET last_seen_at = CURRENT_TIMESTAMP WHERE id = $1 AND last_seen_at < $2`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, sessionID, seenBefore)
+
+	return err
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTouchUserSession(t *testing.T) {
+	seenBefore := time.Now().Add(-time.Minute)
+	query := regexp.QuoteMeta(`UPDATE user_sessions SET last_seen_at = CURRENT_TIMESTAMP WHERE id = $1 AND last_seen_at < $2`)
+
+	testCases := []struct {
+		name           string
+		sessionID      int64
+		expectedError  error
+		transactionCtx bool
+	}{
+		{
+			name:           "Successful Touch",
+			sessionID:      1,
+			expectedError:  nil,
+			transactionCtx: false,
+		},
+		{
+			name:           "Error Executing Query",
+			sessionID:      2,
+			expectedError:  errors.New("some error"),
+			transactionCtx: false,
+		},
+		{
+			name:           "Successful Touch with Transaction",
+			sessionID:      3,
+			expectedError:  nil,
+			transactionCtx: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Touch":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID, seenBefore).WillReturnResult(sqlmock.NewResult(0, 3))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID, seenBefore).WillReturnError(errors.New("some error"))
+			case "Successful Touch with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(tc.sessionID, seenBefore).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					return repo.TouchUserSession(ctx, tc.sessionID, seenBefore)
+				})
+			} else {
+				err = repo.TouchUserSession(ctx, tc.sessionID, seenBefore)
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sawitpro/UserService/common"
+)
+
+// UpdateWebAuthnCredentialSignCount records the signature counter of an
+// accepted assertion and when the passkey was used. It reports false when a
+// concurrent login already stored that counter or a later one, so the same
+// assertion cannot be replayed. Authenticators without a counter always
+// report zero, which is accepted.
+func (c *Client) UpdateWebAuthnCredentialSignCount(ctx context.Context, id, signCount int64) (bool, error) {
+	query := `UPDATE webauthn_credentials SET sign_count = $1, last_used_at = NOW() WHERE id = $2 AND (sign_count < $1 OR $1 = 0)`
+
+	tx, ok := ctx.Value(common.TX_KEY).(*sql.Tx)
+
+	var stmt *sql.Stmt
+	var err error
+	if ok && tx != nil {
+		stmt, err = tx.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.DB.PrepareContext(ctx, query)
+	}
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, signCount, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
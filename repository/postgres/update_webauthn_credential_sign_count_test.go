@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateWebAuthnCredentialSignCount(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE webauthn_credentials SET sign_count = $1, last_used_at = NOW() WHERE id = $2 AND (sign_count < $1 OR $1 = 0)`)
+
+	testCases := []struct {
+		name            string
+		expectedUpdated bool
+		expectedError   error
+		transactionCtx  bool
+	}{
+		{
+			name:            "Successful Update",
+			expectedUpdated: true,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Counter Already Stored",
+			expectedUpdated: false,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Error Executing Query",
+			expectedUpdated: false,
+			expectedError:   errors.New("some error"),
+			transactionCtx:  false,
+		},
+		{
+			name:            "Successful Update with Transaction",
+			expectedUpdated: true,
+			expectedError:   nil,
+			transactionCtx:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Update":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(100), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Counter Already Stored":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(100), int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(100), int64(1)).WillReturnError(errors.New("some error"))
+			case "Successful Update with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(100), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var updated bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					updated, err = repo.UpdateWebAuthnCredentialSignCount(ctx, 1, 100)
+					return err
+				})
+			} else {
+				updated, err = repo.UpdateWebAuthnCredentialSignCount(ctx, 1, 100)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
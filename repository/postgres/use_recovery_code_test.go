@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseRecoveryCode(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE recovery_codes SET used_at = CURRENT_TIMESTAMP WHERE id = $1 AND used_at IS NULL`)
+
+	testCases := []struct {
+		name            string
+		expectedUpdated bool
+		expectedError   error
+		transactionCtx  bool
+	}{
+		{
+			name:            "Successful Update",
+			expectedUpdated: true,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Already Used",
+			expectedUpdated: false,
+			expectedError:   nil,
+			transactionCtx:  false,
+		},
+		{
+			name:            "Error Executing Query",
+			expectedUpdated: false,
+			expectedError:   errors.New("some error"),
+			transactionCtx:  false,
+		},
+		{
+			name:            "Successful Update with Transaction",
+			expectedUpdated: true,
+			expectedError:   nil,
+			transactionCtx:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			defer mockDB.Close()
+
+			repo := &Client{
+				DB: mockDB,
+			}
+
+			ctx := context.Background()
+
+			switch tc.name {
+			case "Successful Update":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			case "Already Used":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			case "Error Executing Query":
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(1)).WillReturnError(errors.New("some error"))
+			case "Successful Update with Transaction":
+				mock.ExpectBegin()
+				mock.ExpectPrepare(query)
+				mock.ExpectExec(query).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			var updated bool
+
+			if tc.transactionCtx {
+				err = repo.ExecTransaction(ctx, func(ctx context.Context) error {
+					updated, err = repo.UseRecoveryCode(ctx, 1)
+					return err
+				})
+			} else {
+				updated, err = repo.UseRecoveryCode(ctx, 1)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
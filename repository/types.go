@@ -4,18 +4,131 @@ package repository
 import "time"
 
 type User struct {
-	ID             int64
-	FullName       string
-	HashedPassword string
-	Phone          string
-	LoginCount     int64
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID                  int64
+	FullName            string
+	HashedPassword      string
+	Phone               string
+	PhoneVerifiedAt     *time.Time
+	PendingPhone        *string
+	LoginCount          int64
+	FailedLoginCount    int64
+	LockedUntil         *time.Time
+	Status              string
+	StatusReason        *string
+	StatusChangedAt     *time.Time
+	DeletionScheduledAt *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// UserFilter narrows ListUsers. Empty fields match every user, and AfterID
+// continues a previous page ordered by descending ID.
+type UserFilter struct {
+	PhonePrefix  string
+	NameFragment string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	AfterID      int64
+	Limit        int
+}
+
+// UserActivityLogFilter narrows ListUserActivityLogs to one user. Empty
+// fields match every activity, and AfterID continues a previous page ordered
+// by descending ID.
+type UserActivityLogFilter struct {
+	UserID       int64
+	ActivityType string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	AfterID      int64
+	Limit        int
 }
 
 type UserActivityLog struct {
 	ID           int64
 	UserID       int64
 	ActivityType string
+	IPAddress    *string
+	UserAgent    *string
+	DeviceFamily *string
+	OSFamily     *string
+	RequestID    *string
+	CreatedAt    time.Time
+}
+
+type Role struct {
+	ID        int64
+	Name      string
+	CreatedAt time.Time
+}
+
+// UserAccess holds the names of the roles assigned to a user and of every
+// permission those roles grant.
+type UserAccess struct {
+	Roles       []string
+	Permissions []string
+}
+
+type UserSession struct {
+	ID         int64
+	UserID     int64
+	DeviceName string
+	IPAddress  *string
+	UserAgent  *string
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	SessionID int64
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+type VerificationCode struct {
+	ID         int64
+	UserID     int64
+	Purpose    string
+	Target     string
+	CodeHash   string
+	Attempts   int64
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
+
+type UserTOTP struct {
+	UserID       int64
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+type RecoveryCode struct {
+	ID        int64
+	UserID    int64
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// WebAuthnCredential is a passkey registered by a user. PublicKey is COSE_Key
+// encoded, and Transports are the hints the authenticator reported for
+// reaching it, such as "internal" or "usb".
+type WebAuthnCredential struct {
+	ID           int64
+	UserID       int64
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    int64
+	Transports   []string
+	LastUsedAt   *time.Time
 	CreatedAt    time.Time
 }
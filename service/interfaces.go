@@ -15,7 +15,79 @@ type ServiceInterface interface {
 
 	Login(ctx context.Context, params LoginParam) (*LoginResponse, common.Error)
 
+	VerifyMFALogin(ctx context.Context, params VerifyMFALoginParam) (*LoginResponse, common.Error)
+
+	RequestLoginCode(ctx context.Context, params RequestLoginCodeParam) common.Error
+
+	VerifyLoginCode(ctx context.Context, params VerifyLoginCodeParam) (*LoginResponse, common.Error)
+
+	BeginPasskeyLogin(ctx context.Context) (*BeginPasskeyLoginResponse, common.Error)
+
+	FinishPasskeyLogin(ctx context.Context, params FinishPasskeyLoginParam) (*LoginResponse, common.Error)
+
+	RefreshToken(ctx context.Context, params RefreshTokenParam) (*LoginResponse, common.Error)
+
+	Logout(ctx context.Context, params LogoutParam) common.Error
+
+	LogoutAll(ctx context.Context, userID int64) common.Error
+
 	GetProfile(ctx context.Context, userID int64) (*UserInfoResponse, common.Error)
 
 	UpdateProfile(ctx context.Context, params UpdateProfileParam) (*UpdateProfileResponse, common.Error)
+
+	ChangePassword(ctx context.Context, params ChangePasswordParam) (*LoginResponse, common.Error)
+
+	ForgotPassword(ctx context.Context, params ForgotPasswordParam) common.Error
+
+	ResetPassword(ctx context.Context, params ResetPasswordParam) common.Error
+
+	VerifyPhone(ctx context.Context, params VerifyPhoneParam) common.Error
+
+	ResendPhoneVerification(ctx context.Context, userID int64) common.Error
+
+	SetupTOTP(ctx context.Context, userID int64) (*SetupTOTPResponse, common.Error)
+
+	ConfirmTOTP(ctx context.Context, params ConfirmTOTPParam) (*ConfirmTOTPResponse, common.Error)
+
+	BeginPasskeyRegistration(ctx context.Context, userID int64) (*BeginPasskeyRegistrationResponse, common.Error)
+
+	FinishPasskeyRegistration(ctx context.Context, params FinishPasskeyRegistrationParam) common.Error
+
+	UnlockAccount(ctx context.Context, userID int64) common.Error
+
+	ListUsers(ctx context.Context, params ListUsersParam) (*ListUsersResponse, common.Error)
+
+	GetUserDetail(ctx context.Context, userID int64) (*UserDetailResponse, common.Error)
+
+	AssignUserRole(ctx context.Context, params UserRoleParam) common.Error
+
+	RemoveUserRole(ctx context.Context, params UserRoleParam) common.Error
+
+	SuspendUser(ctx context.Context, params SuspendUserParam) common.Error
+
+	UnsuspendUser(ctx context.Context, userID int64) common.Error
+
+	DeactivateAccount(ctx context.Context, params DeactivateAccountParam) common.Error
+
+	DeleteProfile(ctx context.Context, params DeleteProfileParam) (*DeleteProfileResponse, common.Error)
+
+	EraseDueAccounts(ctx context.Context) (int, common.Error)
+
+	ExportUserData(ctx context.Context, params ExportUserDataParam) common.Error
+
+	ListActivities(ctx context.Context, params ListActivitiesParam) (*ListActivitiesResponse, common.Error)
+
+	ListSessions(ctx context.Context, params ListSessionsParam) (*ListSessionsResponse, common.Error)
+
+	RevokeSession(ctx context.Context, params RevokeSessionParam) common.Error
+}
+
+// UserDataWriter receives a personal data export piece by piece, in the
+// order of its methods, so the export is never held in memory as a whole.
+type UserDataWriter interface {
+	WriteProfile(profile UserDataProfile) error
+	WriteActivity(activity UserActivity) error
+	WriteSession(session UserSession) error
+	WriteVerificationCode(code UserVerificationCode) error
+	WritePasskey(passkey UserPasskey) error
 }
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// checkAccountActive refuses a user whose account is suspended, deactivated
+// or waiting to be deleted.
+func checkAccountActive(user *repository.User) common.Error {
+	if user.Status != common.ACTIVE_STATUS {
+		return errors.NewAccountStatusError(user.Status)
+	}
+
+	return nil
+}
+
+// SuspendUser blocks an account until support staff unsuspend it and signs
+// out every session. Suspending an already suspended account only replaces
+// the reason.
+func (s *Service) SuspendUser(ctx context.Context, params service.SuspendUserParam) common.Error {
+	user, errSvc := s.getUserForStatusChange(ctx, params.UserID)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	if user.Status != common.ACTIVE_STATUS && user.Status != common.SUSPENDED_STATUS {
+		return errors.NewErrorWithCode(
+			errors.AccountStatusConflictErrorMessage,
+			errors.ConflictErrorType,
+			errors.AccountStatusConflictErrorCode)
+	}
+
+	return s.changeUserStatus(ctx, user.ID, common.SUSPENDED_STATUS, params.Reason, common.ACCOUNT_SUSPENDED_ACTIVITY)
+}
+
+// UnsuspendUser makes a suspended account active again. The user has to log
+// in again, as their sessions were signed out on suspension.
+func (s *Service) UnsuspendUser(ctx context.Context, userID int64) common.Error {
+	user, errSvc := s.getUserForStatusChange(ctx, userID)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	if user.Status != common.SUSPENDED_STATUS {
+		return errors.NewErrorWithCode(
+			errors.AccountStatusConflictErrorMessage,
+			errors.ConflictErrorType,
+			errors.AccountStatusConflictErrorCode)
+	}
+
+	return s.changeUserStatus(ctx, user.ID, common.ACTIVE_STATUS, "", common.ACCOUNT_UNSUSPENDED_ACTIVITY)
+}
+
+// DeactivateAccount lets users close their own account after confirming
+// their password. Every session, including the caller's, is signed out.
+func (s *Service) DeactivateAccount(ctx context.Context, params service.DeactivateAccountParam) common.Error {
+	user, errSvc := s.getUserForStatusChange(ctx, params.UserID)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	err := s.Hasher.CompareHashAndPassword([]byte(user.HashedPassword), []byte(params.Password))
+	if err != nil {
+		return errors.NewErrorWithCode(
+			errors.WrongCurrentPasswordErrorMessage,
+			errors.BadRequestErrorType,
+			errors.WrongCurrentPasswordErrorCode)
+	}
+
+	errSvc = checkAccountActive(user)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	return s.changeUserStatus(ctx, user.ID, common.DEACTIVATED_STATUS, params.Reason, common.ACCOUNT_DEACTIVATED_ACTIVITY)
+}
+
+func (s *Service) getUserForStatusChange(ctx context.Context, userID int64) (*repository.User, common.Error) {
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.NotFoundErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	return user, nil
+}
+
+// changeUserStatus records the new status with its activity, and signs the
+// user out everywhere unless the account becomes active.
+func (s *Service) changeUserStatus(ctx context.Context, userID int64, status, reason, activityType string) common.Error {
+	err := s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.UpdateUserStatus(ctx, userID, status, reason)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, userID, activityType)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if status == common.ACTIVE_STATUS {
+		return nil
+	}
+
+	err = s.revokeAllSessions(ctx, userID)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
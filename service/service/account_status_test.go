@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestSuspendUser(t *testing.T) {
+	params := service.SuspendUserParam{UserID: 1, Reason: "chargeback fraud"}
+
+	testCases := []struct {
+		name          string
+		user          *repository.User
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Suspend",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By ID",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Deactivated Account",
+			user:          &repository.User{ID: 1, Status: common.DEACTIVATED_STATUS},
+			expectedError: commonErr.NewError(commonErr.AccountStatusConflictErrorMessage, commonErr.ConflictErrorType),
+		},
+		{
+			name:          "Update Status Error",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("update error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Sessions Error",
+			user:          &repository.User{ID: 1, Status: common.SUSPENDED_STATUS},
+			expectedError: commonErr.NewError("revoke error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Suspend":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), params.UserID, common.SUSPENDED_STATUS, params.Reason).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.ACCOUNT_SUSPENDED_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), params.UserID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), params.UserID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), params.UserID).Return(nil)
+			case "Error DB - Get User By ID":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(nil, errors.New("some error"))
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(nil, nil)
+			case "Deactivated Account":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+			case "Update Status Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), params.UserID, common.SUSPENDED_STATUS, params.Reason).Return(errors.New("update error"))
+			case "Revoke Sessions Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), params.UserID, common.SUSPENDED_STATUS, params.Reason).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.ACCOUNT_SUSPENDED_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), params.UserID, gomock.Any()).Return(errors.New("revoke error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				RevocationStore: mockStore,
+			})
+
+			err := svc.SuspendUser(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestUnsuspendUser(t *testing.T) {
+	testCases := []struct {
+		name          string
+		user          *repository.User
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Unsuspend",
+			user:          &repository.User{ID: 1, Status: common.SUSPENDED_STATUS},
+			expectedError: nil,
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Account Not Suspended",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.AccountStatusConflictErrorMessage, commonErr.ConflictErrorType),
+		},
+		{
+			name:          "Insert Activity Error",
+			user:          &repository.User{ID: 1, Status: common.SUSPENDED_STATUS},
+			expectedError: commonErr.NewError("insert error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Unsuspend":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(tc.user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), int64(1), common.ACTIVE_STATUS, "").Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.ACCOUNT_UNSUSPENDED_ACTIVITY).Return(nil)
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(nil, nil)
+			case "Account Not Suspended":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(tc.user, nil)
+			case "Insert Activity Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(tc.user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), int64(1), common.ACTIVE_STATUS, "").Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.ACCOUNT_UNSUSPENDED_ACTIVITY).Return(errors.New("insert error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			err := svc.UnsuspendUser(context.Background(), 1)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestDeactivateAccount(t *testing.T) {
+	params := service.DeactivateAccountParam{UserID: 1, Password: "Current1!", Reason: "no longer needed"}
+
+	testCases := []struct {
+		name          string
+		user          *repository.User
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Deactivate",
+			user:          &repository.User{ID: 1, HashedPassword: "hash", Status: common.ACTIVE_STATUS},
+			expectedError: nil,
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Wrong Password",
+			user:          &repository.User{ID: 1, HashedPassword: "hash", Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.WrongCurrentPasswordErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Suspended Account",
+			user:          &repository.User{ID: 1, HashedPassword: "hash", Status: common.SUSPENDED_STATUS},
+			expectedError: commonErr.NewError(commonErr.AccountSuspendedErrorMessage, commonErr.ForbiddenErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Deactivate":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte("hash"), []byte(params.Password)).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), params.UserID, common.DEACTIVATED_STATUS, params.Reason).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.ACCOUNT_DEACTIVATED_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), params.UserID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), params.UserID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), params.UserID).Return(nil)
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(nil, nil)
+			case "Wrong Password":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("mismatch"))
+			case "Suspended Account":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				Hasher:          mockHasher,
+				RevocationStore: mockStore,
+			})
+
+			err := svc.DeactivateAccount(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
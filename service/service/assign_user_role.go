@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// AssignUserRole grants a role to a user. Assigning a role the user already
+// has is not an error. The role's permissions are added to the user's access
+// tokens from their next login or token refresh.
+func (s *Service) AssignUserRole(ctx context.Context, params service.UserRoleParam) common.Error {
+	role, errSvc := s.getUserRole(ctx, params)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	err := s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.AssignUserRole(ctx, params.UserID, role.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, params.UserID, common.ROLE_ASSIGNED_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
+
+// getUserRole checks that the user exists and returns the role named in
+// params.
+func (s *Service) getUserRole(ctx context.Context, params service.UserRoleParam) (*repository.Role, common.Error) {
+	user, err := s.Repository.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.NotFoundErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	role, err := s.Repository.GetRoleByName(ctx, params.Role)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if role == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.RoleNotFoundErrorMessage,
+			errors.NotFoundErrorType,
+			errors.RoleNotFoundErrorCode)
+	}
+
+	return role, nil
+}
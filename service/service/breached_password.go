@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+)
+
+// checkBreachedPassword refuses passwords seen at least MinBreachCount
+// times in breach corpora. It is skipped when no checker is configured.
+func (s *Service) checkBreachedPassword(ctx context.Context, password string) common.Error {
+	if s.BreachChecker == nil {
+		return nil
+	}
+
+	count, err := s.BreachChecker.BreachCount(ctx, password)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	minCount := s.MinBreachCount
+	if minCount < 1 {
+		minCount = 1
+	}
+
+	if count >= minCount {
+		return errors.NewErrorWithCode(
+			errors.BreachedPasswordErrorMessage,
+			errors.BadRequestErrorType,
+			errors.BreachedPasswordErrorCode)
+	}
+
+	return nil
+}
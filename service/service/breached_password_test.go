@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper/breach/rangefile"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestCheckBreachedPassword(t *testing.T) {
+	testCases := []struct {
+		name           string
+		minBreachCount int64
+		expectedError  common.Error
+	}{
+		{
+			name:          "Not Breached",
+			expectedError: nil,
+		},
+		{
+			name:          "Breached",
+			expectedError: commonErr.NewError(commonErr.BreachedPasswordErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:           "Below Minimum Breach Count",
+			minBreachCount: 10,
+			expectedError:  nil,
+		},
+		{
+			name:          "Checker Error",
+			expectedError: commonErr.NewError("read error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "No Checker",
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockChecker := mocks.NewMockChecker(ctrl)
+
+			svc := &Service{
+				BreachChecker:  mockChecker,
+				MinBreachCount: tc.minBreachCount,
+			}
+
+			switch tc.name {
+			case "Not Breached":
+				mockChecker.EXPECT().BreachCount(gomock.Any(), "Kebun#Sawit42").Return(int64(0), nil)
+			case "Breached":
+				mockChecker.EXPECT().BreachCount(gomock.Any(), "Kebun#Sawit42").Return(int64(1), nil)
+			case "Below Minimum Breach Count":
+				mockChecker.EXPECT().BreachCount(gomock.Any(), "Kebun#Sawit42").Return(int64(3), nil)
+			case "Checker Error":
+				mockChecker.EXPECT().BreachCount(gomock.Any(), "Kebun#Sawit42").Return(int64(0), errors.New("read error"))
+			case "No Checker":
+				svc.BreachChecker = nil
+			}
+
+			err := svc.checkBreachedPassword(context.Background(), "Kebun#Sawit42")
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRegisterWithSampleBreachData(t *testing.T) {
+	svc := NewService(ServiceOpts{
+		BreachChecker: rangefile.NewSampleChecker(),
+	})
+
+	_, err := svc.Register(context.Background(), service.RegisterParam{
+		FullName:    "maulana aji satrio",
+		PhoneNumber: "+628232482440",
+		Password:    "Password1!",
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, commonErr.BreachedPasswordErrorMessage, err.GetErrorMessage())
+	assert.Equal(t, commonErr.BadRequestErrorType, err.GetErrorType())
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Service) ChangePassword(ctx context.Context, params service.ChangePasswordParam) (*service.LoginResponse, common.Error) {
+
+	user, err := s.Repository.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.BadRequestErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	err = s.Hasher.CompareHashAndPassword([]byte(user.HashedPassword), []byte(params.CurrentPassword))
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.WrongCurrentPasswordErrorMessage,
+			errors.BadRequestErrorType,
+			errors.WrongCurrentPasswordErrorCode)
+	}
+
+	if params.NewPassword == params.CurrentPassword {
+		return nil, errors.NewErrorWithCode(
+			errors.SamePasswordErrorMessage,
+			errors.BadRequestErrorType,
+			errors.SamePasswordErrorCode)
+	}
+
+	errSvc := s.checkPasswordPolicy(params.NewPassword, user.Phone, user.FullName)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	errSvc = s.checkBreachedPassword(ctx, params.NewPassword)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	hashedPassword, err := s.Hasher.HashPassword(params.NewPassword)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.UpdateUserPassword(ctx, user.ID, hashedPassword)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, user.ID, common.PASSWORD_CHANGE_ACTIVITY)
+	})
+
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	// Every existing session is signed out, including the caller's, which
+	// gets a fresh token pair so it can carry on without logging in again.
+	err = s.revokeAllSessions(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	// The new session is held to the session limit like a login, in case
+	// the user logged in elsewhere meanwhile.
+	evicted, errSvc := s.checkSessionLimit(ctx, user.ID)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	var resp *service.LoginResponse
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.evictSessions(ctx, user.ID, evicted)
+		if err != nil {
+			return err
+		}
+
+		resp, err = s.issueTokens(ctx, user.ID, 0, "")
+		return err
+	})
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestChangePassword(t *testing.T) {
+	params := service.ChangePasswordParam{
+		UserID:          1,
+		CurrentPassword: "Maulana1996@",
+		NewPassword:     "Maulana1997@",
+	}
+	user := &repository.User{ID: 1, HashedPassword: "old hash"}
+
+	testCases := []struct {
+		name          string
+		params        service.ChangePasswordParam
+		sessionLimit  SessionLimitPolicy
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Change Password",
+			params:        params,
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By ID",
+			params:        params,
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "User Not Found",
+			params:        params,
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Wrong Current Password",
+			params:        params,
+			expectedError: commonErr.NewError(commonErr.WrongCurrentPasswordErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name: "Same Password",
+			params: service.ChangePasswordParam{
+				UserID:          1,
+				CurrentPassword: "Maulana1996@",
+				NewPassword:     "Maulana1996@",
+			},
+			expectedError: commonErr.NewError(commonErr.SamePasswordErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name: "Password Policy Violation",
+			params: service.ChangePasswordParam{
+				UserID:          1,
+				CurrentPassword: "Maulana1996@",
+				NewPassword:     "maulana1997",
+			},
+			expectedError: commonErr.NewError("password must contain an uppercase letter, contain a symbol.", commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Hash Password Error",
+			params:        params,
+			expectedError: commonErr.NewError("hash error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Update Password Error",
+			params:        params,
+			expectedError: commonErr.NewError("update error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Sessions Error",
+			params:        params,
+			expectedError: commonErr.NewError("revoke error", commonErr.SystemErrorType),
+		},
+		{
+			name:         "Session Limit Evicts Concurrent Login",
+			params:       params,
+			sessionLimit: SessionLimitPolicy{MaxSessions: 1},
+		},
+		{
+			name:          "Session Limit Rejects New Session",
+			params:        params,
+			sessionLimit:  SessionLimitPolicy{MaxSessions: 1, Action: RejectNewSession},
+			expectedError: commonErr.NewError(commonErr.SessionLimitReachedErrorMessage, commonErr.ForbiddenErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Change Password":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(user.HashedPassword), []byte(tc.params.CurrentPassword)).Return(nil)
+				mockHasher.EXPECT().HashPassword(tc.params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction).Times(2)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.PASSWORD_CHANGE_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 3}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(&repository.RefreshToken{ID: 1}, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), gomock.Any()).Return(&repository.UserAccess{}, nil)
+			case "Session Limit Evicts Concurrent Login", "Session Limit Rejects New Session":
+				concurrent := []*repository.UserSession{{ID: 4, UserID: user.ID}}
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(tc.params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.PASSWORD_CHANGE_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), user.ID).Return(&repository.UserAccess{}, nil)
+				mockRepo.EXPECT().ListUserSessions(gomock.Any(), user.ID, gomock.Any()).Return(concurrent, nil)
+				if tc.name == "Session Limit Rejects New Session" {
+					mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+					mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.SESSION_LIMIT_REJECTED_ACTIVITY).Return(nil)
+					break
+				}
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction).Times(2)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), user.ID, int64(4)).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), int64(4)).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.SESSION_EVICTED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 5}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(&repository.RefreshToken{ID: 1}, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), gomock.Any()).Return(&repository.UserAccess{}, nil)
+			case "Error DB - Get User By ID":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(nil, errors.New("some error"))
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(nil, nil)
+			case "Wrong Current Password":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("mismatch"))
+			case "Same Password":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+			case "Hash Password Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(tc.params.NewPassword).Return("", errors.New("hash error"))
+			case "Update Password Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(tc.params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(errors.New("update error"))
+			case "Password Policy Violation":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+			case "Revoke Sessions Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(tc.params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.PASSWORD_CHANGE_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), user.ID, gomock.Any()).Return(errors.New("revoke error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				Hasher:          mockHasher,
+				RevocationStore: mockStore,
+				PasswordPolicy:  PasswordPolicy{RequireUpper: true, RequireSymbol: true},
+				SessionLimit:    tc.sessionLimit,
+			})
+
+			response, err := svc.ChangePassword(context.Background(), tc.params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+				assert.Equal(t, user.ID, response.UserID)
+				assert.NotEmpty(t, response.Token)
+				assert.NotEmpty(t, response.RefreshToken)
+			}
+		})
+	}
+}
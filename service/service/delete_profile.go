@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+// DeleteProfile schedules the erasure of the caller's account once the
+// deletion grace period ends, after confirming their password. Every session
+// is signed out, and logging in again before the erasure cancels it.
+func (s *Service) DeleteProfile(ctx context.Context, params service.DeleteProfileParam) (*service.DeleteProfileResponse, common.Error) {
+	user, errSvc := s.getUserForStatusChange(ctx, params.UserID)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	err := s.Hasher.CompareHashAndPassword([]byte(user.HashedPassword), []byte(params.Password))
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.WrongCurrentPasswordErrorMessage,
+			errors.BadRequestErrorType,
+			errors.WrongCurrentPasswordErrorCode)
+	}
+
+	errSvc = checkAccountActive(user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	deleteAfter := time.Now().Add(s.DeletionGracePeriod)
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.ScheduleUserDeletion(ctx, user.ID, deleteAfter)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, user.ID, common.ACCOUNT_DELETION_REQUESTED_ACTIVITY)
+	})
+
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	err = s.revokeAllSessions(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return &service.DeleteProfileResponse{
+		DeleteAfter: deleteAfter,
+	}, nil
+}
+
+// cancelUserDeletion makes an account waiting for erasure active again.
+func (s *Service) cancelUserDeletion(ctx context.Context, userID int64) error {
+	return s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.CancelUserDeletion(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, userID, common.ACCOUNT_DELETION_CANCELLED_ACTIVITY)
+	})
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestDeleteProfile(t *testing.T) {
+	params := service.DeleteProfileParam{UserID: 1, Password: "Current1!"}
+	gracePeriod := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name          string
+		user          *repository.User
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Delete",
+			user:          &repository.User{ID: 1, HashedPassword: "hash", Status: common.ACTIVE_STATUS},
+			expectedError: nil,
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Wrong Password",
+			user:          &repository.User{ID: 1, HashedPassword: "hash", Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.WrongCurrentPasswordErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Suspended Account",
+			user:          &repository.User{ID: 1, HashedPassword: "hash", Status: common.SUSPENDED_STATUS},
+			expectedError: commonErr.NewError(commonErr.AccountSuspendedErrorMessage, commonErr.ForbiddenErrorType),
+		},
+		{
+			name:          "Schedule Deletion Error",
+			user:          &repository.User{ID: 1, HashedPassword: "hash", Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("Schedule Deletion Error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Delete":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte("hash"), []byte(params.Password)).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ScheduleUserDeletion(gomock.Any(), params.UserID, gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, deleteAfter time.Time) error {
+					assert.WithinDuration(t, time.Now().Add(gracePeriod), deleteAfter, time.Second)
+					return nil
+				})
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.ACCOUNT_DELETION_REQUESTED_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), params.UserID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), params.UserID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), params.UserID).Return(nil)
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(nil, nil)
+			case "Wrong Password":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("mismatch"))
+			case "Suspended Account":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+			case "Schedule Deletion Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ScheduleUserDeletion(gomock.Any(), params.UserID, gomock.Any()).Return(errors.New("Schedule Deletion Error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:          mockRepo,
+				Hasher:              mockHasher,
+				RevocationStore:     mockStore,
+				DeletionGracePeriod: gracePeriod,
+			})
+
+			resp, err := svc.DeleteProfile(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, resp)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.WithinDuration(t, time.Now().Add(gracePeriod), resp.DeleteAfter, time.Second)
+			}
+		})
+	}
+}
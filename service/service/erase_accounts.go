@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+)
+
+// EraseDueAccounts anonymizes the accounts whose deletion grace period has
+// ended and returns how many were erased. The user rows are kept, as the
+// activity logs still reference them, but the name, phone numbers, password,
+// second factors and passkeys are removed and the logged IP addresses are
+// scrubbed.
+func (s *Service) EraseDueAccounts(ctx context.Context) (int, common.Error) {
+	userIDs, err := s.Repository.ListUsersDueForErasure(ctx, time.Now(), erasureBatchSize)
+	if err != nil {
+		return 0, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	erased := 0
+	for _, userID := range userIDs {
+		ok, err := s.eraseAccount(ctx, userID)
+		if err != nil {
+			return erased, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		if ok {
+			erased++
+		}
+	}
+
+	return erased, nil
+}
+
+// eraseAccount anonymizes one account in a single transaction. It reports
+// false when the deletion was cancelled after the account was listed.
+func (s *Service) eraseAccount(ctx context.Context, userID int64) (bool, error) {
+	erased := false
+	err := s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.Repository.AnonymizeUser(ctx, userID)
+		if err != nil || !ok {
+			return err
+		}
+
+		err = s.Repository.ScrubUserActivityLogs(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.ScrubUserSessions(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.DeleteUserVerificationCodes(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.DeleteUserTOTP(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.DeleteRecoveryCodes(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.DeleteUserWebAuthnCredentials(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.InsertUserActivityLog(ctx, userID, common.ACCOUNT_ERASED_ACTIVITY)
+		if err != nil {
+			return err
+		}
+
+		erased = true
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return erased, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+)
+
+func TestEraseDueAccounts(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedErased int
+		expectedError  common.Error
+	}{
+		{
+			name:           "Successful Erase",
+			expectedErased: 1,
+			expectedError:  nil,
+		},
+		{
+			name:           "Deletion Cancelled Meanwhile",
+			expectedErased: 0,
+			expectedError:  nil,
+		},
+		{
+			name:           "List Users Error",
+			expectedErased: 0,
+			expectedError:  commonErr.NewError("List Users Error", commonErr.SystemErrorType),
+		},
+		{
+			name:           "Scrub Activity Logs Error",
+			expectedErased: 0,
+			expectedError:  commonErr.NewError("Scrub Activity Logs Error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Erase":
+				mockRepo.EXPECT().ListUsersDueForErasure(gomock.Any(), gomock.Any(), erasureBatchSize).Return([]int64{7}, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().AnonymizeUser(gomock.Any(), int64(7)).Return(true, nil)
+				mockRepo.EXPECT().ScrubUserActivityLogs(gomock.Any(), int64(7)).Return(nil)
+				mockRepo.EXPECT().ScrubUserSessions(gomock.Any(), int64(7)).Return(nil)
+				mockRepo.EXPECT().DeleteUserVerificationCodes(gomock.Any(), int64(7)).Return(nil)
+				mockRepo.EXPECT().DeleteUserTOTP(gomock.Any(), int64(7)).Return(nil)
+				mockRepo.EXPECT().DeleteRecoveryCodes(gomock.Any(), int64(7)).Return(nil)
+				mockRepo.EXPECT().DeleteUserWebAuthnCredentials(gomock.Any(), int64(7)).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(7), common.ACCOUNT_ERASED_ACTIVITY).Return(nil)
+			case "Deletion Cancelled Meanwhile":
+				mockRepo.EXPECT().ListUsersDueForErasure(gomock.Any(), gomock.Any(), erasureBatchSize).Return([]int64{7}, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().AnonymizeUser(gomock.Any(), int64(7)).Return(false, nil)
+			case "List Users Error":
+				mockRepo.EXPECT().ListUsersDueForErasure(gomock.Any(), gomock.Any(), erasureBatchSize).Return(nil, errors.New("List Users Error"))
+			case "Scrub Activity Logs Error":
+				mockRepo.EXPECT().ListUsersDueForErasure(gomock.Any(), gomock.Any(), erasureBatchSize).Return([]int64{7}, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().AnonymizeUser(gomock.Any(), int64(7)).Return(true, nil)
+				mockRepo.EXPECT().ScrubUserActivityLogs(gomock.Any(), int64(7)).Return(errors.New("Scrub Activity Logs Error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			erased, err := svc.EraseDueAccounts(context.Background())
+
+			assert.Equal(t, tc.expectedErased, erased)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
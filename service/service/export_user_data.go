@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// ExportUserData streams everything stored about the user to the writer:
+// the account, every activity, the active sessions, the verification codes
+// sent and the passkeys. Rows go to the writer as they are read, and each
+// user gets at most dataExportMaxPerDay exports a day.
+func (s *Service) ExportUserData(ctx context.Context, params service.ExportUserDataParam) common.Error {
+	count, err := s.Repository.CountUserActivitySince(ctx, params.UserID, common.DATA_EXPORT_ACTIVITY, time.Now().Add(-dataExportWindow))
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if count >= dataExportMaxPerDay {
+		return errors.NewErrorWithCode(
+			errors.TooManyDataExportsErrorMessage,
+			errors.TooManyRequestsErrorType,
+			errors.TooManyDataExportsErrorCode)
+	}
+
+	profile, errSvc := s.getUserDataProfile(ctx, params.UserID)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	// The export is recorded before anything is written, so an export cut
+	// short still counts towards the limit.
+	err = s.Repository.InsertUserActivityLog(ctx, params.UserID, common.DATA_EXPORT_ACTIVITY)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	err = s.writeUserData(ctx, profile, params.Writer)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
+
+func (s *Service) getUserDataProfile(ctx context.Context, userID int64) (*service.UserDataProfile, common.Error) {
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.NotFoundErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	access, err := s.Repository.GetUserAccess(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	totp, err := s.Repository.GetUserTOTP(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	recoveryCodes, err := s.Repository.GetUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	profile := &service.UserDataProfile{
+		ID:                  user.ID,
+		FullName:            user.FullName,
+		PhoneNumber:         user.Phone,
+		PhoneVerifiedAt:     user.PhoneVerifiedAt,
+		PendingPhoneNumber:  user.PendingPhone,
+		LoginCount:          user.LoginCount,
+		FailedLoginCount:    user.FailedLoginCount,
+		LockedUntil:         user.LockedUntil,
+		Status:              user.Status,
+		StatusReason:        user.StatusReason,
+		StatusChangedAt:     user.StatusChangedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		Roles:               access.Roles,
+		RecoveryCodesLeft:   len(recoveryCodes),
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}
+	if totp != nil {
+		profile.TwoFactorEnabledAt = totp.ConfirmedAt
+	}
+
+	return profile, nil
+}
+
+func (s *Service) writeUserData(ctx context.Context, profile *service.UserDataProfile, w service.UserDataWriter) error {
+	err := w.WriteProfile(*profile)
+	if err != nil {
+		return err
+	}
+
+	err = s.Repository.StreamUserActivityLogs(ctx, profile.ID, func(log *repository.UserActivityLog) error {
+		return w.WriteActivity(toUserActivity(log))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.Repository.StreamUserSessions(ctx, profile.ID, func(session *repository.UserSession) error {
+		return w.WriteSession(toUserSession(session))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.Repository.StreamUserVerificationCodes(ctx, profile.ID, func(code *repository.VerificationCode) error {
+		return w.WriteVerificationCode(service.UserVerificationCode{
+			Purpose:    code.Purpose,
+			Target:     code.Target,
+			Attempts:   code.Attempts,
+			ExpiresAt:  code.ExpiresAt,
+			ConsumedAt: code.ConsumedAt,
+			CreatedAt:  code.CreatedAt,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.Repository.StreamUserWebAuthnCredentials(ctx, profile.ID, func(credential *repository.WebAuthnCredential) error {
+		return w.WritePasskey(service.UserPasskey{
+			CredentialID: credential.CredentialID,
+			Transports:   credential.Transports,
+			LastUsedAt:   credential.LastUsedAt,
+			CreatedAt:    credential.CreatedAt,
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// recordingUserDataWriter keeps every piece of an export it receives.
+type recordingUserDataWriter struct {
+	profile    *service.UserDataProfile
+	activities []service.UserActivity
+	sessions   []service.UserSession
+	codes      []service.UserVerificationCode
+	passkeys   []service.UserPasskey
+}
+
+func (w *recordingUserDataWriter) WriteProfile(profile service.UserDataProfile) error {
+	w.profile = &profile
+	return nil
+}
+
+func (w *recordingUserDataWriter) WriteActivity(activity service.UserActivity) error {
+	w.activities = append(w.activities, activity)
+	return nil
+}
+
+func (w *recordingUserDataWriter) WriteSession(session service.UserSession) error {
+	w.sessions = append(w.sessions, session)
+	return nil
+}
+
+func (w *recordingUserDataWriter) WriteVerificationCode(code service.UserVerificationCode) error {
+	w.codes = append(w.codes, code)
+	return nil
+}
+
+func (w *recordingUserDataWriter) WritePasskey(passkey service.UserPasskey) error {
+	w.passkeys = append(w.passkeys, passkey)
+	return nil
+}
+
+func TestExportUserData(t *testing.T) {
+	now := time.Now()
+	ip := "203.0.113.7"
+	user := &repository.User{ID: 1, FullName: "maulana aji satrio", HashedPassword: "hash", Phone: "+628232482440", Status: common.ACTIVE_STATUS, CreatedAt: now, UpdatedAt: now}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Export",
+			expectedError: nil,
+		},
+		{
+			name:          "Too Many Exports",
+			expectedError: commonErr.NewErrorWithCode(commonErr.TooManyDataExportsErrorMessage, commonErr.TooManyRequestsErrorType, commonErr.TooManyDataExportsErrorCode),
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewErrorWithCode(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType, commonErr.UserDataNotFoundErrorCode),
+		},
+		{
+			name:          "Stream Activity Logs Error",
+			expectedError: commonErr.NewError("Stream Activity Logs Error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Stream Passkeys Error",
+			expectedError: commonErr.NewError("Stream Passkeys Error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			writer := &recordingUserDataWriter{}
+
+			expectProfile := func() {
+				mockRepo.EXPECT().CountUserActivitySince(gomock.Any(), int64(1), common.DATA_EXPORT_ACTIVITY, gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, activityType string, since time.Time) (int64, error) {
+					assert.WithinDuration(t, time.Now().Add(-24*time.Hour), since, time.Second)
+					return 2, nil
+				})
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(user, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), int64(1)).Return(&repository.UserAccess{Roles: []string{"support"}}, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(&repository.UserTOTP{UserID: 1, ConfirmedAt: &now}, nil)
+				mockRepo.EXPECT().GetUnusedRecoveryCodes(gomock.Any(), int64(1)).Return([]*repository.RecoveryCode{{ID: 1}, {ID: 2}}, nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.DATA_EXPORT_ACTIVITY).Return(nil)
+			}
+
+			switch tc.name {
+			case "Successful Export":
+				expectProfile()
+				mockRepo.EXPECT().StreamUserActivityLogs(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, fn func(*repository.UserActivityLog) error) error {
+					_ = fn(&repository.UserActivityLog{ID: 1, UserID: 1, ActivityType: common.LOGIN_ACTIVITY, IPAddress: &ip, CreatedAt: now})
+					return fn(&repository.UserActivityLog{ID: 2, UserID: 1, ActivityType: common.DATA_EXPORT_ACTIVITY, CreatedAt: now})
+				})
+				mockRepo.EXPECT().StreamUserSessions(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, fn func(*repository.UserSession) error) error {
+					return fn(&repository.UserSession{ID: 5, UserID: 1, DeviceName: "iOS mobile", IPAddress: &ip, LastSeenAt: now, RevokedAt: &now, CreatedAt: now})
+				})
+				mockRepo.EXPECT().StreamUserVerificationCodes(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, fn func(*repository.VerificationCode) error) error {
+					return fn(&repository.VerificationCode{ID: 3, UserID: 1, Purpose: common.PHONE_VERIFICATION_PURPOSE, Target: "+628232482440", CodeHash: "hash", ExpiresAt: now, CreatedAt: now})
+				})
+				mockRepo.EXPECT().StreamUserWebAuthnCredentials(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, fn func(*repository.WebAuthnCredential) error) error {
+					return fn(&repository.WebAuthnCredential{ID: 4, UserID: 1, CredentialID: []byte{1, 2}, PublicKey: []byte{3}, SignCount: 7, Transports: []string{"internal"}, LastUsedAt: &now, CreatedAt: now})
+				})
+			case "Stream Passkeys Error":
+				expectProfile()
+				mockRepo.EXPECT().StreamUserActivityLogs(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().StreamUserSessions(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().StreamUserVerificationCodes(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().StreamUserWebAuthnCredentials(gomock.Any(), int64(1), gomock.Any()).Return(errors.New("Stream Passkeys Error"))
+			case "Too Many Exports":
+				mockRepo.EXPECT().CountUserActivitySince(gomock.Any(), int64(1), common.DATA_EXPORT_ACTIVITY, gomock.Any()).Return(int64(3), nil)
+			case "User Not Found":
+				mockRepo.EXPECT().CountUserActivitySince(gomock.Any(), int64(1), common.DATA_EXPORT_ACTIVITY, gomock.Any()).Return(int64(0), nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(nil, nil)
+			case "Stream Activity Logs Error":
+				expectProfile()
+				mockRepo.EXPECT().StreamUserActivityLogs(gomock.Any(), int64(1), gomock.Any()).Return(errors.New("Stream Activity Logs Error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			err := svc.ExportUserData(context.Background(), service.ExportUserDataParam{
+				UserID: 1,
+				Writer: writer,
+			})
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+				assert.Equal(t, tc.expectedError.GetErrorCode(), err.GetErrorCode())
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, &service.UserDataProfile{
+				ID:                 1,
+				FullName:           "maulana aji satrio",
+				PhoneNumber:        "+628232482440",
+				Status:             common.ACTIVE_STATUS,
+				Roles:              []string{"support"},
+				TwoFactorEnabledAt: &now,
+				RecoveryCodesLeft:  2,
+				CreatedAt:          now,
+				UpdatedAt:          now,
+			}, writer.profile)
+			assert.Equal(t, []service.UserActivity{
+				{ActivityType: common.LOGIN_ACTIVITY, IPAddress: ip, CreatedAt: now},
+				{ActivityType: common.DATA_EXPORT_ACTIVITY, CreatedAt: now},
+			}, writer.activities)
+			assert.Equal(t, []service.UserSession{{ID: 5, DeviceName: "iOS mobile", IPAddress: ip, LastSeenAt: now, RevokedAt: &now, CreatedAt: now}}, writer.sessions)
+			assert.Equal(t, []service.UserVerificationCode{{Purpose: common.PHONE_VERIFICATION_PURPOSE, Target: "+628232482440", ExpiresAt: now, CreatedAt: now}}, writer.codes)
+			assert.Equal(t, []service.UserPasskey{{CredentialID: []byte{1, 2}, Transports: []string{"internal"}, LastUsedAt: &now, CreatedAt: now}}, writer.passkeys)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+const passwordResetMessageFormat = "Your password reset code is %s. It expires in 10 minutes. Do not share it with anyone."
+
+// ForgotPassword sends a password reset code to the phone number. Unknown
+// numbers and rate limited requests succeed silently so that the endpoint
+// cannot be used to find out which numbers are registered.
+func (s *Service) ForgotPassword(ctx context.Context, params service.ForgotPasswordParam) common.Error {
+
+	user, err := s.Repository.GetUserByPhone(ctx, params.PhoneNumber)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	sent, err := s.sendVerificationCode(ctx, user.ID, common.PASSWORD_RESET_PURPOSE, user.Phone, passwordResetMessageFormat)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !sent {
+		return nil
+	}
+
+	err = s.Repository.InsertUserActivityLog(ctx, user.ID, common.PASSWORD_RESET_REQUEST_ACTIVITY)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestForgotPassword(t *testing.T) {
+	params := service.ForgotPasswordParam{PhoneNumber: "+628123456789"}
+	user := &repository.User{ID: 1, Phone: "+628123456789"}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Forgot Password",
+			expectedError: nil,
+		},
+		{
+			name:          "Unknown Phone Number",
+			expectedError: nil,
+		},
+		{
+			name:          "Rate Limited",
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By Phone",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Insert Code Error",
+			expectedError: commonErr.NewError("insert error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Send Error",
+			expectedError: commonErr.NewError("send error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			mockNotifier := mocks.NewMockNotifier(ctrl)
+
+			switch tc.name {
+			case "Successful Forgot Password":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, code *repository.VerificationCode) (*repository.VerificationCode, error) {
+						assert.Equal(t, user.ID, code.UserID)
+						assert.Equal(t, common.PASSWORD_RESET_PURPOSE, code.Purpose)
+						assert.Equal(t, "code hash", code.CodeHash)
+						return code, nil
+					})
+				mockNotifier.EXPECT().Send(gomock.Any(), user.Phone, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.PASSWORD_RESET_REQUEST_ACTIVITY).Return(nil)
+			case "Unknown Phone Number":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, nil)
+			case "Rate Limited":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE, gomock.Any()).Return(int64(verificationCodeMaxPerHour), nil)
+			case "Error DB - Get User By Phone":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, errors.New("some error"))
+			case "Insert Code Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).Return(nil, errors.New("insert error"))
+			case "Send Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).Return(&repository.VerificationCode{ID: 1}, nil)
+				mockNotifier.EXPECT().Send(gomock.Any(), user.Phone, gomock.Any()).Return(errors.New("send error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+				Hasher:     mockHasher,
+				Notifier:   mockNotifier,
+			})
+
+			err := svc.ForgotPassword(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -19,13 +19,21 @@ func (s *Service) GetProfile(ctx context.Context, userID int64) (*service.UserIn
 	}
 
 	if user == nil {
-		return nil, errors.NewError(
+		return nil, errors.NewErrorWithCode(
 			errors.UserDataNotFoundErrorMessage,
-			errors.BadRequestErrorType)
+			errors.BadRequestErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	pendingPhone := ""
+	if user.PendingPhone != nil {
+		pendingPhone = *user.PendingPhone
 	}
 
 	return &service.UserInfoResponse{
-		FullName:    user.FullName,
-		PhoneNumber: user.Phone,
+		FullName:           user.FullName,
+		PhoneNumber:        user.Phone,
+		PhoneVerified:      user.PhoneVerifiedAt != nil,
+		PendingPhoneNumber: pendingPhone,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +17,9 @@ import (
 )
 
 func TestGetProfile(t *testing.T) {
+	verifiedAt := time.Now()
+	pendingPhone := "+62811111111"
+
 	testCases := []struct {
 		name           string
 		userID         int64
@@ -30,6 +34,13 @@ func TestGetProfile(t *testing.T) {
 			expectedError:  nil,
 			expectedResult: &service.UserInfoResponse{FullName: "maulana aji", PhoneNumber: "+628232482488"},
 		},
+		{
+			name:           "Successful GetProfile Pending Phone Change",
+			userID:         1,
+			user:           &repository.User{ID: 1, FullName: "maulana aji", Phone: "+628232482488", PhoneVerifiedAt: &verifiedAt, PendingPhone: &pendingPhone},
+			expectedError:  nil,
+			expectedResult: &service.UserInfoResponse{FullName: "maulana aji", PhoneNumber: "+628232482488", PhoneVerified: true, PendingPhoneNumber: pendingPhone},
+		},
 		{
 			name:           "Error DB",
 			userID:         1,
@@ -52,7 +63,7 @@ func TestGetProfile(t *testing.T) {
 			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
 
 			switch tc.name {
-			case "Successful GetProfile":
+			case "Successful GetProfile", "Successful GetProfile Pending Phone Change":
 				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.userID).Return(tc.user, nil)
 			case "Error DB":
 				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.userID).Return(nil, errors.New("some error"))
@@ -75,6 +86,8 @@ func TestGetProfile(t *testing.T) {
 				assert.NotNil(t, response)
 				assert.Equal(t, tc.expectedResult.FullName, response.FullName)
 				assert.Equal(t, tc.expectedResult.PhoneNumber, response.PhoneNumber)
+				assert.Equal(t, tc.expectedResult.PhoneVerified, response.PhoneVerified)
+				assert.Equal(t, tc.expectedResult.PendingPhoneNumber, response.PendingPhoneNumber)
 			}
 		})
 	}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+// GetUserDetail returns one user with their roles and most recent
+// activities, for support staff.
+func (s *Service) GetUserDetail(ctx context.Context, userID int64) (*service.UserDetailResponse, common.Error) {
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.NotFoundErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	access, err := s.Repository.GetUserAccess(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	logs, err := s.Repository.ListRecentUserActivityLogs(ctx, userID, recentActivityLimit)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	activities := make([]service.UserActivity, 0, len(logs))
+	for _, log := range logs {
+		activities = append(activities, toUserActivity(log))
+	}
+
+	return &service.UserDetailResponse{
+		User:             toAdminUser(user),
+		Roles:            access.Roles,
+		Permissions:      access.Permissions,
+		RecentActivities: activities,
+	}, nil
+}
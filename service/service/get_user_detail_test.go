@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestGetUserDetail(t *testing.T) {
+	now := time.Now()
+	ip := "203.0.113.7"
+	user := &repository.User{ID: 1, FullName: "maulana aji", Phone: "+628232482488", LoginCount: 2, CreatedAt: now, UpdatedAt: now}
+	access := &repository.UserAccess{Roles: []string{"support"}, Permissions: []string{"users:read", "users:unlock"}}
+
+	testCases := []struct {
+		name           string
+		expectedError  common.Error
+		expectedResult *service.UserDetailResponse
+	}{
+		{
+			name: "Successful GetUserDetail",
+			expectedResult: &service.UserDetailResponse{
+				User:        toAdminUser(user),
+				Roles:       []string{"support"},
+				Permissions: []string{"users:read", "users:unlock"},
+				RecentActivities: []service.UserActivity{
+					{ActivityType: common.LOGIN_FAILED_ACTIVITY, IPAddress: ip, CreatedAt: now},
+					{ActivityType: common.LOGIN_ACTIVITY, CreatedAt: now},
+				},
+			},
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Error Get User",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Error Get Access",
+			expectedError: commonErr.NewError("access error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Error List Activities",
+			expectedError: commonErr.NewError("list error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+
+			switch tc.name {
+			case "Successful GetUserDetail":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(user, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), int64(1)).Return(access, nil)
+				mockRepo.EXPECT().ListRecentUserActivityLogs(gomock.Any(), int64(1), recentActivityLimit).Return([]*repository.UserActivityLog{
+					{ID: 2, UserID: 1, ActivityType: common.LOGIN_FAILED_ACTIVITY, IPAddress: &ip, CreatedAt: now},
+					{ID: 1, UserID: 1, ActivityType: common.LOGIN_ACTIVITY, CreatedAt: now},
+				}, nil)
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(nil, nil)
+			case "Error Get User":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(nil, errors.New("some error"))
+			case "Error Get Access":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(user, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), int64(1)).Return(nil, errors.New("access error"))
+			case "Error List Activities":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(user, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), int64(1)).Return(access, nil)
+				mockRepo.EXPECT().ListRecentUserActivityLogs(gomock.Any(), int64(1), recentActivityLimit).Return(nil, errors.New("list error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			response, err := svc.GetUserDetail(context.Background(), 1)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/useragent"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// issueTokens signs a new access token and stores a new refresh token for a
+// session of the user. A zero sessionID starts a new session with a new
+// refresh token family, as on login. The user's roles and permissions are
+// read again every time, so a change applies from the next login or refresh.
+func (s *Service) issueTokens(ctx context.Context, userID, sessionID int64, familyID string) (*service.LoginResponse, error) {
+	if sessionID == 0 {
+		session, err := s.Repository.InsertUserSession(ctx, newUserSession(ctx, userID))
+		if err != nil {
+			return nil, err
+		}
+		sessionID = session.ID
+
+		familyID, err = helper.GenerateOpaqueToken()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	refreshToken, err := helper.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Repository.InsertRefreshToken(ctx, &repository.RefreshToken{
+		UserID:    userID,
+		SessionID: sessionID,
+		FamilyID:  familyID,
+		TokenHash: helper.HashOpaqueToken(refreshToken),
+		ExpiresAt: time.Now().Add(refreshTokenDuration),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := s.Repository.GetUserAccess(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := helper.CreateToken(userID, sessionID, access.Roles, access.Permissions, accessTokenDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.LoginResponse{
+		UserID:       userID,
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// newUserSession describes the client of the request in ctx.
+func newUserSession(ctx context.Context, userID int64) *repository.UserSession {
+	info := common.RequestInfoFromContext(ctx)
+
+	session := &repository.UserSession{
+		UserID:     userID,
+		DeviceName: deviceName(info),
+	}
+	if info.IPAddress != "" {
+		session.IPAddress = &info.IPAddress
+	}
+	if info.UserAgent != "" {
+		session.UserAgent = &info.UserAgent
+	}
+
+	return session
+}
+
+// deviceName names the device of a client after the families parsed from its
+// User-Agent, such as "iOS mobile".
+func deviceName(info common.RequestInfo) string {
+	if info.OSFamily == "" || info.OSFamily == useragent.OtherOS {
+		return "Unknown device"
+	}
+
+	return info.OSFamily + " " + info.DeviceFamily
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// ListActivities returns a page of the user's own activity history, newest
+// first, optionally narrowed to one activity type and a time range.
+func (s *Service) ListActivities(ctx context.Context, params service.ListActivitiesParam) (*service.ListActivitiesResponse, common.Error) {
+	afterID, err := decodeIDCursor(params.Cursor)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidCursorErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidCursorErrorCode)
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultActivityPageSize
+	}
+	if limit > maxActivityPageSize {
+		limit = maxActivityPageSize
+	}
+
+	// One extra row tells whether there is a next page.
+	logs, err := s.Repository.ListUserActivityLogs(ctx, repository.UserActivityLogFilter{
+		UserID:       params.UserID,
+		ActivityType: params.ActivityType,
+		CreatedFrom:  params.CreatedFrom,
+		CreatedTo:    params.CreatedTo,
+		AfterID:      afterID,
+		Limit:        limit + 1,
+	})
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	resp := &service.ListActivitiesResponse{
+		Activities: []service.UserActivity{},
+	}
+
+	if len(logs) > limit {
+		logs = logs[:limit]
+		resp.NextCursor = encodeIDCursor(logs[limit-1].ID)
+	}
+
+	for _, log := range logs {
+		resp.Activities = append(resp.Activities, toUserActivity(log))
+	}
+
+	return resp, nil
+}
+
+func toUserActivity(log *repository.UserActivityLog) service.UserActivity {
+	return service.UserActivity{
+		ActivityType: log.ActivityType,
+		IPAddress:    stringValue(log.IPAddress),
+		UserAgent:    stringValue(log.UserAgent),
+		DeviceFamily: stringValue(log.DeviceFamily),
+		OSFamily:     stringValue(log.OSFamily),
+		RequestID:    stringValue(log.RequestID),
+		CreatedAt:    log.CreatedAt,
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestListActivities(t *testing.T) {
+	now := time.Now()
+	from := now.Add(-24 * time.Hour)
+	ip := "203.0.113.7"
+	logs := []*repository.UserActivityLog{
+		{ID: 9, UserID: 1, ActivityType: common.LOGIN_ACTIVITY, IPAddress: &ip, CreatedAt: now},
+		{ID: 5, UserID: 1, ActivityType: common.LOGIN_ACTIVITY, CreatedAt: now},
+		{ID: 2, UserID: 1, ActivityType: common.LOGIN_ACTIVITY, CreatedAt: now},
+	}
+
+	testCases := []struct {
+		name               string
+		params             service.ListActivitiesParam
+		expectedFilter     repository.UserActivityLogFilter
+		logs               []*repository.UserActivityLog
+		repoError          error
+		expectedActivities []service.UserActivity
+		expectedNextCursor string
+		expectedError      common.Error
+	}{
+		{
+			name: "Last Page",
+			params: service.ListActivitiesParam{
+				UserID:       1,
+				ActivityType: common.LOGIN_ACTIVITY,
+				CreatedFrom:  &from,
+				CreatedTo:    &now,
+			},
+			expectedFilter: repository.UserActivityLogFilter{
+				UserID:       1,
+				ActivityType: common.LOGIN_ACTIVITY,
+				CreatedFrom:  &from,
+				CreatedTo:    &now,
+				Limit:        defaultActivityPageSize + 1,
+			},
+			logs: logs[:1],
+			expectedActivities: []service.UserActivity{
+				{ActivityType: common.LOGIN_ACTIVITY, IPAddress: ip, CreatedAt: now},
+			},
+		},
+		{
+			name:           "Has Next Page",
+			params:         service.ListActivitiesParam{UserID: 1, Limit: 2},
+			expectedFilter: repository.UserActivityLogFilter{UserID: 1, Limit: 3},
+			logs:           logs,
+			expectedActivities: []service.UserActivity{
+				{ActivityType: common.LOGIN_ACTIVITY, IPAddress: ip, CreatedAt: now},
+				{ActivityType: common.LOGIN_ACTIVITY, CreatedAt: now},
+			},
+			expectedNextCursor: encodeIDCursor(5),
+		},
+		{
+			name:           "Continue From Cursor",
+			params:         service.ListActivitiesParam{UserID: 1, Cursor: encodeIDCursor(5), Limit: 2},
+			expectedFilter: repository.UserActivityLogFilter{UserID: 1, AfterID: 5, Limit: 3},
+			logs:           logs[2:],
+			expectedActivities: []service.UserActivity{
+				{ActivityType: common.LOGIN_ACTIVITY, CreatedAt: now},
+			},
+		},
+		{
+			name:               "Limit Is Capped",
+			params:             service.ListActivitiesParam{UserID: 1, Limit: 1000},
+			expectedFilter:     repository.UserActivityLogFilter{UserID: 1, Limit: maxActivityPageSize + 1},
+			logs:               []*repository.UserActivityLog{},
+			expectedActivities: []service.UserActivity{},
+		},
+		{
+			name:          "Invalid Cursor",
+			params:        service.ListActivitiesParam{UserID: 1, Cursor: "not a cursor"},
+			expectedError: commonErr.NewErrorWithCode(commonErr.InvalidCursorErrorMessage, commonErr.BadRequestErrorType, commonErr.InvalidCursorErrorCode),
+		},
+		{
+			name:           "Error DB",
+			params:         service.ListActivitiesParam{UserID: 1},
+			expectedFilter: repository.UserActivityLogFilter{UserID: 1, Limit: defaultActivityPageSize + 1},
+			repoError:      errors.New("some error"),
+			expectedError:  commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			if tc.logs != nil || tc.repoError != nil {
+				mockRepo.EXPECT().ListUserActivityLogs(gomock.Any(), tc.expectedFilter).Return(tc.logs, tc.repoError)
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			response, err := svc.ListActivities(context.Background(), tc.params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+				assert.Equal(t, tc.expectedError.GetErrorCode(), err.GetErrorCode())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedActivities, response.Activities)
+				assert.Equal(t, tc.expectedNextCursor, response.NextCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// ListSessions returns the sessions the user is still logged in with, most
+// recently used first. A session unused for longer than a refresh token
+// lives can no longer be refreshed, so it is left out.
+func (s *Service) ListSessions(ctx context.Context, params service.ListSessionsParam) (*service.ListSessionsResponse, common.Error) {
+	sessions, err := s.Repository.ListUserSessions(ctx, params.UserID, time.Now().Add(-refreshTokenDuration))
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	resp := &service.ListSessionsResponse{
+		Sessions: make([]service.UserSession, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		userSession := toUserSession(session)
+		userSession.Current = session.ID == params.CurrentSessionID
+		resp.Sessions = append(resp.Sessions, userSession)
+	}
+
+	return resp, nil
+}
+
+func toUserSession(session *repository.UserSession) service.UserSession {
+	return service.UserSession{
+		ID:         session.ID,
+		DeviceName: session.DeviceName,
+		IPAddress:  stringValue(session.IPAddress),
+		UserAgent:  stringValue(session.UserAgent),
+		LastSeenAt: session.LastSeenAt,
+		RevokedAt:  session.RevokedAt,
+		CreatedAt:  session.CreatedAt,
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestListSessions(t *testing.T) {
+	now := time.Now()
+	ip := "203.0.113.7"
+	userAgent := "Mozilla/5.0"
+	sessions := []*repository.UserSession{
+		{ID: 4, UserID: 1, DeviceName: "iOS mobile", IPAddress: &ip, UserAgent: &userAgent, LastSeenAt: now, CreatedAt: now},
+		{ID: 2, UserID: 1, DeviceName: "Unknown device", LastSeenAt: now, CreatedAt: now},
+	}
+
+	testCases := []struct {
+		name             string
+		sessions         []*repository.UserSession
+		repoError        error
+		expectedSessions []service.UserSession
+		expectedError    common.Error
+	}{
+		{
+			name:     "Sessions Exist",
+			sessions: sessions,
+			expectedSessions: []service.UserSession{
+				{ID: 4, DeviceName: "iOS mobile", IPAddress: ip, UserAgent: userAgent, LastSeenAt: now, CreatedAt: now},
+				{ID: 2, DeviceName: "Unknown device", Current: true, LastSeenAt: now, CreatedAt: now},
+			},
+		},
+		{
+			name:             "No Sessions",
+			sessions:         []*repository.UserSession{},
+			expectedSessions: []service.UserSession{},
+		},
+		{
+			name:          "Error DB",
+			repoError:     errors.New("some error"),
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockRepo.EXPECT().ListUserSessions(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, userID int64, activeSince time.Time) ([]*repository.UserSession, error) {
+					assert.WithinDuration(t, time.Now().Add(-refreshTokenDuration), activeSince, time.Minute)
+					return tc.sessions, tc.repoError
+				})
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			response, err := svc.ListSessions(context.Background(), service.ListSessionsParam{UserID: 1, CurrentSessionID: 2})
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedSessions, response.Sessions)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// ListUsers returns a page of users matching the search, newest first, for
+// support staff.
+func (s *Service) ListUsers(ctx context.Context, params service.ListUsersParam) (*service.ListUsersResponse, common.Error) {
+	afterID, err := decodeIDCursor(params.Cursor)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidCursorErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidCursorErrorCode)
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultUserPageSize
+	}
+	if limit > maxUserPageSize {
+		limit = maxUserPageSize
+	}
+
+	// One extra row tells whether there is a next page.
+	users, err := s.Repository.ListUsers(ctx, repository.UserFilter{
+		PhonePrefix:  params.PhonePrefix,
+		NameFragment: params.Name,
+		CreatedFrom:  params.CreatedFrom,
+		CreatedTo:    params.CreatedTo,
+		AfterID:      afterID,
+		Limit:        limit + 1,
+	})
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	resp := &service.ListUsersResponse{
+		Users: []service.AdminUser{},
+	}
+
+	if len(users) > limit {
+		users = users[:limit]
+		resp.NextCursor = encodeIDCursor(users[limit-1].ID)
+	}
+
+	for _, user := range users {
+		resp.Users = append(resp.Users, toAdminUser(user))
+	}
+
+	return resp, nil
+}
+
+func toAdminUser(user *repository.User) service.AdminUser {
+	return service.AdminUser{
+		ID:                  user.ID,
+		FullName:            user.FullName,
+		PhoneNumber:         user.Phone,
+		PhoneVerified:       user.PhoneVerifiedAt != nil,
+		LoginCount:          user.LoginCount,
+		FailedLoginCount:    user.FailedLoginCount,
+		LockedUntil:         user.LockedUntil,
+		Status:              user.Status,
+		StatusReason:        user.StatusReason,
+		StatusChangedAt:     user.StatusChangedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}
+}
+
+// Cursors carry the id of the last row of a page. They are opaque to
+// clients so the pagination key can change later.
+func encodeIDCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeIDCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if id <= 0 {
+		return 0, strconv.ErrRange
+	}
+
+	return id, nil
+}
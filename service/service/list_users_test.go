@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestListUsers(t *testing.T) {
+	now := time.Now()
+	from := now.Add(-24 * time.Hour)
+	users := []*repository.User{
+		{ID: 3, FullName: "maulana aji", Phone: "+628232482488", PhoneVerifiedAt: &now, CreatedAt: now},
+		{ID: 2, FullName: "budi santoso", Phone: "+628111111111", LoginCount: 4, LockedUntil: &now, CreatedAt: now},
+		{ID: 1, FullName: "siti aminah", Phone: "+628122222222", CreatedAt: now},
+	}
+
+	testCases := []struct {
+		name               string
+		params             service.ListUsersParam
+		expectedFilter     repository.UserFilter
+		users              []*repository.User
+		repoError          error
+		expectedIDs        []int64
+		expectedNextCursor string
+		expectedError      common.Error
+	}{
+		{
+			name: "Last Page",
+			params: service.ListUsersParam{
+				PhonePrefix: "+628",
+				Name:        "aji",
+				CreatedFrom: &from,
+				CreatedTo:   &now,
+			},
+			expectedFilter: repository.UserFilter{
+				PhonePrefix:  "+628",
+				NameFragment: "aji",
+				CreatedFrom:  &from,
+				CreatedTo:    &now,
+				Limit:        defaultUserPageSize + 1,
+			},
+			users:       users,
+			expectedIDs: []int64{3, 2, 1},
+		},
+		{
+			name:               "Has Next Page",
+			params:             service.ListUsersParam{Limit: 2},
+			expectedFilter:     repository.UserFilter{Limit: 3},
+			users:              users,
+			expectedIDs:        []int64{3, 2},
+			expectedNextCursor: encodeIDCursor(2),
+		},
+		{
+			name:           "Continue From Cursor",
+			params:         service.ListUsersParam{Cursor: encodeIDCursor(2), Limit: 2},
+			expectedFilter: repository.UserFilter{AfterID: 2, Limit: 3},
+			users:          users[2:],
+			expectedIDs:    []int64{1},
+		},
+		{
+			name:           "Limit Is Capped",
+			params:         service.ListUsersParam{Limit: 1000},
+			expectedFilter: repository.UserFilter{Limit: maxUserPageSize + 1},
+			users:          []*repository.User{},
+			expectedIDs:    []int64{},
+		},
+		{
+			name:          "Invalid Cursor",
+			params:        service.ListUsersParam{Cursor: "not a cursor"},
+			expectedError: commonErr.NewErrorWithCode(commonErr.InvalidCursorErrorMessage, commonErr.BadRequestErrorType, commonErr.InvalidCursorErrorCode),
+		},
+		{
+			name:          "Negative Cursor",
+			params:        service.ListUsersParam{Cursor: encodeIDCursor(-1)},
+			expectedError: commonErr.NewErrorWithCode(commonErr.InvalidCursorErrorMessage, commonErr.BadRequestErrorType, commonErr.InvalidCursorErrorCode),
+		},
+		{
+			name:           "Error DB",
+			expectedFilter: repository.UserFilter{Limit: defaultUserPageSize + 1},
+			repoError:      errors.New("some error"),
+			expectedError:  commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			if tc.users != nil || tc.repoError != nil {
+				mockRepo.EXPECT().ListUsers(gomock.Any(), tc.expectedFilter).Return(tc.users, tc.repoError)
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			response, err := svc.ListUsers(context.Background(), tc.params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+				assert.Equal(t, tc.expectedError.GetErrorCode(), err.GetErrorCode())
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+
+				ids := []int64{}
+				for _, user := range response.Users {
+					ids = append(ids, user.ID)
+				}
+				assert.Equal(t, tc.expectedIDs, ids)
+				assert.Equal(t, tc.expectedNextCursor, response.NextCursor)
+			}
+		})
+	}
+}
+
+func TestToAdminUser(t *testing.T) {
+	now := time.Now()
+	reason := "chargeback fraud"
+
+	adminUser := toAdminUser(&repository.User{
+		ID:                  2,
+		FullName:            "budi santoso",
+		HashedPassword:      "hash",
+		Phone:               "+628111111111",
+		PhoneVerifiedAt:     &now,
+		LoginCount:          4,
+		FailedLoginCount:    5,
+		LockedUntil:         &now,
+		Status:              common.SUSPENDED_STATUS,
+		StatusReason:        &reason,
+		StatusChangedAt:     &now,
+		DeletionScheduledAt: &now,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	})
+
+	assert.Equal(t, service.AdminUser{
+		ID:                  2,
+		FullName:            "budi santoso",
+		PhoneNumber:         "+628111111111",
+		PhoneVerified:       true,
+		LoginCount:          4,
+		FailedLoginCount:    5,
+		LockedUntil:         &now,
+		Status:              common.SUSPENDED_STATUS,
+		StatusReason:        &reason,
+		StatusChangedAt:     &now,
+		DeletionScheduledAt: &now,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}, adminUser)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+)
+
+// LockoutPolicy controls how failed logins are throttled. A zero Threshold
+// disables the per-account lockout and a zero IPThreshold disables the
+// per-IP limit.
+type LockoutPolicy struct {
+	// Threshold is the number of consecutive failed logins after which the
+	// account is locked.
+	Threshold int64
+	// BaseDuration is the first lockout; it doubles with every further
+	// failure, up to MaxDuration.
+	BaseDuration time.Duration
+	MaxDuration  time.Duration
+	// IPThreshold is the number of failed logins from one client IP within
+	// IPWindow after which that IP is refused.
+	IPThreshold int64
+	IPWindow    time.Duration
+}
+
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		Threshold:    5,
+		BaseDuration: time.Minute,
+		MaxDuration:  time.Hour,
+		IPThreshold:  20,
+		IPWindow:     15 * time.Minute,
+	}
+}
+
+// lockoutDuration returns how long to lock an account that has failed
+// failedCount consecutive logins, or zero when it stays unlocked.
+func (p LockoutPolicy) lockoutDuration(failedCount int64) time.Duration {
+	if p.Threshold <= 0 || failedCount < p.Threshold {
+		return 0
+	}
+
+	duration := p.BaseDuration
+	for i := p.Threshold; i < failedCount && duration < p.MaxDuration; i++ {
+		duration *= 2
+	}
+
+	if p.MaxDuration > 0 && duration > p.MaxDuration {
+		return p.MaxDuration
+	}
+
+	return duration
+}
+
+func clientIPFromContext(ctx context.Context) string {
+	return common.RequestInfoFromContext(ctx).IPAddress
+}
+
+// checkLoginAllowed refuses the attempt when the client IP has failed too
+// often recently or when the account is still locked.
+func (s *Service) checkLoginAllowed(ctx context.Context, user *repository.User) common.Error {
+	ip := clientIPFromContext(ctx)
+	if ip != "" && s.Lockout.IPThreshold > 0 {
+		count, err := s.Repository.CountFailedLoginAttemptsSince(ctx, ip, time.Now().Add(-s.Lockout.IPWindow))
+		if err != nil {
+			return errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		if count >= s.Lockout.IPThreshold {
+			return errors.NewErrorWithCode(
+				errors.TooManyLoginAttemptsErrorMessage,
+				errors.TooManyRequestsErrorType,
+				errors.TooManyLoginAttemptsErrorCode)
+		}
+	}
+
+	if user != nil && user.LockedUntil != nil && time.Now().Before(*user.LockedUntil) {
+		return errors.NewErrorWithCode(
+			errors.AccountLockedErrorMessage,
+			errors.LockedErrorType,
+			errors.AccountLockedErrorCode)
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts the failure against the client IP, then logs it
+// for the user and locks the account once the policy threshold is reached.
+// userID is zero when the phone number belongs to no account, so that
+// guessing numbers is throttled like guessing passwords.
+func (s *Service) recordFailedLogin(ctx context.Context, userID int64) error {
+	ip := clientIPFromContext(ctx)
+	if ip == "" && userID == 0 {
+		return nil
+	}
+
+	return s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		if ip != "" {
+			err := s.Repository.InsertFailedLoginAttempt(ctx, ip)
+			if err != nil {
+				return err
+			}
+		}
+
+		if userID == 0 {
+			return nil
+		}
+
+		err := s.Repository.InsertUserActivityLog(ctx, userID, common.LOGIN_FAILED_ACTIVITY)
+		if err != nil {
+			return err
+		}
+
+		failedCount, err := s.Repository.IncrementFailedLoginCount(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		duration := s.Lockout.lockoutDuration(failedCount)
+		if duration == 0 {
+			return nil
+		}
+
+		return s.Repository.LockUser(ctx, userID, time.Now().Add(duration))
+	})
+}
+
+// UnlockAccount clears a lockout and the failed login counter, for support
+// staff helping a user who is locked out.
+func (s *Service) UnlockAccount(ctx context.Context, userID int64) common.Error {
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.NotFoundErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.ClearLoginLockout(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, userID, common.ACCOUNT_UNLOCKED_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	policy := DefaultLockoutPolicy()
+
+	testCases := []struct {
+		name        string
+		policy      LockoutPolicy
+		failedCount int64
+		expected    time.Duration
+	}{
+		{
+			name:        "Below Threshold",
+			policy:      policy,
+			failedCount: 4,
+			expected:    0,
+		},
+		{
+			name:        "At Threshold",
+			policy:      policy,
+			failedCount: 5,
+			expected:    time.Minute,
+		},
+		{
+			name:        "Doubles After Threshold",
+			policy:      policy,
+			failedCount: 7,
+			expected:    4 * time.Minute,
+		},
+		{
+			name:        "Capped At Max Duration",
+			policy:      policy,
+			failedCount: 100,
+			expected:    time.Hour,
+		},
+		{
+			name:        "Disabled",
+			policy:      LockoutPolicy{},
+			failedCount: 100,
+			expected:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.lockoutDuration(tc.failedCount))
+		})
+	}
+}
+
+func TestUnlockAccount(t *testing.T) {
+	user := &repository.User{ID: 1, FailedLoginCount: 5}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Unlock",
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By ID",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Clear Lockout Error",
+			expectedError: commonErr.NewError("clear error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Unlock":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ClearLoginLockout(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.ACCOUNT_UNLOCKED_ACTIVITY).Return(nil)
+			case "Error DB - Get User By ID":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(nil, errors.New("some error"))
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(nil, nil)
+			case "Clear Lockout Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ClearLoginLockout(gomock.Any(), user.ID).Return(errors.New("clear error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			err := svc.UnlockAccount(context.Background(), user.ID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sawitpro/UserService/common"
 	"github.com/sawitpro/UserService/common/errors"
 	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/repository"
 	"github.com/sawitpro/UserService/service"
 )
 
@@ -19,48 +20,181 @@ func (s *Service) Login(ctx context.Context, params service.LoginParam) (*servic
 			errors.SystemErrorType)
 	}
 
+	errSvc := s.checkLoginAllowed(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
 	if user == nil {
-		return nil, errors.NewError(
+		err = s.recordFailedLogin(ctx, 0)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		return nil, errors.NewErrorWithCode(
 			errors.WrongPhonePasswordErrorMessage,
-			errors.BadRequestErrorType)
+			errors.BadRequestErrorType,
+			errors.WrongPhonePasswordErrorCode)
 	}
 
 	err = s.Hasher.CompareHashAndPassword([]byte(user.HashedPassword), []byte(params.Password))
 	if err != nil {
-		return nil, errors.NewError(
+		err = s.recordFailedLogin(ctx, user.ID)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		return nil, errors.NewErrorWithCode(
 			errors.WrongPhonePasswordErrorMessage,
-			errors.BadRequestErrorType)
+			errors.BadRequestErrorType,
+			errors.WrongPhonePasswordErrorCode)
 	}
 
-	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
-		err = s.Repository.InsertUserActivityLog(ctx, user.ID, common.LOGIN_ACTIVITY)
+	// The status is only revealed to callers who know the password.
+	errSvc = s.admitLogin(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	// Migrate hashes made with an outdated algorithm or cost while the
+	// plaintext password is at hand. A failure only delays the migration to
+	// the next login, so it does not fail this one.
+	if s.Hasher.NeedsRehash([]byte(user.HashedPassword)) {
+		hashedPassword, err := s.Hasher.HashPassword(params.Password)
+		if err == nil {
+			_ = s.Repository.UpdateUserPassword(ctx, user.ID, hashedPassword)
+		}
+	}
+
+	return s.startLogin(ctx, user)
+}
+
+// admitLogin lets a user who has proved who they are log in if their account
+// is active. Logging in during the grace period takes a scheduled deletion
+// back.
+func (s *Service) admitLogin(ctx context.Context, user *repository.User) common.Error {
+	if user.Status == common.PENDING_DELETION_STATUS && user.DeletionScheduledAt != nil && time.Now().Before(*user.DeletionScheduledAt) {
+		err := s.cancelUserDeletion(ctx, user.ID)
 		if err != nil {
-			return err
+			return errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
 		}
 
-		err = s.Repository.IncrementLoginCount(ctx, user.ID)
+		user.Status = common.ACTIVE_STATUS
+	}
+
+	return checkAccountActive(user)
+}
+
+// clearLoginLockout resets the failed login counter and lockout once the
+// user has passed every factor, so a correct password alone does not wipe
+// out wrong second factor codes.
+func (s *Service) clearLoginLockout(ctx context.Context, user *repository.User) common.Error {
+	if user.FailedLoginCount > 0 || user.LockedUntil != nil {
+		err := s.Repository.ClearLoginLockout(ctx, user.ID)
 		if err != nil {
-			return err
+			return errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
 		}
+	}
 
-		return nil
-	})
+	return nil
+}
 
+// startLogin completes the login of an admitted user, or with two-factor
+// authentication enabled only returns a challenge token, exchanged for real
+// tokens by VerifyMFALogin.
+func (s *Service) startLogin(ctx context.Context, user *repository.User) (*service.LoginResponse, common.Error) {
+	totp, err := s.Repository.GetUserTOTP(ctx, user.ID)
 	if err != nil {
 		return nil, errors.NewError(
 			err.Error(),
 			errors.SystemErrorType)
 	}
 
-	token, err := helper.CreateToken(user.ID, time.Duration(6)*time.Hour)
+	if totp != nil && totp.ConfirmedAt != nil {
+		mfaToken, err := helper.CreateMFAToken(user.ID, mfaTokenDuration)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		return &service.LoginResponse{
+			UserID:      user.ID,
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}, nil
+	}
+
+	errSvc := s.clearLoginLockout(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	return s.completeLogin(ctx, user.ID)
+}
+
+// completeLogin records a successful login, starts a session and issues the
+// token pair once every required factor has been checked. The session limit
+// may evict older sessions or reject the login, and a login unlike the
+// user's recent ones is flagged and notified to the user.
+func (s *Service) completeLogin(ctx context.Context, userID int64) (*service.LoginResponse, common.Error) {
+	evicted, errSvc := s.checkSessionLimit(ctx, userID)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	suspicious, err := s.isSuspiciousLogin(ctx, userID)
 	if err != nil {
 		return nil, errors.NewError(
 			err.Error(),
 			errors.SystemErrorType)
 	}
 
-	return &service.LoginResponse{
-		UserID: user.ID,
-		Token:  token,
-	}, nil
+	var resp *service.LoginResponse
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.InsertUserActivityLog(ctx, userID, common.LOGIN_ACTIVITY)
+		if err != nil {
+			return err
+		}
+
+		if suspicious {
+			err = s.Repository.InsertUserActivityLog(ctx, userID, common.SUSPICIOUS_LOGIN_ACTIVITY)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = s.Repository.IncrementLoginCount(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.evictSessions(ctx, userID, evicted)
+		if err != nil {
+			return err
+		}
+
+		resp, err = s.issueTokens(ctx, userID, 0, "")
+		return err
+	})
+
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if suspicious {
+		s.notifySuspiciousLogin(ctx, userID)
+	}
+
+	return resp, nil
 }
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/repository"
+)
+
+const suspiciousLoginMessageFormat = "New login to your account from %s at %s. If this was not you, reset your password and log out of your other sessions."
+
+// LoginAnomalyPolicy scores a successful login against the user's recent
+// logins. Every rule that finds the login unusual adds its score, and a login
+// scoring at least Threshold is suspicious. A zero HistorySize or Threshold
+// disables the detection.
+type LoginAnomalyPolicy struct {
+	// HistorySize is how many recent logins a new one is compared with.
+	HistorySize int
+	// MinHistory is how many earlier logins a user needs before a login can
+	// be flagged, so the first logins of a new account are not.
+	MinHistory int
+	// NewNetworkScore is added when the client IP is outside every network
+	// seen before, networks being IPv4 and IPv6 prefixes of the given lengths.
+	NewNetworkScore int
+	IPv4PrefixBits  int
+	IPv6PrefixBits  int
+	// NewDeviceScore is added when the device and OS families parsed from
+	// the User-Agent were never seen together before.
+	NewDeviceScore int
+	// UnusualHourScore is added when the login is more than HourTolerance
+	// hours away from the time of day of every earlier login.
+	UnusualHourScore int
+	HourTolerance    int
+	Threshold        int
+}
+
+func DefaultLoginAnomalyPolicy() LoginAnomalyPolicy {
+	return LoginAnomalyPolicy{
+		HistorySize:      20,
+		MinHistory:       3,
+		NewNetworkScore:  1,
+		IPv4PrefixBits:   24,
+		IPv6PrefixBits:   48,
+		NewDeviceScore:   2,
+		UnusualHourScore: 1,
+		HourTolerance:    2,
+		Threshold:        2,
+	}
+}
+
+func (p LoginAnomalyPolicy) enabled() bool {
+	return p.HistorySize > 0 && p.Threshold > 0
+}
+
+// score rates a login made at the given time by the client described by
+// info, against history, the user's earlier logins. Rules that lack the data
+// to compare, such as logins recorded without an IP, add nothing.
+func (p LoginAnomalyPolicy) score(history []*repository.UserActivityLog, info common.RequestInfo, at time.Time) int {
+	if len(history) == 0 || len(history) < p.MinHistory {
+		return 0
+	}
+
+	score := 0
+
+	if network := p.network(info.IPAddress); network != "" {
+		known, seen := false, false
+		for _, log := range history {
+			if log.IPAddress == nil {
+				continue
+			}
+			known = true
+			if p.network(*log.IPAddress) == network {
+				seen = true
+				break
+			}
+		}
+		if known && !seen {
+			score += p.NewNetworkScore
+		}
+	}
+
+	if info.DeviceFamily != "" && info.OSFamily != "" {
+		known, seen := false, false
+		for _, log := range history {
+			if log.DeviceFamily == nil || log.OSFamily == nil {
+				continue
+			}
+			known = true
+			if *log.DeviceFamily == info.DeviceFamily && *log.OSFamily == info.OSFamily {
+				seen = true
+				break
+			}
+		}
+		if known && !seen {
+			score += p.NewDeviceScore
+		}
+	}
+
+	usual := false
+	for _, log := range history {
+		if hourDistance(log.CreatedAt, at) <= p.HourTolerance {
+			usual = true
+			break
+		}
+	}
+	if !usual {
+		score += p.UnusualHourScore
+	}
+
+	return score
+}
+
+// suspicious reports whether a login with the given score is flagged.
+func (p LoginAnomalyPolicy) suspicious(score int) bool {
+	return p.enabled() && score >= p.Threshold
+}
+
+// network returns the prefix of address the policy compares, or an empty
+// string when address is not an IP.
+func (p LoginAnomalyPolicy) network(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(p.IPv4PrefixBits, 32)).String()
+	}
+
+	return ip.Mask(net.CIDRMask(p.IPv6PrefixBits, 128)).String()
+}
+
+// hourDistance returns how many hours apart the times of day of a and b are
+// in the local time zone, wrapping around midnight.
+func hourDistance(a, b time.Time) int {
+	distance := a.Local().Hour() - b.Local().Hour()
+	if distance < 0 {
+		distance = -distance
+	}
+	if distance > 12 {
+		distance = 24 - distance
+	}
+
+	return distance
+}
+
+// isSuspiciousLogin scores a login of the user by the client in ctx against
+// their recent logins. It must run before the login itself is recorded.
+func (s *Service) isSuspiciousLogin(ctx context.Context, userID int64) (bool, error) {
+	if !s.LoginAnomaly.enabled() {
+		return false, nil
+	}
+
+	history, err := s.Repository.ListUserActivityLogs(ctx, repository.UserActivityLogFilter{
+		UserID:       userID,
+		ActivityType: common.LOGIN_ACTIVITY,
+		Limit:        s.LoginAnomaly.HistorySize,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	score := s.LoginAnomaly.score(history, common.RequestInfoFromContext(ctx), time.Now())
+
+	return s.LoginAnomaly.suspicious(score), nil
+}
+
+// notifySuspiciousLogin warns the user about a suspicious login on their
+// phone. The login has already succeeded, so failures are ignored.
+func (s *Service) notifySuspiciousLogin(ctx context.Context, userID int64) {
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil || user == nil || user.Phone == "" {
+		return
+	}
+
+	device := deviceName(common.RequestInfoFromContext(ctx))
+	_ = s.Notifier.Send(ctx, user.Phone, fmt.Sprintf(suspiciousLoginMessageFormat, device, time.Now().Format("2 Jan 2006 15:04")))
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+)
+
+// loginHistoryFixture is a user who logs in from their iPhone, on one home
+// network, in the morning and in the evening.
+func loginHistoryFixture() []*repository.UserActivityLog {
+	login := func(ip, device, os string, hour int) *repository.UserActivityLog {
+		return &repository.UserActivityLog{
+			UserID:       1,
+			ActivityType: common.LOGIN_ACTIVITY,
+			IPAddress:    &ip,
+			DeviceFamily: &device,
+			OSFamily:     &os,
+			CreatedAt:    time.Date(2024, 1, 1, hour, 0, 0, 0, time.Local),
+		}
+	}
+
+	return []*repository.UserActivityLog{
+		login("203.0.113.7", "mobile", "iOS", 8),
+		login("203.0.113.20", "mobile", "iOS", 9),
+		login("2001:db8:1::5", "mobile", "iOS", 19),
+		login("203.0.113.7", "mobile", "iOS", 23),
+	}
+}
+
+func TestLoginAnomalyScore(t *testing.T) {
+	policy := DefaultLoginAnomalyPolicy()
+	history := loginHistoryFixture()
+	at := func(hour int) time.Time {
+		return time.Date(2024, 2, 1, hour, 30, 0, 0, time.Local)
+	}
+	iPhone := func(ip string) common.RequestInfo {
+		return common.RequestInfo{IPAddress: ip, DeviceFamily: "mobile", OSFamily: "iOS"}
+	}
+
+	testCases := []struct {
+		name               string
+		history            []*repository.UserActivityLog
+		info               common.RequestInfo
+		at                 time.Time
+		expectedScore      int
+		expectedSuspicious bool
+	}{
+		{
+			name:          "Familiar Login",
+			history:       history,
+			info:          iPhone("203.0.113.99"),
+			at:            at(10),
+			expectedScore: 0,
+		},
+		{
+			name:          "Same IPv6 Network",
+			history:       history,
+			info:          iPhone("2001:db8:1:ff::9"),
+			at:            at(20),
+			expectedScore: 0,
+		},
+		{
+			name:          "New Network",
+			history:       history,
+			info:          iPhone("198.51.100.1"),
+			at:            at(9),
+			expectedScore: 1,
+		},
+		{
+			name:               "New Device",
+			history:            history,
+			info:               common.RequestInfo{IPAddress: "203.0.113.7", DeviceFamily: "mobile", OSFamily: "Android"},
+			at:                 at(9),
+			expectedScore:      2,
+			expectedSuspicious: true,
+		},
+		{
+			name:          "Unusual Hour",
+			history:       history,
+			info:          iPhone("203.0.113.7"),
+			at:            at(3),
+			expectedScore: 1,
+		},
+		{
+			name:          "Hour Wraps Around Midnight",
+			history:       history,
+			info:          iPhone("203.0.113.7"),
+			at:            at(0),
+			expectedScore: 0,
+		},
+		{
+			name:               "Every Rule",
+			history:            history,
+			info:               common.RequestInfo{IPAddress: "198.51.100.1", DeviceFamily: "desktop", OSFamily: "Windows"},
+			at:                 at(3),
+			expectedScore:      4,
+			expectedSuspicious: true,
+		},
+		{
+			name:          "Too Little History",
+			history:       history[:2],
+			info:          common.RequestInfo{IPAddress: "198.51.100.1", DeviceFamily: "desktop", OSFamily: "Windows"},
+			at:            at(3),
+			expectedScore: 0,
+		},
+		{
+			name: "History Without Client Data",
+			history: []*repository.UserActivityLog{
+				{CreatedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)},
+				{CreatedAt: time.Date(2024, 1, 2, 9, 0, 0, 0, time.Local)},
+				{CreatedAt: time.Date(2024, 1, 3, 9, 0, 0, 0, time.Local)},
+			},
+			info:          common.RequestInfo{IPAddress: "198.51.100.1", DeviceFamily: "desktop", OSFamily: "Windows"},
+			at:            at(9),
+			expectedScore: 0,
+		},
+		{
+			name:          "Unknown Client",
+			history:       history,
+			info:          common.RequestInfo{},
+			at:            at(9),
+			expectedScore: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score := policy.score(tc.history, tc.info, tc.at)
+
+			assert.Equal(t, tc.expectedScore, score)
+			assert.Equal(t, tc.expectedSuspicious, policy.suspicious(score))
+		})
+	}
+}
+
+func TestCompleteLoginSuspiciousLogin(t *testing.T) {
+	user := &repository.User{ID: 1, Phone: "+628123456789"}
+	newDevice := common.RequestInfo{IPAddress: "198.51.100.1", DeviceFamily: "desktop", OSFamily: "Windows"}
+	familiar := common.RequestInfo{IPAddress: "203.0.113.7", DeviceFamily: "mobile", OSFamily: "iOS"}
+
+	testCases := []struct {
+		name          string
+		info          common.RequestInfo
+		expectedError common.Error
+	}{
+		{
+			name: "Suspicious Login Is Notified",
+			info: newDevice,
+		},
+		{
+			name: "Familiar Login",
+			info: familiar,
+		},
+		{
+			name: "Notify Error Does Not Fail Login",
+			info: newDevice,
+		},
+		{
+			name:          "List History Error",
+			info:          newDevice,
+			expectedError: commonErr.NewError("list error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Insert Suspicious Login Error",
+			info:          newDevice,
+			expectedError: commonErr.NewError("insert error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockNotifier := mocks.NewMockNotifier(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+			expectLogin := func() {
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.LOGIN_ACTIVITY).Return(nil)
+			}
+			expectTokens := func() {
+				mockRepo.EXPECT().IncrementLoginCount(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 2, UserID: user.ID}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(&repository.RefreshToken{ID: 1}, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), user.ID).Return(&repository.UserAccess{}, nil)
+			}
+
+			filter := repository.UserActivityLogFilter{UserID: user.ID, ActivityType: common.LOGIN_ACTIVITY, Limit: 20}
+			if tc.name == "List History Error" {
+				mockRepo.EXPECT().ListUserActivityLogs(gomock.Any(), filter).Return(nil, errors.New("list error"))
+			} else {
+				mockRepo.EXPECT().ListUserActivityLogs(gomock.Any(), filter).Return(loginHistoryFixture(), nil)
+			}
+
+			switch tc.name {
+			case "Suspicious Login Is Notified":
+				expectLogin()
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.SUSPICIOUS_LOGIN_ACTIVITY).Return(nil)
+				expectTokens()
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockNotifier.EXPECT().Send(gomock.Any(), user.Phone, gomock.Any()).DoAndReturn(func(ctx context.Context, phoneNumber, message string) error {
+					assert.Contains(t, message, "Windows desktop")
+					return nil
+				})
+			case "Familiar Login":
+				expectLogin()
+				expectTokens()
+			case "Notify Error Does Not Fail Login":
+				expectLogin()
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.SUSPICIOUS_LOGIN_ACTIVITY).Return(nil)
+				expectTokens()
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockNotifier.EXPECT().Send(gomock.Any(), user.Phone, gomock.Any()).Return(errors.New("send error"))
+			case "Insert Suspicious Login Error":
+				expectLogin()
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.SUSPICIOUS_LOGIN_ACTIVITY).Return(errors.New("insert error"))
+			}
+
+			svc := &Service{
+				Repository:   mockRepo,
+				Notifier:     mockNotifier,
+				LoginAnomaly: DefaultLoginAnomalyPolicy(),
+			}
+
+			ctx := context.WithValue(context.Background(), common.REQUEST_INFO_CTX_KEY, tc.info)
+			response, err := svc.completeLogin(ctx, user.ID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.NotEmpty(t, response.Token)
+			}
+		})
+	}
+}
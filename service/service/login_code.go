@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+const loginCodeMessageFormat = "Your login code is %s. It expires in 10 minutes. Do not share it with anyone."
+
+// RequestLoginCode sends a one-time login code to the phone number, as an
+// alternative to the password. Unknown numbers and rate limited requests
+// succeed silently so that the endpoint cannot be used to find out which
+// numbers are registered.
+func (s *Service) RequestLoginCode(ctx context.Context, params service.RequestLoginCodeParam) common.Error {
+
+	user, err := s.Repository.GetUserByPhone(ctx, params.PhoneNumber)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	sent, err := s.sendVerificationCode(ctx, user.ID, common.LOGIN_PURPOSE, user.Phone, loginCodeMessageFormat)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !sent {
+		return nil
+	}
+
+	err = s.Repository.InsertUserActivityLog(ctx, user.ID, common.LOGIN_CODE_REQUEST_ACTIVITY)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
+
+// VerifyLoginCode logs the user in with a code sent by RequestLoginCode
+// instead of their password. The code can only be used once, and the login
+// then goes on exactly like a password login. Wrong codes and unknown numbers
+// are failed logins, counted towards the account lockout and the client IP
+// throttle.
+func (s *Service) VerifyLoginCode(ctx context.Context, params service.VerifyLoginCodeParam) (*service.LoginResponse, common.Error) {
+
+	user, err := s.Repository.GetUserByPhone(ctx, params.PhoneNumber)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	errSvc := s.checkLoginAllowed(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	if user == nil {
+		err = s.recordFailedLogin(ctx, 0)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidVerificationCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidVerificationCodeErrorCode)
+	}
+
+	code, errSvc := s.checkVerificationCode(ctx, user.ID, common.LOGIN_PURPOSE, user.Phone, params.Code)
+	if errSvc != nil {
+		if errSvc.GetErrorType() != errors.SystemErrorType {
+			err = s.recordFailedLogin(ctx, user.ID)
+			if err != nil {
+				return nil, errors.NewError(
+					err.Error(),
+					errors.SystemErrorType)
+			}
+		}
+
+		return nil, errSvc
+	}
+
+	consumed, err := s.Repository.ConsumeVerificationCode(ctx, code.ID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	// A concurrent request used the same code first.
+	if !consumed {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidVerificationCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidVerificationCodeErrorCode)
+	}
+
+	// The status is only revealed to callers who know the code.
+	errSvc = s.admitLogin(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	return s.startLogin(ctx, user)
+}
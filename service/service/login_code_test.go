@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestRequestLoginCode(t *testing.T) {
+	params := service.RequestLoginCodeParam{PhoneNumber: "+628123456789"}
+	user := &repository.User{ID: 1, Phone: "+628123456789"}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Request",
+			expectedError: nil,
+		},
+		{
+			name:          "Unknown Phone Number",
+			expectedError: nil,
+		},
+		{
+			name:          "Rate Limited",
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By Phone",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Send Error",
+			expectedError: commonErr.NewError("send error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			mockNotifier := mocks.NewMockNotifier(ctrl)
+
+			switch tc.name {
+			case "Successful Request":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), user.ID, common.LOGIN_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, code *repository.VerificationCode) (*repository.VerificationCode, error) {
+						assert.Equal(t, user.ID, code.UserID)
+						assert.Equal(t, common.LOGIN_PURPOSE, code.Purpose)
+						assert.Equal(t, user.Phone, code.Target)
+						assert.Equal(t, "code hash", code.CodeHash)
+						return code, nil
+					})
+				mockNotifier.EXPECT().Send(gomock.Any(), user.Phone, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.LOGIN_CODE_REQUEST_ACTIVITY).Return(nil)
+			case "Unknown Phone Number":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, nil)
+			case "Rate Limited":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), user.ID, common.LOGIN_PURPOSE, gomock.Any()).Return(int64(verificationCodeMaxPerHour), nil)
+			case "Error DB - Get User By Phone":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, errors.New("some error"))
+			case "Send Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), user.ID, common.LOGIN_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).Return(&repository.VerificationCode{ID: 1}, nil)
+				mockNotifier.EXPECT().Send(gomock.Any(), user.Phone, gomock.Any()).Return(errors.New("send error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+				Hasher:     mockHasher,
+				Notifier:   mockNotifier,
+			})
+
+			err := svc.RequestLoginCode(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyLoginCode(t *testing.T) {
+	params := service.VerifyLoginCodeParam{PhoneNumber: "+628123456789", Code: "123456"}
+	user := &repository.User{ID: 1, Phone: "+628123456789", Status: common.ACTIVE_STATUS}
+	now := time.Now()
+	code := &repository.VerificationCode{
+		ID:        10,
+		UserID:    1,
+		Purpose:   common.LOGIN_PURPOSE,
+		Target:    "+628123456789",
+		CodeHash:  "code hash",
+		ExpiresAt: now.Add(time.Minute),
+	}
+
+	lockedUntil := now.Add(time.Minute)
+
+	testCases := []struct {
+		name          string
+		clientIP      string
+		user          *repository.User
+		expectedMFA   bool
+		expectedError common.Error
+	}{
+		{
+			name: "Successful Login",
+			user: user,
+		},
+		{
+			name: "Login Clears Lockout",
+			user: &repository.User{ID: 1, Phone: "+628123456789", Status: common.ACTIVE_STATUS, FailedLoginCount: 5},
+		},
+		{
+			name:        "MFA Required",
+			user:        user,
+			expectedMFA: true,
+		},
+		{
+			name:          "Error DB - Get User By Phone",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Unknown Phone Number",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Unknown Phone Number Counts Against IP",
+			clientIP:      "10.0.0.1",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Wrong Code",
+			user:          user,
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Wrong Code Locks Account",
+			clientIP:      "10.0.0.1",
+			user:          &repository.User{ID: 1, Phone: "+628123456789", Status: common.ACTIVE_STATUS, FailedLoginCount: 4},
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Too Many Attempts Counts As Failed Login",
+			user:          user,
+			expectedError: commonErr.NewError(commonErr.TooManyVerificationAttemptsErrorMessage, commonErr.TooManyRequestsErrorType),
+		},
+		{
+			name:          "Record Failed Login Error",
+			user:          user,
+			expectedError: commonErr.NewError("record error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Account Locked",
+			user:          &repository.User{ID: 1, Phone: "+628123456789", Status: common.ACTIVE_STATUS, FailedLoginCount: 5, LockedUntil: &lockedUntil},
+			expectedError: commonErr.NewError(commonErr.AccountLockedErrorMessage, commonErr.LockedErrorType),
+		},
+		{
+			name:          "Too Many Failed Logins From IP",
+			clientIP:      "10.0.0.1",
+			expectedError: commonErr.NewError(commonErr.TooManyLoginAttemptsErrorMessage, commonErr.TooManyRequestsErrorType),
+		},
+		{
+			name:          "Code Consumed Concurrently",
+			user:          user,
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Suspended Account",
+			user:          &repository.User{ID: 1, Phone: "+628123456789", Status: common.SUSPENDED_STATUS},
+			expectedError: commonErr.NewError(commonErr.AccountSuspendedErrorMessage, commonErr.ForbiddenErrorType),
+		},
+		{
+			name:          "Insert User Activity Log Error",
+			user:          user,
+			expectedError: commonErr.NewError("insert error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+			expectValidCode := func() {
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), tc.user.ID, common.LOGIN_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(code.CodeHash), []byte(params.Code)).Return(nil)
+			}
+			expectLogin := func() {
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementLoginCount(gomock.Any(), tc.user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 2, UserID: tc.user.ID}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(&repository.RefreshToken{ID: 1}, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), tc.user.ID).Return(&repository.UserAccess{}, nil)
+			}
+
+			switch tc.name {
+			case "Successful Login":
+				expectValidCode()
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, nil)
+				expectLogin()
+			case "Login Clears Lockout":
+				expectValidCode()
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().ClearLoginLockout(gomock.Any(), tc.user.ID).Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, nil)
+				expectLogin()
+			case "MFA Required":
+				confirmedAt := now
+				expectValidCode()
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(&repository.UserTOTP{UserID: tc.user.ID, ConfirmedAt: &confirmedAt}, nil)
+			case "Error DB - Get User By Phone":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, errors.New("some error"))
+			case "Unknown Phone Number":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, nil)
+			case "Wrong Code":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), tc.user.ID, common.LOGIN_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(code.CodeHash), []byte(params.Code)).Return(errors.New("mismatch"))
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_FAILED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementFailedLoginCount(gomock.Any(), tc.user.ID).Return(int64(1), nil)
+			case "Unknown Phone Number Counts Against IP":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(3), nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertFailedLoginAttempt(gomock.Any(), tc.clientIP).Return(nil)
+			case "Wrong Code Locks Account":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(4), nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), tc.user.ID, common.LOGIN_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(code.CodeHash), []byte(params.Code)).Return(errors.New("mismatch"))
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertFailedLoginAttempt(gomock.Any(), tc.clientIP).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_FAILED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementFailedLoginCount(gomock.Any(), tc.user.ID).Return(int64(5), nil)
+				mockRepo.EXPECT().LockUser(gomock.Any(), tc.user.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, lockedUntil time.Time) error {
+					assert.WithinDuration(t, time.Now().Add(time.Minute), lockedUntil, time.Second)
+					return nil
+				})
+			case "Too Many Attempts Counts As Failed Login":
+				exhausted := *code
+				exhausted.Attempts = 5
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), tc.user.ID, common.LOGIN_PURPOSE).Return(&exhausted, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), exhausted.ID, verificationCodeMaxAttempts).Return(false, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_FAILED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementFailedLoginCount(gomock.Any(), tc.user.ID).Return(int64(1), nil)
+			case "Record Failed Login Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), tc.user.ID, common.LOGIN_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(code.CodeHash), []byte(params.Code)).Return(errors.New("mismatch"))
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_FAILED_ACTIVITY).Return(errors.New("record error"))
+			case "Account Locked":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(tc.user, nil)
+			case "Too Many Failed Logins From IP":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(20), nil)
+			case "Code Consumed Concurrently":
+				expectValidCode()
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(false, nil)
+			case "Suspended Account":
+				expectValidCode()
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+			case "Insert User Activity Log Error":
+				expectValidCode()
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_ACTIVITY).Return(errors.New("insert error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+				Hasher:     mockHasher,
+				Lockout:    DefaultLockoutPolicy(),
+			})
+
+			ctx := context.Background()
+			if tc.clientIP != "" {
+				ctx = context.WithValue(ctx, common.REQUEST_INFO_CTX_KEY, common.RequestInfo{IPAddress: tc.clientIP})
+			}
+
+			response, err := svc.VerifyLoginCode(ctx, params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.user.ID, response.UserID)
+				assert.Equal(t, tc.expectedMFA, response.MFARequired)
+				if tc.expectedMFA {
+					assert.NotEmpty(t, response.MFAToken)
+				} else {
+					assert.NotEmpty(t, response.Token)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -16,19 +17,25 @@ import (
 )
 
 func TestLogin(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Minute)
+	lockExpired := time.Now().Add(-time.Minute)
+	deleteAfter := time.Now().Add(24 * time.Hour)
+
 	testCases := []struct {
 		name          string
 		phoneNumber   string
 		password      string
+		clientIP      string
 		user          *repository.User
 		expectedError common.Error
 		expectedToken string
+		expectMFA     bool
 	}{
 		{
 			name:          "Successful Login",
 			phoneNumber:   "+628232482440",
 			password:      "@Maulana",
-			user:          &repository.User{ID: 1},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
 			expectedError: nil,
 			expectedToken: "mocked_token",
 		},
@@ -42,27 +49,154 @@ func TestLogin(t *testing.T) {
 			name:          "Wrong Phone or Password",
 			phoneNumber:   "+628232482440",
 			password:      "@Maulana",
-			expectedError: commonErr.NewError(errors.New(commonErr.WrongPhonePasswordErrorMessage).Error(), commonErr.BadRequestErrorType),
+			expectedError: commonErr.NewErrorWithCode(errors.New(commonErr.WrongPhonePasswordErrorMessage).Error(), commonErr.BadRequestErrorType, commonErr.WrongPhonePasswordErrorCode),
+		},
+		{
+			name:          "Unknown Phone Number Counts Against IP",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			clientIP:      "10.0.0.1",
+			expectedError: commonErr.NewErrorWithCode(commonErr.WrongPhonePasswordErrorMessage, commonErr.BadRequestErrorType, commonErr.WrongPhonePasswordErrorCode),
+		},
+		{
+			name:          "Record Unknown Phone Number Error",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			clientIP:      "10.0.0.1",
+			expectedError: commonErr.NewError("Record Unknown Phone Number Error", commonErr.SystemErrorType),
 		},
 		{
 			name:          "Wrong Password",
 			phoneNumber:   "+628232482440",
 			password:      "@Maulana",
-			user:          &repository.User{ID: 1},
-			expectedError: commonErr.NewError("password or phone number is incorrect.", commonErr.BadRequestErrorType),
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewErrorWithCode("password or phone number is incorrect.", commonErr.BadRequestErrorType, commonErr.WrongPhonePasswordErrorCode),
 		},
 		{
 			name:          "Insert User Activity Log Error",
 			phoneNumber:   "+628232482440",
 			password:      "@Maulana",
-			user:          &repository.User{ID: 1},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
 			expectedError: commonErr.NewError("Insert User Activity Log Error", commonErr.SystemErrorType),
 		},
+		{
+			name:          "Insert Refresh Token Error",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("Insert Refresh Token Error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "MFA Required",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: nil,
+			expectMFA:     true,
+		},
+		{
+			name:          "Get User TOTP Error",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("Get User TOTP Error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Wrong Password Locks Account",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			clientIP:      "10.0.0.1",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, FailedLoginCount: 4},
+			expectedError: commonErr.NewErrorWithCode(commonErr.WrongPhonePasswordErrorMessage, commonErr.BadRequestErrorType, commonErr.WrongPhonePasswordErrorCode),
+		},
+		{
+			name:          "Record Failed Login Error",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("Record Failed Login Error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Account Locked",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, FailedLoginCount: 5, LockedUntil: &lockedUntil},
+			expectedError: commonErr.NewErrorWithCode(commonErr.AccountLockedErrorMessage, commonErr.LockedErrorType, commonErr.AccountLockedErrorCode),
+		},
+		{
+			name:          "Expired Lockout Kept Until Second Factor",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, FailedLoginCount: 5, LockedUntil: &lockExpired},
+			expectedError: nil,
+			expectMFA:     true,
+		},
+		{
+			name:          "Too Many Failed Logins From IP",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			clientIP:      "10.0.0.1",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewErrorWithCode(commonErr.TooManyLoginAttemptsErrorMessage, commonErr.TooManyRequestsErrorType, commonErr.TooManyLoginAttemptsErrorCode),
+		},
+		{
+			name:          "Count Failed Logins Error",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			clientIP:      "10.0.0.1",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("Count Failed Logins Error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Rehash Outdated Password",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, HashedPassword: "$2a$10$outdated"},
+			expectedError: nil,
+			expectMFA:     true,
+		},
+		{
+			name:          "Rehash Error Does Not Fail Login",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, HashedPassword: "$2a$10$outdated"},
+			expectedError: nil,
+			expectMFA:     true,
+		},
+		{
+			name:          "Suspended Account",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.SUSPENDED_STATUS},
+			expectedError: commonErr.NewErrorWithCode(commonErr.AccountSuspendedErrorMessage, commonErr.ForbiddenErrorType, commonErr.AccountSuspendedErrorCode),
+		},
+		{
+			name:          "Deactivated Account",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.DEACTIVATED_STATUS},
+			expectedError: commonErr.NewErrorWithCode(commonErr.AccountDeactivatedErrorMessage, commonErr.ForbiddenErrorType, commonErr.AccountDeactivatedErrorCode),
+		},
+		{
+			name:          "Login Cancels Scheduled Deletion",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.PENDING_DELETION_STATUS, DeletionScheduledAt: &deleteAfter},
+			expectedError: nil,
+			expectMFA:     true,
+		},
+		{
+			name:          "Deletion Grace Period Ended",
+			phoneNumber:   "+628232482440",
+			password:      "@Maulana",
+			user:          &repository.User{ID: 1, Status: common.PENDING_DELETION_STATUS, DeletionScheduledAt: &lockExpired},
+			expectedError: commonErr.NewErrorWithCode(commonErr.AccountPendingDeletionErrorMessage, commonErr.ForbiddenErrorType, commonErr.AccountPendingDeletionErrorCode),
+		},
 		{
 			name:          "Increment Login Count Error",
 			phoneNumber:   "+628232482440",
 			password:      "@Maulana",
-			user:          &repository.User{ID: 1},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
 			expectedError: commonErr.NewError("Increment Login Count Error", commonErr.SystemErrorType),
 		},
 	}
@@ -76,10 +210,16 @@ func TestLogin(t *testing.T) {
 
 			mockHasher := mocks.NewMockPasswordHasher(ctrl)
 
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
 			switch tc.name {
 			case "Successful Login":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), gomock.Any()).Return(tc.user, nil)
 				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, nil)
 				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 					// Simulate successful execution of transaction function
 					if err := fn(ctx); err != nil {
@@ -97,16 +237,100 @@ func TestLogin(t *testing.T) {
 				}).Times(1)
 				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_ACTIVITY).Return(nil).MinTimes(1)
 				mockRepo.EXPECT().IncrementLoginCount(gomock.Any(), tc.user.ID).Return(nil).MinTimes(1)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, session *repository.UserSession) (*repository.UserSession, error) {
+					assert.Equal(t, tc.user.ID, session.UserID)
+					return &repository.UserSession{ID: 3, UserID: session.UserID}, nil
+				})
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, token *repository.RefreshToken) (*repository.RefreshToken, error) {
+					assert.Equal(t, int64(3), token.SessionID)
+					return &repository.RefreshToken{ID: 1}, nil
+				})
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), gomock.Any()).Return(&repository.UserAccess{}, nil)
+			case "Login Cancels Scheduled Deletion":
+				confirmedAt := time.Now()
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), tc.user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.ACCOUNT_DELETION_CANCELLED_ACTIVITY).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(&repository.UserTOTP{UserID: tc.user.ID, ConfirmedAt: &confirmedAt}, nil)
+			case "Suspended Account", "Deactivated Account", "Deletion Grace Period Ended":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), gomock.Any()).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
 			case "Error DB":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
 			case "Wrong Phone or Password":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(nil, nil)
+			case "Unknown Phone Number Counts Against IP":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(nil, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(3), nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertFailedLoginAttempt(gomock.Any(), tc.clientIP).Return(nil)
+			case "Record Unknown Phone Number Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(nil, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(3), nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertFailedLoginAttempt(gomock.Any(), tc.clientIP).Return(errors.New("Record Unknown Phone Number Error"))
 			case "Wrong Password":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
 				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_FAILED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementFailedLoginCount(gomock.Any(), tc.user.ID).Return(int64(1), nil)
+			case "Wrong Password Locks Account":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(4), nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertFailedLoginAttempt(gomock.Any(), tc.clientIP).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_FAILED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementFailedLoginCount(gomock.Any(), tc.user.ID).Return(int64(5), nil)
+				mockRepo.EXPECT().LockUser(gomock.Any(), tc.user.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, lockedUntil time.Time) error {
+					assert.WithinDuration(t, time.Now().Add(time.Minute), lockedUntil, time.Second)
+					return nil
+				})
+			case "Record Failed Login Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_FAILED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementFailedLoginCount(gomock.Any(), tc.user.ID).Return(int64(0), errors.New("Record Failed Login Error"))
+			case "Account Locked":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+			case "Expired Lockout Kept Until Second Factor":
+				confirmedAt := time.Now()
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(&repository.UserTOTP{UserID: tc.user.ID, ConfirmedAt: &confirmedAt}, nil)
+			case "Rehash Outdated Password":
+				confirmedAt := time.Now()
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(tc.user.HashedPassword), []byte(tc.password)).Return(nil)
+				mockHasher.EXPECT().NeedsRehash([]byte(tc.user.HashedPassword)).Return(true)
+				mockHasher.EXPECT().HashPassword(tc.password).Return("$argon2id$new", nil)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), tc.user.ID, "$argon2id$new").Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(&repository.UserTOTP{UserID: tc.user.ID, ConfirmedAt: &confirmedAt}, nil)
+			case "Rehash Error Does Not Fail Login":
+				confirmedAt := time.Now()
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(true)
+				mockHasher.EXPECT().HashPassword(tc.password).Return("$argon2id$new", nil)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), tc.user.ID, "$argon2id$new").Return(errors.New("update error"))
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(&repository.UserTOTP{UserID: tc.user.ID, ConfirmedAt: &confirmedAt}, nil)
+			case "Too Many Failed Logins From IP":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(20), nil)
+			case "Count Failed Logins Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockRepo.EXPECT().CountFailedLoginAttemptsSince(gomock.Any(), tc.clientIP, gomock.Any()).Return(int64(0), errors.New("Count Failed Logins Error"))
 			case "Insert User Activity Log Error":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
 				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, nil)
 				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 					if err := fn(ctx); err != nil {
 						return err
@@ -119,9 +343,32 @@ func TestLogin(t *testing.T) {
 					return nil
 				})
 				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_ACTIVITY).Return(errors.New("Insert User Activity Log Error"))
+			case "Insert Refresh Token Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.user.ID, common.LOGIN_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementLoginCount(gomock.Any(), tc.user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 3}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(nil, errors.New("Insert Refresh Token Error"))
+			case "MFA Required":
+				confirmedAt := time.Now()
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(&repository.UserTOTP{UserID: tc.user.ID, ConfirmedAt: &confirmedAt}, nil)
+			case "Get User TOTP Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, errors.New("Get User TOTP Error"))
 			case "Increment Login Count Error":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phoneNumber).Return(tc.user, nil)
 				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), tc.user.ID).Return(nil, nil)
 				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 					if err := fn(ctx); err != nil {
 						return err
@@ -141,9 +388,15 @@ func TestLogin(t *testing.T) {
 			svc := NewService(ServiceOpts{
 				Repository: mockRepo,
 				Hasher:     mockHasher,
+				Lockout:    DefaultLockoutPolicy(),
 			})
 
-			response, err := svc.Login(context.Background(), service.LoginParam{
+			ctx := context.Background()
+			if tc.clientIP != "" {
+				ctx = context.WithValue(ctx, common.REQUEST_INFO_CTX_KEY, common.RequestInfo{IPAddress: tc.clientIP})
+			}
+
+			response, err := svc.Login(ctx, service.LoginParam{
 				PhoneNumber: tc.phoneNumber,
 				Password:    tc.password,
 			})
@@ -152,9 +405,20 @@ func TestLogin(t *testing.T) {
 				assert.NotNil(t, err)
 				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
 				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+				assert.Equal(t, tc.expectedError.GetErrorCode(), err.GetErrorCode())
+			} else if tc.expectMFA {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+				assert.True(t, response.MFARequired)
+				assert.NotEmpty(t, response.MFAToken)
+				assert.Empty(t, response.Token)
+				assert.Empty(t, response.RefreshToken)
 			} else {
 				assert.Nil(t, err)
 				assert.NotNil(t, response)
+				assert.Equal(t, tc.user.ID, response.UserID)
+				assert.NotEmpty(t, response.Token)
+				assert.NotEmpty(t, response.RefreshToken)
 			}
 		})
 	}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Service) Logout(ctx context.Context, params service.LogoutParam) common.Error {
+
+	familyID := ""
+	if params.RefreshToken != "" {
+		storedToken, err := s.Repository.GetRefreshTokenByHash(ctx, helper.HashOpaqueToken(params.RefreshToken))
+		if err != nil {
+			return errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		if storedToken != nil && storedToken.UserID == params.UserID {
+			familyID = storedToken.FamilyID
+		}
+	}
+
+	err := s.RevocationStore.RevokeToken(ctx, params.TokenID, params.UserID, params.TokenExpiresAt)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		if familyID != "" {
+			err := s.Repository.RevokeRefreshTokenFamily(ctx, familyID)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := s.revokeSession(ctx, params.UserID, params.SessionID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, params.UserID, common.LOGOUT_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+)
+
+func (s *Service) LogoutAll(ctx context.Context, userID int64) common.Error {
+
+	err := s.revokeAllSessions(ctx, userID)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	err = s.Repository.InsertUserActivityLog(ctx, userID, common.LOGOUT_ACTIVITY)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
+
+// revokeAllSessions invalidates every session and every access and refresh
+// token issued to the user so far. Tokens carry their issue time in
+// microseconds, the precision the revocation store keeps, so the cut-off is
+// truncated to match and tokens issued right afterwards stay valid.
+func (s *Service) revokeAllSessions(ctx context.Context, userID int64) error {
+	err := s.RevocationStore.RevokeAllUserTokens(ctx, userID, time.Now().Truncate(time.Microsecond))
+	if err != nil {
+		return err
+	}
+
+	err = s.Repository.RevokeAllUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.Repository.RevokeUserRefreshTokens(ctx, userID)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+)
+
+func TestLogoutAll(t *testing.T) {
+	testCases := []struct {
+		name          string
+		userID        int64
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Logout All",
+			userID:        1,
+			expectedError: nil,
+		},
+		{
+			name:          "Revoke All Tokens Error",
+			userID:        1,
+			expectedError: commonErr.NewError("revoke error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Sessions Error",
+			userID:        1,
+			expectedError: commonErr.NewError("revoke sessions error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Refresh Tokens Error",
+			userID:        1,
+			expectedError: commonErr.NewError("revoke refresh tokens error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Insert User Activity Log Error",
+			userID:        1,
+			expectedError: commonErr.NewError("insert user activity log error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+			revokeAll := func(ctx context.Context, userID int64, revokedBefore time.Time) error {
+				assert.Equal(t, revokedBefore, revokedBefore.Truncate(time.Microsecond))
+				return nil
+			}
+
+			switch tc.name {
+			case "Successful Logout All":
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), tc.userID, gomock.Any()).DoAndReturn(revokeAll)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), tc.userID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), tc.userID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.userID, common.LOGOUT_ACTIVITY).Return(nil)
+			case "Revoke All Tokens Error":
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), tc.userID, gomock.Any()).Return(errors.New("revoke error"))
+			case "Revoke Sessions Error":
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), tc.userID, gomock.Any()).DoAndReturn(revokeAll)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), tc.userID).Return(errors.New("revoke sessions error"))
+			case "Revoke Refresh Tokens Error":
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), tc.userID, gomock.Any()).DoAndReturn(revokeAll)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), tc.userID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), tc.userID).Return(errors.New("revoke refresh tokens error"))
+			case "Insert User Activity Log Error":
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), tc.userID, gomock.Any()).DoAndReturn(revokeAll)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), tc.userID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), tc.userID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.userID, common.LOGOUT_ACTIVITY).Return(errors.New("insert user activity log error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				RevocationStore: mockStore,
+			})
+
+			err := svc.LogoutAll(context.Background(), tc.userID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
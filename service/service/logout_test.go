@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestLogout(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name          string
+		params        service.LogoutParam
+		storedToken   *repository.RefreshToken
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Logout",
+			params:        service.LogoutParam{UserID: 1, SessionID: 4, TokenID: "token-id", TokenExpiresAt: expiresAt},
+			expectedError: nil,
+		},
+		{
+			name:          "Successful Logout With Refresh Token",
+			params:        service.LogoutParam{UserID: 1, SessionID: 4, TokenID: "token-id", TokenExpiresAt: expiresAt, RefreshToken: "refresh"},
+			storedToken:   &repository.RefreshToken{ID: 1, UserID: 1, FamilyID: "family"},
+			expectedError: nil,
+		},
+		{
+			name:          "Refresh Token Of Another User",
+			params:        service.LogoutParam{UserID: 1, SessionID: 4, TokenID: "token-id", TokenExpiresAt: expiresAt, RefreshToken: "refresh"},
+			storedToken:   &repository.RefreshToken{ID: 1, UserID: 2, FamilyID: "family"},
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get Refresh Token",
+			params:        service.LogoutParam{UserID: 1, SessionID: 4, TokenID: "token-id", TokenExpiresAt: expiresAt, RefreshToken: "refresh"},
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Token Error",
+			params:        service.LogoutParam{UserID: 1, SessionID: 4, TokenID: "token-id", TokenExpiresAt: expiresAt},
+			expectedError: commonErr.NewError("revoke error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Session Error",
+			params:        service.LogoutParam{UserID: 1, SessionID: 4, TokenID: "token-id", TokenExpiresAt: expiresAt},
+			expectedError: commonErr.NewError("revoke session error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Insert User Activity Log Error",
+			params:        service.LogoutParam{UserID: 1, SessionID: 4, TokenID: "token-id", TokenExpiresAt: expiresAt},
+			expectedError: commonErr.NewError("insert user activity log error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Logout":
+				mockStore.EXPECT().RevokeToken(gomock.Any(), tc.params.TokenID, tc.params.UserID, expiresAt).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.params.UserID, tc.params.SessionID).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), tc.params.SessionID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.params.UserID, common.LOGOUT_ACTIVITY).Return(nil)
+			case "Successful Logout With Refresh Token":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), helper.HashOpaqueToken(tc.params.RefreshToken)).Return(tc.storedToken, nil)
+				mockStore.EXPECT().RevokeToken(gomock.Any(), tc.params.TokenID, tc.params.UserID, expiresAt).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), tc.storedToken.FamilyID).Return(nil)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.params.UserID, tc.params.SessionID).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), tc.params.SessionID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.params.UserID, common.LOGOUT_ACTIVITY).Return(nil)
+			case "Refresh Token Of Another User":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), helper.HashOpaqueToken(tc.params.RefreshToken)).Return(tc.storedToken, nil)
+				mockStore.EXPECT().RevokeToken(gomock.Any(), tc.params.TokenID, tc.params.UserID, expiresAt).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.params.UserID, tc.params.SessionID).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), tc.params.SessionID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.params.UserID, common.LOGOUT_ACTIVITY).Return(nil)
+			case "Error DB - Get Refresh Token":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			case "Revoke Token Error":
+				mockStore.EXPECT().RevokeToken(gomock.Any(), tc.params.TokenID, tc.params.UserID, expiresAt).Return(errors.New("revoke error"))
+			case "Revoke Session Error":
+				mockStore.EXPECT().RevokeToken(gomock.Any(), tc.params.TokenID, tc.params.UserID, expiresAt).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.params.UserID, tc.params.SessionID).Return(false, errors.New("revoke session error"))
+			case "Insert User Activity Log Error":
+				mockStore.EXPECT().RevokeToken(gomock.Any(), tc.params.TokenID, tc.params.UserID, expiresAt).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.params.UserID, tc.params.SessionID).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), tc.params.SessionID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.params.UserID, common.LOGOUT_ACTIVITY).Return(errors.New("insert user activity log error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				RevocationStore: mockStore,
+			})
+
+			err := svc.Logout(context.Background(), tc.params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/webauthn"
+	"github.com/sawitpro/UserService/service"
+)
+
+// BeginPasskeyLogin starts a login with a passkey. No phone number is asked
+// for: the authenticator offers the user's discoverable passkeys, and the
+// one picked identifies the user.
+func (s *Service) BeginPasskeyLogin(ctx context.Context) (*service.BeginPasskeyLoginResponse, common.Error) {
+
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	ceremonyToken, err := helper.CreatePasskeyLoginToken(challenge, passkeyCeremonyDuration)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return &service.BeginPasskeyLoginResponse{
+		CeremonyToken:  ceremonyToken,
+		Challenge:      challenge,
+		RelyingPartyID: s.RelyingParty.ID,
+		Timeout:        passkeyCeremonyDuration,
+	}, nil
+}
+
+// FinishPasskeyLogin verifies the authenticator's signature over the
+// challenge of BeginPasskeyLogin and logs the owner of the passkey in. As
+// passkeys require user verification they count as two factors, so no TOTP
+// code is asked for.
+func (s *Service) FinishPasskeyLogin(ctx context.Context, params service.FinishPasskeyLoginParam) (*service.LoginResponse, common.Error) {
+
+	claims, err := helper.ValidatePasskeyLoginToken(params.CeremonyToken)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidPasskeyTokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidPasskeyTokenErrorCode)
+	}
+
+	credential, err := s.Repository.GetWebAuthnCredential(ctx, params.CredentialID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if credential == nil || (len(params.UserHandle) > 0 && !bytes.Equal(params.UserHandle, webauthn.UserHandle(credential.UserID))) {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidPasskeyErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidPasskeyErrorCode)
+	}
+
+	errSvc := s.consumeCeremonyToken(ctx, claims, credential.UserID)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	signCount, err := s.RelyingParty.VerifyAssertion(claims.Challenge, webauthn.Credential{
+		ID:        credential.CredentialID,
+		PublicKey: credential.PublicKey,
+		SignCount: uint32(credential.SignCount),
+	}, webauthn.AssertionResponse{
+		ClientDataJSON:    params.ClientDataJSON,
+		AuthenticatorData: params.AuthenticatorData,
+		Signature:         params.Signature,
+	})
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidPasskeyErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidPasskeyErrorCode)
+	}
+
+	updated, err := s.Repository.UpdateWebAuthnCredentialSignCount(ctx, credential.ID, int64(signCount))
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	// A concurrent login stored the same counter first.
+	if !updated {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidPasskeyErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidPasskeyErrorCode)
+	}
+
+	user, err := s.Repository.GetUserByID(ctx, credential.UserID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidPasskeyErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidPasskeyErrorCode)
+	}
+
+	// The status is only revealed to callers who hold the passkey.
+	errSvc = s.admitLogin(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	errSvc = s.clearLoginLockout(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	return s.completeLogin(ctx, user.ID)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/webauthn"
+	"github.com/sawitpro/UserService/helper/webauthn/softauthn"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestBeginPasskeyLogin(t *testing.T) {
+	svc := NewService(ServiceOpts{
+		RelyingParty: testRelyingParty,
+	})
+
+	resp, err := svc.BeginPasskeyLogin(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "sawitpro.example", resp.RelyingPartyID)
+	assert.Equal(t, passkeyCeremonyDuration, resp.Timeout)
+
+	claims, validateErr := helper.ValidatePasskeyLoginToken(resp.CeremonyToken)
+	assert.NoError(t, validateErr)
+	assert.Equal(t, resp.Challenge, claims.Challenge)
+}
+
+func TestFinishPasskeyLogin(t *testing.T) {
+	authenticator := softauthn.New("https://sawitpro.example")
+	registrationChallenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		t.Fatalf("failed to generate challenge: %v", err)
+	}
+	attestation, err := authenticator.Register(testRelyingParty.ID, registrationChallenge, webauthn.UserHandle(1))
+	if err != nil {
+		t.Fatalf("failed to register credential: %v", err)
+	}
+	registered, err := testRelyingParty.VerifyRegistration(registrationChallenge, webauthn.AttestationResponse{
+		ClientDataJSON:    attestation.ClientDataJSON,
+		AttestationObject: attestation.AttestationObject,
+	})
+	if err != nil {
+		t.Fatalf("failed to verify registration: %v", err)
+	}
+
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		t.Fatalf("failed to generate challenge: %v", err)
+	}
+	assertion, err := authenticator.Login(testRelyingParty.ID, challenge, registered.ID)
+	if err != nil {
+		t.Fatalf("failed to sign assertion: %v", err)
+	}
+	ceremonyToken, err := helper.CreatePasskeyLoginToken(challenge, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create ceremony token: %v", err)
+	}
+	registrationToken, err := helper.CreatePasskeyRegistrationToken(1, challenge, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create ceremony token: %v", err)
+	}
+
+	credential := &repository.WebAuthnCredential{ID: 5, UserID: 1, CredentialID: registered.ID, PublicKey: registered.PublicKey}
+	user := &repository.User{ID: 1, Phone: "+628123456789", Status: common.ACTIVE_STATUS}
+	params := service.FinishPasskeyLoginParam{
+		CeremonyToken:     ceremonyToken,
+		CredentialID:      assertion.CredentialID,
+		ClientDataJSON:    assertion.ClientDataJSON,
+		AuthenticatorData: assertion.AuthenticatorData,
+		Signature:         assertion.Signature,
+		UserHandle:        assertion.UserHandle,
+	}
+	registrationTokenParams := params
+	registrationTokenParams.CeremonyToken = registrationToken
+	otherUserHandle := params
+	otherUserHandle.UserHandle = webauthn.UserHandle(2)
+
+	testCases := []struct {
+		name          string
+		params        service.FinishPasskeyLoginParam
+		credential    *repository.WebAuthnCredential
+		user          *repository.User
+		expectedError common.Error
+	}{
+		{
+			name:       "Successful Passkey Login",
+			params:     params,
+			credential: credential,
+			user:       user,
+		},
+		{
+			name:          "Registration Token Instead Of Login Token",
+			params:        registrationTokenParams,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyTokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Unknown Passkey",
+			params:        params,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "User Handle Mismatch",
+			params:        otherUserHandle,
+			credential:    credential,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Token Already Used",
+			params:        params,
+			credential:    credential,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyTokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Revocation Store Error",
+			params:        params,
+			credential:    credential,
+			expectedError: commonErr.NewError("store error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Cloned Authenticator",
+			params:        params,
+			credential:    &repository.WebAuthnCredential{ID: 5, UserID: 1, CredentialID: registered.ID, PublicKey: registered.PublicKey, SignCount: 1},
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Counter Stored Concurrently",
+			params:        params,
+			credential:    credential,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Suspended Account",
+			params:        params,
+			credential:    credential,
+			user:          &repository.User{ID: 1, Phone: "+628123456789", Status: common.SUSPENDED_STATUS},
+			expectedError: commonErr.NewError(commonErr.AccountSuspendedErrorMessage, commonErr.ForbiddenErrorType),
+		},
+		{
+			name:          "Get Credential Error",
+			params:        params,
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+			expectTokenConsumed := func() {
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), registered.ID).Return(tc.credential, nil)
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+			}
+
+			switch tc.name {
+			case "Successful Passkey Login":
+				expectTokenConsumed()
+				mockRepo.EXPECT().UpdateWebAuthnCredentialSignCount(gomock.Any(), int64(5), int64(1)).Return(true, nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(tc.user, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.LOGIN_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementLoginCount(gomock.Any(), int64(1)).Return(nil)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 2, UserID: 1}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(&repository.RefreshToken{ID: 1}, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), int64(1)).Return(&repository.UserAccess{}, nil)
+			case "Registration Token Instead Of Login Token":
+			case "Unknown Passkey":
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), registered.ID).Return(nil, nil)
+			case "User Handle Mismatch":
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), registered.ID).Return(tc.credential, nil)
+			case "Token Already Used":
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), registered.ID).Return(tc.credential, nil)
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+			case "Revocation Store Error":
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), registered.ID).Return(tc.credential, nil)
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(false, errors.New("store error"))
+			case "Cloned Authenticator":
+				expectTokenConsumed()
+			case "Counter Stored Concurrently":
+				expectTokenConsumed()
+				mockRepo.EXPECT().UpdateWebAuthnCredentialSignCount(gomock.Any(), int64(5), int64(1)).Return(false, nil)
+			case "Suspended Account":
+				expectTokenConsumed()
+				mockRepo.EXPECT().UpdateWebAuthnCredentialSignCount(gomock.Any(), int64(5), int64(1)).Return(true, nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(tc.user, nil)
+			case "Get Credential Error":
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), registered.ID).Return(nil, errors.New("some error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				RevocationStore: mockStore,
+				RelyingParty:    testRelyingParty,
+			})
+
+			response, err := svc.FinishPasskeyLogin(context.Background(), tc.params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), response.UserID)
+				assert.False(t, response.MFARequired)
+				assert.NotEmpty(t, response.Token)
+				assert.NotEmpty(t, response.RefreshToken)
+			}
+		})
+	}
+}
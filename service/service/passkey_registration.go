@@ -0,0 +1,166 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/webauthn"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+// BeginPasskeyRegistration starts registering a passkey for the user. The
+// challenge travels in a signed ceremony token, so no state is kept until
+// FinishPasskeyRegistration. Passkeys the user already has are excluded, so
+// an authenticator is not registered twice.
+func (s *Service) BeginPasskeyRegistration(ctx context.Context, userID int64) (*service.BeginPasskeyRegistrationResponse, common.Error) {
+
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.BadRequestErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	credentials, err := s.Repository.ListUserWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	ceremonyToken, err := helper.CreatePasskeyRegistrationToken(userID, challenge, passkeyCeremonyDuration)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	excludeCredentials := make([]service.PasskeyDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		excludeCredentials = append(excludeCredentials, service.PasskeyDescriptor{
+			CredentialID: credential.CredentialID,
+			Transports:   credential.Transports,
+		})
+	}
+
+	return &service.BeginPasskeyRegistrationResponse{
+		CeremonyToken:      ceremonyToken,
+		Challenge:          challenge,
+		RelyingPartyID:     s.RelyingParty.ID,
+		RelyingPartyName:   s.RelyingParty.Name,
+		UserHandle:         webauthn.UserHandle(userID),
+		UserName:           user.Phone,
+		UserDisplayName:    user.FullName,
+		Algorithms:         webauthn.SupportedAlgorithms,
+		ExcludeCredentials: excludeCredentials,
+		Timeout:            passkeyCeremonyDuration,
+	}, nil
+}
+
+// FinishPasskeyRegistration verifies the authenticator's response to the
+// challenge of BeginPasskeyRegistration and stores the new passkey. The
+// ceremony token is single-use.
+func (s *Service) FinishPasskeyRegistration(ctx context.Context, params service.FinishPasskeyRegistrationParam) common.Error {
+
+	claims, err := helper.ValidatePasskeyRegistrationToken(params.CeremonyToken)
+	if err != nil || claims.UserID != params.UserID {
+		return errors.NewErrorWithCode(
+			errors.InvalidPasskeyTokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidPasskeyTokenErrorCode)
+	}
+
+	errSvc := s.consumeCeremonyToken(ctx, claims, claims.UserID)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	credential, err := s.RelyingParty.VerifyRegistration(claims.Challenge, webauthn.AttestationResponse{
+		ClientDataJSON:    params.ClientDataJSON,
+		AttestationObject: params.AttestationObject,
+	})
+	if err != nil || !bytes.Equal(credential.ID, params.CredentialID) {
+		return errors.NewErrorWithCode(
+			errors.InvalidPasskeyErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidPasskeyErrorCode)
+	}
+
+	existing, err := s.Repository.GetWebAuthnCredential(ctx, credential.ID)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if existing != nil {
+		return errors.NewErrorWithCode(
+			errors.PasskeyAlreadyRegisteredErrorMessage,
+			errors.ConflictErrorType,
+			errors.PasskeyAlreadyRegisteredErrorCode)
+	}
+
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		_, err := s.Repository.InsertWebAuthnCredential(ctx, &repository.WebAuthnCredential{
+			UserID:       params.UserID,
+			CredentialID: credential.ID,
+			PublicKey:    credential.PublicKey,
+			SignCount:    int64(credential.SignCount),
+			Transports:   params.Transports,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, params.UserID, common.PASSKEY_REGISTERED_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
+
+// consumeCeremonyToken revokes a passkey ceremony token so its challenge
+// cannot be answered twice. The revocation is kept for userID, who may only
+// be known once the authenticator has picked a passkey. Consuming is atomic,
+// so of concurrent requests with the same token only one goes through.
+func (s *Service) consumeCeremonyToken(ctx context.Context, claims *helper.Claims, userID int64) common.Error {
+	consumed, err := s.RevocationStore.ConsumeToken(ctx, claims.Id, userID, time.Unix(claims.ExpiresAt, 0))
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !consumed {
+		return errors.NewErrorWithCode(
+			errors.InvalidPasskeyTokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidPasskeyTokenErrorCode)
+	}
+
+	return nil
+}
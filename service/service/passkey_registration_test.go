@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/webauthn"
+	"github.com/sawitpro/UserService/helper/webauthn/softauthn"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+var testRelyingParty = webauthn.RelyingParty{
+	ID:      "sawitpro.example",
+	Name:    "SawitPro",
+	Origins: []string{"https://sawitpro.example"},
+}
+
+func TestBeginPasskeyRegistration(t *testing.T) {
+	user := &repository.User{ID: 1, FullName: "Budi Santoso", Phone: "+628123456789"}
+	registered := &repository.WebAuthnCredential{ID: 5, UserID: 1, CredentialID: []byte{1, 2}, Transports: []string{"internal"}}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Begin",
+			expectedError: nil,
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "List Credentials Error",
+			expectedError: commonErr.NewError("list error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+
+			switch tc.name {
+			case "Successful Begin":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(user, nil)
+				mockRepo.EXPECT().ListUserWebAuthnCredentials(gomock.Any(), int64(1)).Return([]*repository.WebAuthnCredential{registered}, nil)
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(nil, nil)
+			case "List Credentials Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(user, nil)
+				mockRepo.EXPECT().ListUserWebAuthnCredentials(gomock.Any(), int64(1)).Return(nil, errors.New("list error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:   mockRepo,
+				RelyingParty: testRelyingParty,
+			})
+
+			resp, err := svc.BeginPasskeyRegistration(context.Background(), 1)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, resp)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "sawitpro.example", resp.RelyingPartyID)
+				assert.Equal(t, "SawitPro", resp.RelyingPartyName)
+				assert.Equal(t, webauthn.UserHandle(1), resp.UserHandle)
+				assert.Equal(t, user.Phone, resp.UserName)
+				assert.Equal(t, user.FullName, resp.UserDisplayName)
+				assert.Equal(t, webauthn.SupportedAlgorithms, resp.Algorithms)
+				assert.Equal(t, []service.PasskeyDescriptor{{CredentialID: []byte{1, 2}, Transports: []string{"internal"}}}, resp.ExcludeCredentials)
+				assert.Equal(t, passkeyCeremonyDuration, resp.Timeout)
+
+				claims, err := helper.ValidatePasskeyRegistrationToken(resp.CeremonyToken)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), claims.UserID)
+				assert.Equal(t, resp.Challenge, claims.Challenge)
+			}
+		})
+	}
+}
+
+func TestFinishPasskeyRegistration(t *testing.T) {
+	authenticator := softauthn.New("https://sawitpro.example")
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		t.Fatalf("failed to generate challenge: %v", err)
+	}
+	attestation, err := authenticator.Register(testRelyingParty.ID, challenge, webauthn.UserHandle(1))
+	if err != nil {
+		t.Fatalf("failed to register credential: %v", err)
+	}
+	ceremonyToken, err := helper.CreatePasskeyRegistrationToken(1, challenge, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create ceremony token: %v", err)
+	}
+	otherChallengeToken, err := helper.CreatePasskeyRegistrationToken(1, "other challenge", time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create ceremony token: %v", err)
+	}
+	loginToken, err := helper.CreatePasskeyLoginToken(challenge, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create ceremony token: %v", err)
+	}
+
+	params := service.FinishPasskeyRegistrationParam{
+		UserID:            1,
+		CeremonyToken:     ceremonyToken,
+		CredentialID:      attestation.CredentialID,
+		ClientDataJSON:    attestation.ClientDataJSON,
+		AttestationObject: attestation.AttestationObject,
+		Transports:        []string{"internal", "hybrid"},
+	}
+	withToken := func(token string) service.FinishPasskeyRegistrationParam {
+		p := params
+		p.CeremonyToken = token
+		return p
+	}
+	otherUser := params
+	otherUser.UserID = 2
+	otherCredentialID := params
+	otherCredentialID.CredentialID = []byte("another credential")
+
+	testCases := []struct {
+		name          string
+		params        service.FinishPasskeyRegistrationParam
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Registration",
+			params:        params,
+			expectedError: nil,
+		},
+		{
+			name:          "Login Token Instead Of Registration Token",
+			params:        withToken(loginToken),
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyTokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Token Of Another User",
+			params:        otherUser,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyTokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Token Already Used",
+			params:        params,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyTokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Revocation Store Error",
+			params:        params,
+			expectedError: commonErr.NewError("store error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Wrong Challenge",
+			params:        withToken(otherChallengeToken),
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Credential ID Mismatch",
+			params:        otherCredentialID,
+			expectedError: commonErr.NewError(commonErr.InvalidPasskeyErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Passkey Already Registered",
+			params:        params,
+			expectedError: commonErr.NewError(commonErr.PasskeyAlreadyRegisteredErrorMessage, commonErr.ConflictErrorType),
+		},
+		{
+			name:          "Insert Credential Error",
+			params:        params,
+			expectedError: commonErr.NewError("insert error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+			expectTokenConsumed := func() {
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+			}
+
+			switch tc.name {
+			case "Successful Registration":
+				expectTokenConsumed()
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), attestation.CredentialID).Return(nil, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertWebAuthnCredential(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, credential *repository.WebAuthnCredential) (*repository.WebAuthnCredential, error) {
+						assert.Equal(t, int64(1), credential.UserID)
+						assert.Equal(t, attestation.CredentialID, credential.CredentialID)
+						assert.NotEmpty(t, credential.PublicKey)
+						assert.Equal(t, int64(0), credential.SignCount)
+						assert.Equal(t, []string{"internal", "hybrid"}, credential.Transports)
+						return &repository.WebAuthnCredential{ID: 5}, nil
+					})
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.PASSKEY_REGISTERED_ACTIVITY).Return(nil)
+			case "Login Token Instead Of Registration Token":
+			case "Token Of Another User":
+			case "Revocation Store Error":
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(false, errors.New("store error"))
+			case "Token Already Used":
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+			case "Wrong Challenge":
+				expectTokenConsumed()
+			case "Credential ID Mismatch":
+				expectTokenConsumed()
+			case "Passkey Already Registered":
+				expectTokenConsumed()
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), attestation.CredentialID).Return(&repository.WebAuthnCredential{ID: 5, UserID: 3}, nil)
+			case "Insert Credential Error":
+				expectTokenConsumed()
+				mockRepo.EXPECT().GetWebAuthnCredential(gomock.Any(), attestation.CredentialID).Return(nil, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertWebAuthnCredential(gomock.Any(), gomock.Any()).Return(nil, errors.New("insert error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				RevocationStore: mockStore,
+				RelyingParty:    testRelyingParty,
+			})
+
+			err := svc.FinishPasskeyRegistration(context.Background(), tc.params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
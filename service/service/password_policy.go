@@ -0,0 +1,186 @@
+package service
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/common/i18n"
+)
+
+//go:embed banned_passwords.txt
+var defaultBannedPasswords string
+
+// PasswordPolicy holds the rules a new password must satisfy. The zero
+// value accepts any password.
+type PasswordPolicy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MaxRepeatedChars is the longest allowed run of one character, zero
+	// means no limit.
+	MaxRepeatedChars int
+	// DisallowPersonalInfo rejects passwords containing the phone number or
+	// a part of the full name.
+	DisallowPersonalInfo bool
+	// BannedPasswords are lower-cased passwords that are always rejected.
+	BannedPasswords map[string]struct{}
+}
+
+// DefaultPasswordPolicy keeps the rules of the former api.yml pattern and
+// adds the repetition, personal information and banned list checks.
+func DefaultPasswordPolicy() PasswordPolicy {
+	banned, _ := ParseBannedPasswords(strings.NewReader(defaultBannedPasswords))
+
+	return PasswordPolicy{
+		MinLength:            6,
+		MaxLength:            64,
+		RequireUpper:         true,
+		RequireDigit:         true,
+		RequireSymbol:        true,
+		MaxRepeatedChars:     3,
+		DisallowPersonalInfo: true,
+		BannedPasswords:      banned,
+	}
+}
+
+// ParseBannedPasswords reads one password per line, skipping blank lines
+// and lines starting with #.
+func ParseBannedPasswords(r io.Reader) (map[string]struct{}, error) {
+	banned := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		banned[strings.ToLower(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return banned, nil
+}
+
+// Validate returns a description of every rule the password breaks, or nil
+// when it satisfies the policy.
+func (p PasswordPolicy) Validate(password, phoneNumber, fullName string) i18n.Phrases {
+	var failed i18n.Phrases
+
+	length := len([]rune(password))
+	if p.MinLength > 0 && length < p.MinLength {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordMinLengthPhrase, p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordMaxLengthPhrase, p.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordUpperPhrase))
+	}
+	if p.RequireLower && !hasLower {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordLowerPhrase))
+	}
+	if p.RequireDigit && !hasDigit {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordDigitPhrase))
+	}
+	if p.RequireSymbol && !hasSymbol {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordSymbolPhrase))
+	}
+
+	if p.MaxRepeatedChars > 0 && longestRun(password) > p.MaxRepeatedChars {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordRepeatedCharsPhrase, p.MaxRepeatedChars))
+	}
+
+	lowered := strings.ToLower(password)
+
+	if p.DisallowPersonalInfo {
+		if containsPhoneNumber(lowered, phoneNumber) {
+			failed = append(failed, i18n.NewPhrase(i18n.PasswordPhoneNumberPhrase))
+		}
+		if containsName(lowered, fullName) {
+			failed = append(failed, i18n.NewPhrase(i18n.PasswordFullNamePhrase))
+		}
+	}
+
+	if _, ok := p.BannedPasswords[lowered]; ok {
+		failed = append(failed, i18n.NewPhrase(i18n.PasswordBannedPhrase))
+	}
+
+	return failed
+}
+
+func longestRun(s string) int {
+	longest, run := 0, 0
+	var prev rune
+	for i, r := range s {
+		if i > 0 && r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+		prev = r
+	}
+	return longest
+}
+
+// containsPhoneNumber matches the number without its +62 country code, so
+// both the international and the local 08 form are caught.
+func containsPhoneNumber(password, phoneNumber string) bool {
+	number := strings.TrimPrefix(phoneNumber, "+62")
+	if len(number) < 6 {
+		return false
+	}
+	return strings.Contains(password, number)
+}
+
+// containsName ignores name parts shorter than three letters, which would
+// reject too many unrelated passwords.
+func containsName(password, fullName string) bool {
+	for _, part := range strings.Fields(strings.ToLower(fullName)) {
+		if len([]rune(part)) >= 3 && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) checkPasswordPolicy(password, phoneNumber, fullName string) common.Error {
+	failed := s.PasswordPolicy.Validate(password, phoneNumber, fullName)
+	if len(failed) > 0 {
+		return errors.NewErrorWithCode(
+			errors.NewPasswordPolicyErrorMessage(failed.Strings(i18n.English)),
+			errors.BadRequestErrorType,
+			errors.PasswordPolicyErrorCode,
+			failed)
+	}
+
+	return nil
+}
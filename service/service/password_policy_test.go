@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sawitpro/UserService/common/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	testCases := []struct {
+		name           string
+		policy         PasswordPolicy
+		password       string
+		expectedFailed []string
+	}{
+		{
+			name:           "Valid Password",
+			policy:         policy,
+			password:       "Kebun#Sawit42",
+			expectedFailed: nil,
+		},
+		{
+			name:     "Too Short Without Classes",
+			policy:   policy,
+			password: "abc",
+			expectedFailed: []string{
+				"be at least 6 characters long",
+				"contain an uppercase letter",
+				"contain a digit",
+				"contain a symbol",
+			},
+		},
+		{
+			name:           "Too Long",
+			policy:         policy,
+			password:       "Kebun#Sawit42" + strings.Repeat("xy", 30),
+			expectedFailed: []string{"be at most 64 characters long"},
+		},
+		{
+			name:           "Lowercase Required",
+			policy:         PasswordPolicy{RequireLower: true},
+			password:       "KEBUN#42",
+			expectedFailed: []string{"contain a lowercase letter"},
+		},
+		{
+			name:           "Repeated Characters",
+			policy:         policy,
+			password:       "Kebun#Saaaawit42",
+			expectedFailed: []string{"not repeat a character more than 3 times in a row"},
+		},
+		{
+			name:           "Contains Phone Number",
+			policy:         policy,
+			password:       "X#08232482440",
+			expectedFailed: []string{"not contain your phone number"},
+		},
+		{
+			name:           "Contains Name",
+			policy:         policy,
+			password:       "Satrio#1996",
+			expectedFailed: []string{"not contain your name"},
+		},
+		{
+			name:           "Banned Password",
+			policy:         policy,
+			password:       "Sawitpro@123",
+			expectedFailed: []string{"not be a commonly used password"},
+		},
+		{
+			name:           "Zero Policy Accepts Anything",
+			policy:         PasswordPolicy{},
+			password:       "a",
+			expectedFailed: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			failed := tc.policy.Validate(tc.password, "+628232482440", "maulana aji satrio")
+			assert.Equal(t, tc.expectedFailed, failed.Strings(i18n.English))
+		})
+	}
+}
+
+func TestParseBannedPasswords(t *testing.T) {
+	banned, err := ParseBannedPasswords(strings.NewReader("# common passwords\n\nPassword1\n  qwerty  \n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"password1": {}, "qwerty": {}}, banned)
+}
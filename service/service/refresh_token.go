@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Service) RefreshToken(ctx context.Context, params service.RefreshTokenParam) (*service.LoginResponse, common.Error) {
+
+	storedToken, err := s.Repository.GetRefreshTokenByHash(ctx, helper.HashOpaqueToken(params.RefreshToken))
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if storedToken == nil || !time.Now().Before(storedToken.ExpiresAt) {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidRefreshTokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidRefreshTokenErrorCode)
+	}
+
+	if storedToken.RevokedAt != nil {
+		return nil, s.revokeReusedRefreshTokenFamily(ctx, storedToken)
+	}
+
+	var resp *service.LoginResponse
+	reused := false
+
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		revoked, err := s.Repository.RevokeRefreshToken(ctx, storedToken.ID)
+		if err != nil {
+			return err
+		}
+
+		// Another request rotated this token between our read and the update.
+		if !revoked {
+			reused = true
+			return nil
+		}
+
+		resp, err = s.issueTokens(ctx, storedToken.UserID, storedToken.SessionID, storedToken.FamilyID)
+		return err
+	})
+
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if reused {
+		return nil, s.revokeReusedRefreshTokenFamily(ctx, storedToken)
+	}
+
+	return resp, nil
+}
+
+// revokeReusedRefreshTokenFamily is called when an already rotated refresh
+// token is presented again. The token may have been stolen, so the session
+// and every token issued from the same login are revoked and the user has to
+// login again.
+func (s *Service) revokeReusedRefreshTokenFamily(ctx context.Context, token *repository.RefreshToken) common.Error {
+	err := s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.RevokeRefreshTokenFamily(ctx, token.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.Repository.RevokeUserSession(ctx, token.UserID, token.SessionID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, token.UserID, common.REFRESH_TOKEN_REUSE_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return errors.NewErrorWithCode(
+		errors.RefreshTokenReusedErrorMessage,
+		errors.UnauthorizedErrorType,
+		errors.RefreshTokenReusedErrorCode)
+}
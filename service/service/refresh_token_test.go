@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestRefreshToken(t *testing.T) {
+	now := time.Now()
+	validToken := &repository.RefreshToken{ID: 1, UserID: 10, SessionID: 4, FamilyID: "family", ExpiresAt: now.Add(time.Hour)}
+	expiredToken := &repository.RefreshToken{ID: 2, UserID: 10, SessionID: 4, FamilyID: "family", ExpiresAt: now.Add(-time.Hour)}
+	revokedToken := &repository.RefreshToken{ID: 3, UserID: 10, SessionID: 4, FamilyID: "family", ExpiresAt: now.Add(time.Hour), RevokedAt: &now}
+	access := &repository.UserAccess{Roles: []string{"admin"}, Permissions: []string{"users:read"}}
+
+	testCases := []struct {
+		name          string
+		storedToken   *repository.RefreshToken
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Rotation",
+			storedToken:   validToken,
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Token Not Found",
+			expectedError: commonErr.NewError(commonErr.InvalidRefreshTokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Token Expired",
+			storedToken:   expiredToken,
+			expectedError: commonErr.NewError(commonErr.InvalidRefreshTokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Token Reused",
+			storedToken:   revokedToken,
+			expectedError: commonErr.NewError(commonErr.RefreshTokenReusedErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Token Rotated Concurrently",
+			storedToken:   validToken,
+			expectedError: commonErr.NewError(commonErr.RefreshTokenReusedErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Revoke Family Error",
+			storedToken:   revokedToken,
+			expectedError: commonErr.NewError("revoke family error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Session Error",
+			storedToken:   revokedToken,
+			expectedError: commonErr.NewError("revoke session error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Token Error",
+			storedToken:   validToken,
+			expectedError: commonErr.NewError("revoke token error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Insert Refresh Token Error",
+			storedToken:   validToken,
+			expectedError: commonErr.NewError("insert refresh token error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+			tokenHash := helper.HashOpaqueToken("refresh")
+
+			switch tc.name {
+			case "Successful Rotation":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeRefreshToken(gomock.Any(), tc.storedToken.ID).Return(true, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, token *repository.RefreshToken) (*repository.RefreshToken, error) {
+					assert.Equal(t, tc.storedToken.UserID, token.UserID)
+					assert.Equal(t, tc.storedToken.SessionID, token.SessionID)
+					assert.Equal(t, tc.storedToken.FamilyID, token.FamilyID)
+					assert.NotEqual(t, tokenHash, token.TokenHash)
+					return token, nil
+				})
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), tc.storedToken.UserID).Return(access, nil)
+			case "Error DB":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(nil, errors.New("some error"))
+			case "Token Not Found":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(nil, nil)
+			case "Token Expired":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+			case "Token Reused":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), tc.storedToken.FamilyID).Return(nil)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.storedToken.UserID, tc.storedToken.SessionID).Return(true, nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.storedToken.UserID, common.REFRESH_TOKEN_REUSE_ACTIVITY).Return(nil)
+			case "Token Rotated Concurrently":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction).Times(2)
+				mockRepo.EXPECT().RevokeRefreshToken(gomock.Any(), tc.storedToken.ID).Return(false, nil)
+				mockRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), tc.storedToken.FamilyID).Return(nil)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.storedToken.UserID, tc.storedToken.SessionID).Return(true, nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), tc.storedToken.UserID, common.REFRESH_TOKEN_REUSE_ACTIVITY).Return(nil)
+			case "Revoke Family Error":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), tc.storedToken.FamilyID).Return(errors.New("revoke family error"))
+			case "Revoke Session Error":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), tc.storedToken.FamilyID).Return(nil)
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), tc.storedToken.UserID, tc.storedToken.SessionID).Return(false, errors.New("revoke session error"))
+			case "Revoke Token Error":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeRefreshToken(gomock.Any(), tc.storedToken.ID).Return(false, errors.New("revoke token error"))
+			case "Insert Refresh Token Error":
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), tokenHash).Return(tc.storedToken, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RevokeRefreshToken(gomock.Any(), tc.storedToken.ID).Return(true, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(nil, errors.New("insert refresh token error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			response, err := svc.RefreshToken(context.Background(), service.RefreshTokenParam{
+				RefreshToken: "refresh",
+			})
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+				assert.Equal(t, tc.storedToken.UserID, response.UserID)
+				assert.NotEmpty(t, response.Token)
+				assert.NotEmpty(t, response.RefreshToken)
+				assert.NotEqual(t, "refresh", response.RefreshToken)
+
+				claims, errToken := helper.ValidateToken(response.Token)
+				assert.NoError(t, errToken)
+				assert.Equal(t, access.Roles, claims.Roles)
+				assert.Equal(t, access.Permissions, claims.Permissions)
+			}
+		})
+	}
+}
@@ -11,6 +11,16 @@ import (
 
 func (s *Service) Register(ctx context.Context, params service.RegisterParam) (*service.RegisterResponse, common.Error) {
 
+	errSvc := s.checkPasswordPolicy(params.Password, params.PhoneNumber, params.FullName)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	errSvc = s.checkBreachedPassword(ctx, params.Password)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
 	existingUser, err := s.Repository.GetUserByPhone(ctx, params.PhoneNumber)
 	if err != nil {
 		return nil, errors.NewError(
@@ -19,9 +29,11 @@ func (s *Service) Register(ctx context.Context, params service.RegisterParam) (*
 	}
 
 	if existingUser != nil {
-		return nil, errors.NewError(
+		return nil, errors.NewErrorWithCode(
 			errors.NewPhoneAlreadyUsedErrorMessage(params.PhoneNumber),
-			errors.BadRequestErrorType)
+			errors.BadRequestErrorType,
+			errors.PhoneAlreadyUsedErrorCode,
+			params.PhoneNumber)
 	}
 
 	hashedPassword, err := s.Hasher.HashPassword(params.Password)
@@ -31,11 +43,24 @@ func (s *Service) Register(ctx context.Context, params service.RegisterParam) (*
 			errors.SystemErrorType)
 	}
 
-	user, err := s.Repository.InsertUser(ctx, &repository.User{
-		FullName:       params.FullName,
-		HashedPassword: hashedPassword,
-		Phone:          params.PhoneNumber,
+	// The account starts unverified and the first code goes out straight
+	// away, so a failed delivery rolls the registration back.
+	var user *repository.User
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		user, err = s.Repository.InsertUser(ctx, &repository.User{
+			FullName:       params.FullName,
+			HashedPassword: hashedPassword,
+			Phone:          params.PhoneNumber,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = s.sendVerificationCode(ctx, user.ID, common.PHONE_VERIFICATION_PURPOSE, user.Phone, phoneVerificationMessageFormat)
+
+		return err
 	})
+
 	if err != nil {
 		return nil, errors.NewError(
 			err.Error(),
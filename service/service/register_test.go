@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/sawitpro/UserService/common"
 	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper/notifier/memory"
 	"github.com/sawitpro/UserService/mocks"
 	"github.com/sawitpro/UserService/repository"
 	"github.com/sawitpro/UserService/service"
@@ -27,6 +28,11 @@ func TestRegister(t *testing.T) {
 			expectedError:  nil,
 			expectedUserID: 1,
 		},
+		{
+			name:          "Password Policy Violation",
+			phone:         "+628232482440",
+			expectedError: commonErr.NewError("password must be at least 6 characters long.", commonErr.BadRequestErrorType),
+		},
 		{
 			name:          "Error DB",
 			phone:         "+628232482440",
@@ -47,6 +53,11 @@ func TestRegister(t *testing.T) {
 			phone:         "+628232482440",
 			expectedError: commonErr.NewError("insert user error", commonErr.SystemErrorType),
 		},
+		{
+			name:          "Insert Verification Code Error",
+			phone:         "+628232482440",
+			expectedError: commonErr.NewError("insert code error", commonErr.SystemErrorType),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -56,12 +67,20 @@ func TestRegister(t *testing.T) {
 
 			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
 			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			notifier := memory.NewNotifier()
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
 
 			switch tc.name {
 			case "Successful Registration":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phone).Return(nil, nil)
 				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("any hash password", nil)
-				mockRepo.EXPECT().InsertUser(gomock.Any(), gomock.Any()).Return(&repository.User{ID: tc.expectedUserID}, nil).Times(1)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUser(gomock.Any(), gomock.Any()).Return(&repository.User{ID: tc.expectedUserID, Phone: tc.phone}, nil).Times(1)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), tc.expectedUserID, common.PHONE_VERIFICATION_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).Return(&repository.VerificationCode{ID: 1}, nil)
 			case "Error DB":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phone).Return(nil, errors.New("some error"))
 			case "Phone Already Used":
@@ -72,18 +91,34 @@ func TestRegister(t *testing.T) {
 			case "Insert User Error":
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phone).Return(nil, nil)
 				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("any hash password", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
 				mockRepo.EXPECT().InsertUser(gomock.Any(), gomock.Any()).Return(nil, errors.New("insert user error")).Times(1)
+			case "Insert Verification Code Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.phone).Return(nil, nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("any hash password", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUser(gomock.Any(), gomock.Any()).Return(&repository.User{ID: 1, Phone: tc.phone}, nil).Times(1)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), int64(1), common.PHONE_VERIFICATION_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).Return(nil, errors.New("insert code error"))
 			}
 
 			svc := NewService(ServiceOpts{
-				Repository: mockRepo,
-				Hasher:     mockHasher,
+				Repository:     mockRepo,
+				Hasher:         mockHasher,
+				Notifier:       notifier,
+				PasswordPolicy: PasswordPolicy{MinLength: 6},
 			})
 
+			password := "@Maulana"
+			if tc.name == "Password Policy Violation" {
+				password = "@Mau"
+			}
+
 			response, err := svc.Register(context.Background(), service.RegisterParam{
 				FullName:    "maulana aji satrio",
 				PhoneNumber: tc.phone,
-				Password:    "@Maulana",
+				Password:    password,
 			})
 
 			if tc.expectedError != nil {
@@ -94,6 +129,8 @@ func TestRegister(t *testing.T) {
 				assert.Nil(t, err)
 				assert.NotNil(t, response)
 				assert.Equal(t, tc.expectedUserID, response.UserID)
+				assert.Len(t, notifier.Messages(), 1)
+				assert.Equal(t, tc.phone, notifier.Messages()[0].PhoneNumber)
 			}
 		})
 	}
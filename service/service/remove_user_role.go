@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+// RemoveUserRole takes a role away from a user. Access tokens already issued
+// keep the role's permissions until they expire.
+func (s *Service) RemoveUserRole(ctx context.Context, params service.UserRoleParam) common.Error {
+	role, errSvc := s.getUserRole(ctx, params)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	err := s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.RemoveUserRole(ctx, params.UserID, role.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, params.UserID, common.ROLE_REMOVED_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestRemoveUserRole(t *testing.T) {
+	user := &repository.User{ID: 1}
+	role := &repository.Role{ID: 2, Name: "support"}
+	params := service.UserRoleParam{UserID: user.ID, Role: role.Name}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Remove",
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By ID",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Error DB - Get Role By Name",
+			expectedError: commonErr.NewError("role error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Role Not Found",
+			expectedError: commonErr.NewError(commonErr.RoleNotFoundErrorMessage, commonErr.NotFoundErrorType),
+		},
+		{
+			name:          "Remove Role Error",
+			expectedError: commonErr.NewError("remove error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Remove":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetRoleByName(gomock.Any(), role.Name).Return(role, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RemoveUserRole(gomock.Any(), user.ID, role.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.ROLE_REMOVED_ACTIVITY).Return(nil)
+			case "Error DB - Get User By ID":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(nil, errors.New("some error"))
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(nil, nil)
+			case "Error DB - Get Role By Name":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetRoleByName(gomock.Any(), role.Name).Return(nil, errors.New("role error"))
+			case "Role Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetRoleByName(gomock.Any(), role.Name).Return(nil, nil)
+			case "Remove Role Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetRoleByName(gomock.Any(), role.Name).Return(role, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().RemoveUserRole(gomock.Any(), user.ID, role.ID).Return(errors.New("remove error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			err := svc.RemoveUserRole(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+func (s *Service) ResetPassword(ctx context.Context, params service.ResetPasswordParam) common.Error {
+
+	user, err := s.Repository.GetUserByPhone(ctx, params.PhoneNumber)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return errors.NewErrorWithCode(
+			errors.InvalidVerificationCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidVerificationCodeErrorCode)
+	}
+
+	// The new password is checked before the code, so a rejected password
+	// does not use up an attempt.
+	errSvc := s.checkPasswordPolicy(params.NewPassword, user.Phone, user.FullName)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	errSvc = s.checkBreachedPassword(ctx, params.NewPassword)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	code, errSvc := s.checkVerificationCode(ctx, user.ID, common.PASSWORD_RESET_PURPOSE, user.Phone, params.Code)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	hashedPassword, err := s.Hasher.HashPassword(params.NewPassword)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	consumed := false
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		consumed, err = s.Repository.ConsumeVerificationCode(ctx, code.ID)
+		if err != nil || !consumed {
+			return err
+		}
+
+		err = s.Repository.UpdateUserPassword(ctx, user.ID, hashedPassword)
+		if err != nil {
+			return err
+		}
+
+		// Proving ownership of the phone is enough to lift a lockout.
+		err = s.Repository.ClearLoginLockout(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, user.ID, common.PASSWORD_RESET_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	// A concurrent request used the same code first.
+	if !consumed {
+		return errors.NewErrorWithCode(
+			errors.InvalidVerificationCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidVerificationCodeErrorCode)
+	}
+
+	err = s.revokeAllSessions(ctx, user.ID)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return nil
+}
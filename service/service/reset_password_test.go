@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestResetPassword(t *testing.T) {
+	params := service.ResetPasswordParam{
+		PhoneNumber: "+628123456789",
+		Code:        "123456",
+		NewPassword: "Maulana1997@",
+	}
+	user := &repository.User{ID: 1, Phone: "+628123456789"}
+	now := time.Now()
+	code := &repository.VerificationCode{
+		ID:        10,
+		UserID:    1,
+		Purpose:   common.PASSWORD_RESET_PURPOSE,
+		Target:    "+628123456789",
+		CodeHash:  "code hash",
+		ExpiresAt: now.Add(time.Minute),
+	}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Reset Password",
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By Phone",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Unknown Phone Number",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Password Policy Violation",
+			expectedError: commonErr.NewError("password must not contain your phone number.", commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "No Code Issued",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Code Sent To Another Number",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Expired Code",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Too Many Attempts",
+			expectedError: commonErr.NewError(commonErr.TooManyVerificationAttemptsErrorMessage, commonErr.TooManyRequestsErrorType),
+		},
+		{
+			name:          "Wrong Code",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Code Consumed Concurrently",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Update Password Error",
+			expectedError: commonErr.NewError("update error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Clear Lockout Error",
+			expectedError: commonErr.NewError("clear error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Sessions Error",
+			expectedError: commonErr.NewError("revoke error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Reset Password":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(code.CodeHash), []byte(params.Code)).Return(nil)
+				mockHasher.EXPECT().HashPassword(params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(nil)
+				mockRepo.EXPECT().ClearLoginLockout(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.PASSWORD_RESET_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().RevokeAllUserSessions(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), user.ID).Return(nil)
+			case "Error DB - Get User By Phone":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, errors.New("some error"))
+			case "Unknown Phone Number":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(nil, nil)
+			case "Password Policy Violation":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+			case "No Code Issued":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(nil, nil)
+			case "Code Sent To Another Number":
+				other := *code
+				other.Target = "+628987654321"
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(&other, nil)
+			case "Expired Code":
+				expired := *code
+				expired.ExpiresAt = now.Add(-time.Minute)
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(&expired, nil)
+			case "Too Many Attempts":
+				exhausted := *code
+				exhausted.Attempts = verificationCodeMaxAttempts
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(&exhausted, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), exhausted.ID, verificationCodeMaxAttempts).Return(false, nil)
+			case "Wrong Code":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("mismatch"))
+			case "Code Consumed Concurrently":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(false, nil)
+			case "Update Password Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(errors.New("update error"))
+			case "Clear Lockout Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(nil)
+				mockRepo.EXPECT().ClearLoginLockout(gomock.Any(), user.ID).Return(errors.New("clear error"))
+			case "Revoke Sessions Error":
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), params.PhoneNumber).Return(user, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), user.ID, common.PASSWORD_RESET_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockHasher.EXPECT().HashPassword(params.NewPassword).Return("new hash", nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, "new hash").Return(nil)
+				mockRepo.EXPECT().ClearLoginLockout(gomock.Any(), user.ID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), user.ID, common.PASSWORD_RESET_ACTIVITY).Return(nil)
+				mockStore.EXPECT().RevokeAllUserTokens(gomock.Any(), user.ID, gomock.Any()).Return(errors.New("revoke error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				Hasher:          mockHasher,
+				RevocationStore: mockStore,
+				PasswordPolicy:  PasswordPolicy{DisallowPersonalInfo: true},
+			})
+
+			resetParams := params
+			if tc.name == "Password Policy Violation" {
+				resetParams.NewPassword = "Kebun@08123456789"
+			}
+
+			err := svc.ResetPassword(context.Background(), resetParams)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/service"
+)
+
+// RevokeSession logs the user out of one of their sessions. Its refresh
+// tokens stop working at once, and AuthMiddleware refuses its access tokens.
+func (s *Service) RevokeSession(ctx context.Context, params service.RevokeSessionParam) common.Error {
+	revoked := false
+	err := s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		revoked, err = s.revokeSession(ctx, params.UserID, params.SessionID)
+		if err != nil || !revoked {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, params.UserID, common.SESSION_REVOKED_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !revoked {
+		return errors.NewErrorWithCode(
+			errors.SessionNotFoundErrorMessage,
+			errors.NotFoundErrorType,
+			errors.SessionNotFoundErrorCode)
+	}
+
+	return nil
+}
+
+// revokeSession revokes a session of the user and its refresh tokens. It
+// reports false when the session is unknown or was already revoked.
+func (s *Service) revokeSession(ctx context.Context, userID, sessionID int64) (bool, error) {
+	revoked, err := s.Repository.RevokeUserSession(ctx, userID, sessionID)
+	if err != nil || !revoked {
+		return false, err
+	}
+
+	err = s.Repository.RevokeSessionRefreshTokens(ctx, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
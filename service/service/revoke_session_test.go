@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestRevokeSession(t *testing.T) {
+	params := service.RevokeSessionParam{UserID: 1, SessionID: 3}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Revoke",
+			expectedError: nil,
+		},
+		{
+			name:          "Session Not Found",
+			expectedError: commonErr.NewErrorWithCode(commonErr.SessionNotFoundErrorMessage, commonErr.NotFoundErrorType, commonErr.SessionNotFoundErrorCode),
+		},
+		{
+			name:          "Revoke Session Error",
+			expectedError: commonErr.NewError("revoke session error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revoke Refresh Tokens Error",
+			expectedError: commonErr.NewError("revoke refresh tokens error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Insert User Activity Log Error",
+			expectedError: commonErr.NewError("insert user activity log error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			execTransaction := func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}
+			mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+
+			switch tc.name {
+			case "Successful Revoke":
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), params.UserID, params.SessionID).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), params.SessionID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.SESSION_REVOKED_ACTIVITY).Return(nil)
+			case "Session Not Found":
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), params.UserID, params.SessionID).Return(false, nil)
+			case "Revoke Session Error":
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), params.UserID, params.SessionID).Return(false, errors.New("revoke session error"))
+			case "Revoke Refresh Tokens Error":
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), params.UserID, params.SessionID).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), params.SessionID).Return(errors.New("revoke refresh tokens error"))
+			case "Insert User Activity Log Error":
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), params.UserID, params.SessionID).Return(true, nil)
+				mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), params.SessionID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.SESSION_REVOKED_ACTIVITY).Return(errors.New("insert user activity log error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			err := svc.RevokeSession(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+				assert.Equal(t, tc.expectedError.GetErrorCode(), err.GetErrorCode())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -1,24 +1,88 @@
 package service
 
 import (
+	"time"
+
+	"github.com/sawitpro/UserService/helper/breach"
 	"github.com/sawitpro/UserService/helper/hasher"
+	"github.com/sawitpro/UserService/helper/notifier"
+	"github.com/sawitpro/UserService/helper/revocation"
+	"github.com/sawitpro/UserService/helper/webauthn"
 	"github.com/sawitpro/UserService/repository"
 	"github.com/sawitpro/UserService/service"
 )
 
+const (
+	accessTokenDuration  = time.Duration(6) * time.Hour
+	refreshTokenDuration = time.Duration(30*24) * time.Hour
+
+	verificationCodeDigits      = 6
+	verificationCodeDuration    = time.Duration(10) * time.Minute
+	verificationCodeMaxAttempts = 5
+	verificationCodeMaxPerHour  = 3
+
+	mfaTokenDuration  = time.Duration(5) * time.Minute
+	recoveryCodeCount = 10
+	totpIssuer        = "SawitPro"
+
+	passkeyCeremonyDuration = time.Duration(5) * time.Minute
+
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+	recentActivityLimit = 20
+
+	defaultActivityPageSize = 20
+	maxActivityPageSize     = 100
+
+	erasureBatchSize = 100
+
+	dataExportWindow    = time.Duration(24) * time.Hour
+	dataExportMaxPerDay = 3
+)
+
 type Service struct {
-	Repository repository.RepositoryInterface
-	Hasher     hasher.PasswordHasher
+	Repository          repository.RepositoryInterface
+	Hasher              hasher.PasswordHasher
+	RevocationStore     revocation.Store
+	Notifier            notifier.Notifier
+	Lockout             LockoutPolicy
+	PasswordPolicy      PasswordPolicy
+	BreachChecker       breach.Checker
+	MinBreachCount      int64
+	DeletionGracePeriod time.Duration
+	SessionLimit        SessionLimitPolicy
+	LoginAnomaly        LoginAnomalyPolicy
+	RelyingParty        webauthn.RelyingParty
 }
 
 type ServiceOpts struct {
-	Repository repository.RepositoryInterface
-	Hasher     hasher.PasswordHasher
+	Repository          repository.RepositoryInterface
+	Hasher              hasher.PasswordHasher
+	RevocationStore     revocation.Store
+	Notifier            notifier.Notifier
+	Lockout             LockoutPolicy
+	PasswordPolicy      PasswordPolicy
+	BreachChecker       breach.Checker
+	MinBreachCount      int64
+	DeletionGracePeriod time.Duration
+	SessionLimit        SessionLimitPolicy
+	LoginAnomaly        LoginAnomalyPolicy
+	RelyingParty        webauthn.RelyingParty
 }
 
 func NewService(opts ServiceOpts) service.ServiceInterface {
 	return &Service{
-		Repository: opts.Repository,
-		Hasher:     opts.Hasher,
+		Repository:          opts.Repository,
+		Hasher:              opts.Hasher,
+		RevocationStore:     opts.RevocationStore,
+		Notifier:            opts.Notifier,
+		Lockout:             opts.Lockout,
+		PasswordPolicy:      opts.PasswordPolicy,
+		BreachChecker:       opts.BreachChecker,
+		MinBreachCount:      opts.MinBreachCount,
+		DeletionGracePeriod: opts.DeletionGracePeriod,
+		SessionLimit:        opts.SessionLimit,
+		LoginAnomaly:        opts.LoginAnomaly,
+		RelyingParty:        opts.RelyingParty,
 	}
 }
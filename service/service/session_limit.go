@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+)
+
+// SessionLimitAction decides what happens to a login that would take a user
+// past their session limit.
+type SessionLimitAction string
+
+const (
+	// EvictOldestSession revokes the sessions started longest ago to make
+	// room for the new one.
+	EvictOldestSession SessionLimitAction = "evict_oldest"
+	// RejectNewSession refuses the login and keeps the existing sessions.
+	RejectNewSession SessionLimitAction = "reject"
+)
+
+// SessionLimitPolicy caps how many sessions a user may have at once.
+// MaxSessions applies to every user and RoleMaxSessions overrides it for the
+// holders of a role; a user holding several of those roles gets the highest
+// limit. Zero means no limit.
+type SessionLimitPolicy struct {
+	MaxSessions     int
+	RoleMaxSessions map[string]int
+	// Action defaults to EvictOldestSession.
+	Action SessionLimitAction
+}
+
+func (p SessionLimitPolicy) enabled() bool {
+	return p.MaxSessions > 0 || len(p.RoleMaxSessions) > 0
+}
+
+// maxSessions returns the limit of a user holding roles, or zero when the
+// user has no limit.
+func (p SessionLimitPolicy) maxSessions(roles []string) int {
+	limit, overridden := 0, false
+	for _, role := range roles {
+		n, ok := p.RoleMaxSessions[role]
+		if !ok {
+			continue
+		}
+		if n == 0 {
+			return 0
+		}
+		if !overridden || n > limit {
+			limit, overridden = n, true
+		}
+	}
+
+	if !overridden {
+		return p.MaxSessions
+	}
+
+	return limit
+}
+
+// checkSessionLimit applies the session limit to a new login of the user. It
+// returns the sessions to evict to make room for the new one, or an error
+// when the policy rejects the login, which is recorded.
+func (s *Service) checkSessionLimit(ctx context.Context, userID int64) ([]*repository.UserSession, common.Error) {
+	if !s.SessionLimit.enabled() {
+		return nil, nil
+	}
+
+	access, err := s.Repository.GetUserAccess(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	limit := s.SessionLimit.maxSessions(access.Roles)
+	if limit == 0 {
+		return nil, nil
+	}
+
+	sessions, err := s.Repository.ListUserSessions(ctx, userID, time.Now().Add(-refreshTokenDuration))
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if len(sessions) < limit {
+		return nil, nil
+	}
+
+	if s.SessionLimit.Action == RejectNewSession {
+		err = s.Repository.InsertUserActivityLog(ctx, userID, common.SESSION_LIMIT_REJECTED_ACTIVITY)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		return nil, errors.NewErrorWithCode(
+			errors.SessionLimitReachedErrorMessage,
+			errors.ForbiddenErrorType,
+			errors.SessionLimitReachedErrorCode)
+	}
+
+	// Sessions are listed most recently used first, but the ones to evict
+	// are those started first.
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].ID < sessions[j].ID
+		}
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+
+	return sessions[:len(sessions)-limit+1], nil
+}
+
+// evictSessions revokes sessions of the user picked by checkSessionLimit,
+// recording each eviction.
+func (s *Service) evictSessions(ctx context.Context, userID int64, sessions []*repository.UserSession) error {
+	for _, session := range sessions {
+		revoked, err := s.revokeSession(ctx, userID, session.ID)
+		if err != nil {
+			return err
+		}
+
+		// A session revoked meanwhile, by its own logout, was not evicted.
+		if !revoked {
+			continue
+		}
+
+		err = s.Repository.InsertUserActivityLog(ctx, userID, common.SESSION_EVICTED_ACTIVITY)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
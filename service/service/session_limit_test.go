@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+)
+
+func TestSessionLimitMaxSessions(t *testing.T) {
+	policy := SessionLimitPolicy{
+		MaxSessions:     3,
+		RoleMaxSessions: map[string]int{"field_worker": 1, "supervisor": 2, "admin": 0},
+	}
+
+	testCases := []struct {
+		name     string
+		policy   SessionLimitPolicy
+		roles    []string
+		expected int
+	}{
+		{
+			name:     "Default Limit",
+			policy:   policy,
+			roles:    []string{"support"},
+			expected: 3,
+		},
+		{
+			name:     "Role Limit",
+			policy:   policy,
+			roles:    []string{"field_worker"},
+			expected: 1,
+		},
+		{
+			name:     "Highest Role Limit",
+			policy:   policy,
+			roles:    []string{"field_worker", "supervisor"},
+			expected: 2,
+		},
+		{
+			name:     "Exempt Role",
+			policy:   policy,
+			roles:    []string{"field_worker", "admin"},
+			expected: 0,
+		},
+		{
+			name:     "Disabled",
+			policy:   SessionLimitPolicy{},
+			roles:    []string{"field_worker"},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.maxSessions(tc.roles))
+		})
+	}
+}
+
+func TestCompleteLoginSessionLimit(t *testing.T) {
+	userID := int64(1)
+	now := time.Now()
+	// Session 7 was started first but is the one used most recently.
+	sessions := func() []*repository.UserSession {
+		return []*repository.UserSession{
+			{ID: 7, UserID: userID, LastSeenAt: now, CreatedAt: now.Add(-72 * time.Hour)},
+			{ID: 5, UserID: userID, LastSeenAt: now.Add(-time.Hour), CreatedAt: now.Add(-2 * time.Hour)},
+			{ID: 2, UserID: userID, LastSeenAt: now.Add(-24 * time.Hour), CreatedAt: now.Add(-48 * time.Hour)},
+		}
+	}
+	access := &repository.UserAccess{Roles: []string{"field_worker"}}
+
+	testCases := []struct {
+		name          string
+		policy        SessionLimitPolicy
+		expectedError common.Error
+	}{
+		{
+			name:   "Under Limit",
+			policy: SessionLimitPolicy{MaxSessions: 4},
+		},
+		{
+			name:   "Evict Oldest Sessions",
+			policy: SessionLimitPolicy{RoleMaxSessions: map[string]int{"field_worker": 2}, Action: EvictOldestSession},
+		},
+		{
+			name:   "Evicted Session Already Revoked",
+			policy: SessionLimitPolicy{MaxSessions: 3},
+		},
+		{
+			name:          "Reject New Session",
+			policy:        SessionLimitPolicy{MaxSessions: 3, Action: RejectNewSession},
+			expectedError: commonErr.NewErrorWithCode(commonErr.SessionLimitReachedErrorMessage, commonErr.ForbiddenErrorType, commonErr.SessionLimitReachedErrorCode),
+		},
+		{
+			name:   "Exempt Role",
+			policy: SessionLimitPolicy{MaxSessions: 1, RoleMaxSessions: map[string]int{"field_worker": 0}},
+		},
+		{
+			name:          "List Sessions Error",
+			policy:        SessionLimitPolicy{MaxSessions: 3},
+			expectedError: commonErr.NewError("list error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Evict Session Error",
+			policy:        SessionLimitPolicy{MaxSessions: 3},
+			expectedError: commonErr.NewError("revoke error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Insert Rejection Log Error",
+			policy:        SessionLimitPolicy{MaxSessions: 3, Action: RejectNewSession},
+			expectedError: commonErr.NewError("insert error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+			expectLogin := func() {
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), userID, common.LOGIN_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementLoginCount(gomock.Any(), userID).Return(nil)
+			}
+			expectTokens := func() {
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 8, UserID: userID}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(&repository.RefreshToken{ID: 1}, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), userID).Return(access, nil)
+			}
+
+			mockRepo.EXPECT().GetUserAccess(gomock.Any(), userID).Return(access, nil)
+
+			switch tc.name {
+			case "Under Limit", "Exempt Role":
+				if tc.name == "Under Limit" {
+					mockRepo.EXPECT().ListUserSessions(gomock.Any(), userID, gomock.Any()).Return(sessions(), nil)
+				}
+				expectLogin()
+				expectTokens()
+			case "Evict Oldest Sessions":
+				mockRepo.EXPECT().ListUserSessions(gomock.Any(), userID, gomock.Any()).Return(sessions(), nil)
+				expectLogin()
+				for _, sessionID := range []int64{7, 2} {
+					mockRepo.EXPECT().RevokeUserSession(gomock.Any(), userID, sessionID).Return(true, nil)
+					mockRepo.EXPECT().RevokeSessionRefreshTokens(gomock.Any(), sessionID).Return(nil)
+				}
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), userID, common.SESSION_EVICTED_ACTIVITY).Return(nil).Times(2)
+				expectTokens()
+			case "Evicted Session Already Revoked":
+				mockRepo.EXPECT().ListUserSessions(gomock.Any(), userID, gomock.Any()).Return(sessions(), nil)
+				expectLogin()
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), userID, int64(7)).Return(false, nil)
+				expectTokens()
+			case "Reject New Session":
+				mockRepo.EXPECT().ListUserSessions(gomock.Any(), userID, gomock.Any()).Return(sessions(), nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), userID, common.SESSION_LIMIT_REJECTED_ACTIVITY).Return(nil)
+			case "List Sessions Error":
+				mockRepo.EXPECT().ListUserSessions(gomock.Any(), userID, gomock.Any()).Return(nil, errors.New("list error"))
+			case "Evict Session Error":
+				mockRepo.EXPECT().ListUserSessions(gomock.Any(), userID, gomock.Any()).Return(sessions(), nil)
+				expectLogin()
+				mockRepo.EXPECT().RevokeUserSession(gomock.Any(), userID, int64(7)).Return(false, errors.New("revoke error"))
+			case "Insert Rejection Log Error":
+				mockRepo.EXPECT().ListUserSessions(gomock.Any(), userID, gomock.Any()).Return(sessions(), nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), userID, common.SESSION_LIMIT_REJECTED_ACTIVITY).Return(errors.New("insert error"))
+			}
+
+			svc := &Service{
+				Repository:   mockRepo,
+				SessionLimit: tc.policy,
+			}
+
+			response, err := svc.completeLogin(context.Background(), userID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Nil(t, response)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+				assert.Equal(t, tc.expectedError.GetErrorCode(), err.GetErrorCode())
+			} else {
+				assert.Nil(t, err)
+				assert.NotEmpty(t, response.Token)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/totp"
+	"github.com/sawitpro/UserService/service"
+)
+
+// SetupTOTP starts enrolment of an authenticator app. The secret only takes
+// effect once ConfirmTOTP has seen a valid code generated from it.
+func (s *Service) SetupTOTP(ctx context.Context, userID int64) (*service.SetupTOTPResponse, common.Error) {
+
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.BadRequestErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	userTOTP, err := s.Repository.GetUserTOTP(ctx, userID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if userTOTP != nil && userTOTP.ConfirmedAt != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.TOTPAlreadyEnabledErrorMessage,
+			errors.ConflictErrorType,
+			errors.TOTPAlreadyEnabledErrorCode)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	err = s.Repository.UpsertUserTOTP(ctx, userID, secret)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return &service.SetupTOTPResponse{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, user.Phone, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication and returns a fresh set of
+// recovery codes. The codes are only ever shown here; just their hashes are
+// stored.
+func (s *Service) ConfirmTOTP(ctx context.Context, params service.ConfirmTOTPParam) (*service.ConfirmTOTPResponse, common.Error) {
+
+	userTOTP, err := s.Repository.GetUserTOTP(ctx, params.UserID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if userTOTP == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.TOTPNotSetUpErrorMessage,
+			errors.BadRequestErrorType,
+			errors.TOTPNotSetUpErrorCode)
+	}
+
+	if userTOTP.ConfirmedAt != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.TOTPAlreadyEnabledErrorMessage,
+			errors.ConflictErrorType,
+			errors.TOTPAlreadyEnabledErrorCode)
+	}
+
+	step, ok := totp.Validate(userTOTP.Secret, params.Code, time.Now())
+	if !ok {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidTOTPCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidTOTPCodeErrorCode)
+	}
+
+	recoveryCodes := make([]string, recoveryCodeCount)
+	codeHashes := make([]string, recoveryCodeCount)
+	for i := range recoveryCodes {
+		recoveryCodes[i], err = helper.GenerateRecoveryCode()
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		codeHashes[i], err = s.Hasher.HashPassword(recoveryCodes[i])
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+	}
+
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		err := s.Repository.ConfirmUserTOTP(ctx, params.UserID)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.Repository.UpdateTOTPLastUsedStep(ctx, params.UserID, step)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.DeleteRecoveryCodes(ctx, params.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, codeHash := range codeHashes {
+			err = s.Repository.InsertRecoveryCode(ctx, params.UserID, codeHash)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, params.UserID, common.MFA_ENABLED_ACTIVITY)
+	})
+
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	return &service.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper/totp"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestSetupTOTP(t *testing.T) {
+	now := time.Now()
+	user := &repository.User{ID: 1, Phone: "+628232482440"}
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Setup",
+			expectedError: nil,
+		},
+		{
+			name:          "Successful Setup Replacing Unconfirmed Secret",
+			expectedError: nil,
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Already Enabled",
+			expectedError: commonErr.NewError(commonErr.TOTPAlreadyEnabledErrorMessage, commonErr.ConflictErrorType),
+		},
+		{
+			name:          "Upsert Error",
+			expectedError: commonErr.NewError("upsert error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+
+			switch tc.name {
+			case "Successful Setup":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), user.ID).Return(nil, nil)
+				mockRepo.EXPECT().UpsertUserTOTP(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+			case "Successful Setup Replacing Unconfirmed Secret":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), user.ID).Return(&repository.UserTOTP{UserID: user.ID, Secret: "OLD"}, nil)
+				mockRepo.EXPECT().UpsertUserTOTP(gomock.Any(), user.ID, gomock.Not("OLD")).Return(nil)
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(nil, nil)
+			case "Already Enabled":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), user.ID).Return(&repository.UserTOTP{UserID: user.ID, ConfirmedAt: &now}, nil)
+			case "Upsert Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), user.ID).Return(nil, nil)
+				mockRepo.EXPECT().UpsertUserTOTP(gomock.Any(), user.ID, gomock.Any()).Return(errors.New("upsert error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+			})
+
+			response, err := svc.SetupTOTP(context.Background(), user.ID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+				assert.NotEmpty(t, response.Secret)
+				assert.True(t, strings.HasPrefix(response.URI, "otpauth://totp/"))
+				assert.Contains(t, response.URI, "secret="+response.Secret)
+			}
+		})
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	now := time.Now()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	code, err := totp.Code(secret, now)
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	pending := &repository.UserTOTP{UserID: 1, Secret: secret}
+
+	testCases := []struct {
+		name          string
+		code          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Confirm",
+			code:          code,
+			expectedError: nil,
+		},
+		{
+			name:          "Not Set Up",
+			code:          code,
+			expectedError: commonErr.NewError(commonErr.TOTPNotSetUpErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Already Enabled",
+			code:          code,
+			expectedError: commonErr.NewError(commonErr.TOTPAlreadyEnabledErrorMessage, commonErr.ConflictErrorType),
+		},
+		{
+			name:          "Invalid Code",
+			code:          "000000",
+			expectedError: commonErr.NewError(commonErr.InvalidTOTPCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Insert Recovery Code Error",
+			code:          code,
+			expectedError: commonErr.NewError("insert error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Confirm":
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(pending, nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil).Times(recoveryCodeCount)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConfirmUserTOTP(gomock.Any(), int64(1)).Return(nil)
+				mockRepo.EXPECT().UpdateTOTPLastUsedStep(gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().DeleteRecoveryCodes(gomock.Any(), int64(1)).Return(nil)
+				mockRepo.EXPECT().InsertRecoveryCode(gomock.Any(), int64(1), "code hash").Return(nil).Times(recoveryCodeCount)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.MFA_ENABLED_ACTIVITY).Return(nil)
+			case "Not Set Up":
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(nil, nil)
+			case "Already Enabled":
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(&repository.UserTOTP{UserID: 1, Secret: secret, ConfirmedAt: &now}, nil)
+			case "Invalid Code":
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(pending, nil)
+			case "Insert Recovery Code Error":
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(pending, nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil).Times(recoveryCodeCount)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConfirmUserTOTP(gomock.Any(), int64(1)).Return(nil)
+				mockRepo.EXPECT().UpdateTOTPLastUsedStep(gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().DeleteRecoveryCodes(gomock.Any(), int64(1)).Return(nil)
+				mockRepo.EXPECT().InsertRecoveryCode(gomock.Any(), int64(1), "code hash").Return(errors.New("insert error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+				Hasher:     mockHasher,
+			})
+
+			response, err := svc.ConfirmTOTP(context.Background(), service.ConfirmTOTPParam{UserID: 1, Code: tc.code})
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+				assert.Len(t, response.RecoveryCodes, recoveryCodeCount)
+			}
+		})
+	}
+}
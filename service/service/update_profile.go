@@ -19,18 +19,22 @@ func (s *Service) UpdateProfile(ctx context.Context, params service.UpdateProfil
 	}
 
 	if user == nil {
-		return nil, errors.NewError(
+		return nil, errors.NewErrorWithCode(
 			errors.UserDataNotFoundErrorMessage,
-			errors.BadRequestErrorType)
+			errors.BadRequestErrorType,
+			errors.UserDataNotFoundErrorCode)
 	}
 
 	if params.FullName == "" {
 		params.FullName = user.FullName
 	}
 
-	if params.PhoneNumber == "" {
-		params.PhoneNumber = user.Phone
-	} else {
+	pendingPhone := ""
+	if user.PendingPhone != nil {
+		pendingPhone = *user.PendingPhone
+	}
+
+	if params.PhoneNumber != "" {
 		existingUser, err := s.Repository.GetUserByPhone(ctx, params.PhoneNumber)
 		if err != nil {
 			return nil, errors.NewError(
@@ -39,13 +43,42 @@ func (s *Service) UpdateProfile(ctx context.Context, params service.UpdateProfil
 		}
 
 		if existingUser != nil {
-			return nil, errors.NewError(
+			return nil, errors.NewErrorWithCode(
 				errors.NewPhoneAlreadyUsedErrorMessage(params.PhoneNumber),
-				errors.ConflictErrorType)
+				errors.ConflictErrorType,
+				errors.PhoneAlreadyUsedErrorCode,
+				params.PhoneNumber)
+		}
+	}
+
+	// A new phone number is only kept as pending until the user confirms the
+	// code sent to it. The current number stays in place until then.
+	if params.PhoneNumber != "" && params.PhoneNumber != user.Phone {
+		sent, err := s.sendVerificationCode(ctx, user.ID, common.PHONE_VERIFICATION_PURPOSE, params.PhoneNumber, phoneVerificationMessageFormat)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		if !sent {
+			return nil, errors.NewErrorWithCode(
+				errors.TooManyVerificationCodesErrorMessage,
+				errors.TooManyRequestsErrorType,
+				errors.TooManyVerificationCodesErrorCode)
+		}
+
+		err = s.Repository.SetPendingPhone(ctx, user.ID, params.PhoneNumber)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
 		}
+
+		pendingPhone = params.PhoneNumber
 	}
 
-	err = s.Repository.UpdateUser(ctx, params.UserID, params.FullName, params.PhoneNumber)
+	err = s.Repository.UpdateUser(ctx, params.UserID, params.FullName, user.Phone)
 	if err != nil {
 		return nil, errors.NewError(
 			err.Error(),
@@ -53,7 +86,8 @@ func (s *Service) UpdateProfile(ctx context.Context, params service.UpdateProfil
 	}
 
 	return &service.UpdateProfileResponse{
-		FullName:    params.FullName,
-		PhoneNumber: params.PhoneNumber,
+		FullName:           params.FullName,
+		PhoneNumber:        user.Phone,
+		PendingPhoneNumber: pendingPhone,
 	}, nil
 }
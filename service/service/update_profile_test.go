@@ -10,6 +10,7 @@ import (
 
 	"github.com/sawitpro/UserService/common"
 	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper/notifier/memory"
 	"github.com/sawitpro/UserService/mocks"
 	"github.com/sawitpro/UserService/repository"
 	"github.com/sawitpro/UserService/service"
@@ -99,6 +100,27 @@ func TestUpdateProfile(t *testing.T) {
 			updateUserErr: errors.New("some error"),
 			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
 		},
+		{
+			name: "Phone Change Pending Verification",
+			params: service.UpdateProfileParam{
+				UserID:      1,
+				FullName:    "maulana aji satrio",
+				PhoneNumber: "+62811111111",
+			},
+			user:           &repository.User{ID: 1, FullName: "Old Name", Phone: "+62823248244"},
+			expectedError:  nil,
+			expectedResult: &service.UpdateProfileResponse{FullName: "maulana aji satrio", PhoneNumber: "+62823248244", PendingPhoneNumber: "+62811111111"},
+		},
+		{
+			name: "Phone Change Rate Limited",
+			params: service.UpdateProfileParam{
+				UserID:      1,
+				FullName:    "maulana aji satrio",
+				PhoneNumber: "+62811111111",
+			},
+			user:          &repository.User{ID: 1, FullName: "Old Name", Phone: "+62823248244"},
+			expectedError: commonErr.NewError(commonErr.TooManyVerificationCodesErrorMessage, commonErr.TooManyRequestsErrorType),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -107,6 +129,8 @@ func TestUpdateProfile(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			notifier := memory.NewNotifier()
 
 			switch tc.name {
 			case "Successful Update":
@@ -130,10 +154,24 @@ func TestUpdateProfile(t *testing.T) {
 				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(tc.user, nil)
 				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.params.PhoneNumber).Return(nil, nil)
 				mockRepo.EXPECT().UpdateUser(gomock.Any(), tc.params.UserID, tc.params.FullName, tc.params.PhoneNumber).Return(errors.New("some error"))
+			case "Phone Change Pending Verification":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(tc.user, nil)
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.params.PhoneNumber).Return(nil, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), tc.user.ID, common.PHONE_VERIFICATION_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).Return(&repository.VerificationCode{ID: 1}, nil)
+				mockRepo.EXPECT().SetPendingPhone(gomock.Any(), tc.user.ID, tc.params.PhoneNumber).Return(nil)
+				mockRepo.EXPECT().UpdateUser(gomock.Any(), tc.user.ID, tc.params.FullName, tc.user.Phone).Return(nil)
+			case "Phone Change Rate Limited":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tc.params.UserID).Return(tc.user, nil)
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), tc.params.PhoneNumber).Return(nil, nil)
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), tc.user.ID, common.PHONE_VERIFICATION_PURPOSE, gomock.Any()).Return(int64(verificationCodeMaxPerHour), nil)
 			}
 
 			svc := NewService(ServiceOpts{
 				Repository: mockRepo,
+				Hasher:     mockHasher,
+				Notifier:   notifier,
 			})
 
 			response, err := svc.UpdateProfile(context.Background(), tc.params)
@@ -147,6 +185,12 @@ func TestUpdateProfile(t *testing.T) {
 				assert.NotNil(t, response)
 				assert.Equal(t, tc.expectedResult.FullName, response.FullName)
 				assert.Equal(t, tc.expectedResult.PhoneNumber, response.PhoneNumber)
+				assert.Equal(t, tc.expectedResult.PendingPhoneNumber, response.PendingPhoneNumber)
+			}
+
+			if tc.name == "Phone Change Pending Verification" {
+				assert.Len(t, notifier.Messages(), 1)
+				assert.Equal(t, tc.params.PhoneNumber, notifier.Messages()[0].PhoneNumber)
 			}
 		})
 	}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/repository"
+)
+
+// sendVerificationCode generates a new one-time code for the given purpose,
+// stores its hash and delivers the plain code to the phone number. It reports
+// false without sending anything once the hourly limit has been reached.
+func (s *Service) sendVerificationCode(ctx context.Context, userID int64, purpose, phoneNumber, messageFormat string) (bool, error) {
+	now := time.Now()
+
+	count, err := s.Repository.CountVerificationCodesSince(ctx, userID, purpose, now.Add(-time.Hour))
+	if err != nil {
+		return false, err
+	}
+
+	if count >= verificationCodeMaxPerHour {
+		return false, nil
+	}
+
+	code, err := helper.GenerateNumericCode(verificationCodeDigits)
+	if err != nil {
+		return false, err
+	}
+
+	codeHash, err := s.Hasher.HashPassword(code)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = s.Repository.InsertVerificationCode(ctx, &repository.VerificationCode{
+		UserID:    userID,
+		Purpose:   purpose,
+		Target:    phoneNumber,
+		CodeHash:  codeHash,
+		ExpiresAt: now.Add(verificationCodeDuration),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = s.Notifier.Send(ctx, phoneNumber, fmt.Sprintf(messageFormat, code))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkVerificationCode matches the code against the latest one issued to
+// the user for the given purpose. Only the latest code is accepted, only if
+// it was sent to the expected phone number, and every guess counts towards
+// its attempt limit.
+func (s *Service) checkVerificationCode(ctx context.Context, userID int64, purpose, target, code string) (*repository.VerificationCode, common.Error) {
+
+	latest, err := s.Repository.GetLatestVerificationCode(ctx, userID, purpose)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if latest == nil || latest.Target != target || latest.ConsumedAt != nil || time.Now().After(latest.ExpiresAt) {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidVerificationCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidVerificationCodeErrorCode)
+	}
+
+	// The attempt is spent before the code is compared, so concurrent
+	// guesses cannot all pass the limit before any of them is counted.
+	spent, err := s.Repository.SpendVerificationCodeAttempt(ctx, latest.ID, verificationCodeMaxAttempts)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !spent {
+		return nil, errors.NewErrorWithCode(
+			errors.TooManyVerificationAttemptsErrorMessage,
+			errors.TooManyRequestsErrorType,
+			errors.TooManyVerificationAttemptsErrorCode)
+	}
+
+	err = s.Hasher.CompareHashAndPassword([]byte(latest.CodeHash), []byte(code))
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidVerificationCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidVerificationCodeErrorCode)
+	}
+
+	return latest, nil
+}
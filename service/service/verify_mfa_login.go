@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/totp"
+	"github.com/sawitpro/UserService/service"
+)
+
+// VerifyMFALogin completes a login started by Login for a user with
+// two-factor authentication, using either a TOTP code or a recovery code.
+// The challenge token is single-use: a wrong code means logging in again.
+// Wrong codes count as failed logins, so the lockout also limits guessing
+// the second factor.
+func (s *Service) VerifyMFALogin(ctx context.Context, params service.VerifyMFALoginParam) (*service.LoginResponse, common.Error) {
+
+	claims, err := helper.ValidateMFAToken(params.MFAToken)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidMFATokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidMFATokenErrorCode)
+	}
+
+	// Consuming is atomic, so of concurrent requests with the same token
+	// only one gets to try a code.
+	consumed, err := s.RevocationStore.ConsumeToken(ctx, claims.Id, claims.UserID, time.Unix(claims.ExpiresAt, 0))
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !consumed {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidMFATokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidMFATokenErrorCode)
+	}
+
+	user, err := s.Repository.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidMFATokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidMFATokenErrorCode)
+	}
+
+	errSvc := s.checkLoginAllowed(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	userTOTP, err := s.Repository.GetUserTOTP(ctx, claims.UserID)
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if userTOTP == nil || userTOTP.ConfirmedAt == nil {
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidMFATokenErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidMFATokenErrorCode)
+	}
+
+	var verified bool
+	if len(params.Code) == totp.Digits {
+		step, ok := totp.Validate(userTOTP.Secret, params.Code, time.Now())
+		if ok {
+			verified, err = s.Repository.UpdateTOTPLastUsedStep(ctx, claims.UserID, step)
+		}
+	} else {
+		verified, err = s.useRecoveryCode(ctx, claims.UserID, params.Code)
+	}
+
+	if err != nil {
+		return nil, errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !verified {
+		err = s.recordFailedLogin(ctx, user.ID)
+		if err != nil {
+			return nil, errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		return nil, errors.NewErrorWithCode(
+			errors.InvalidMFACodeErrorMessage,
+			errors.UnauthorizedErrorType,
+			errors.InvalidMFACodeErrorCode)
+	}
+
+	errSvc = s.clearLoginLockout(ctx, user)
+	if errSvc != nil {
+		return nil, errSvc
+	}
+
+	return s.completeLogin(ctx, user.ID)
+}
+
+// useRecoveryCode looks for an unused recovery code matching the given one
+// and marks it as used.
+func (s *Service) useRecoveryCode(ctx context.Context, userID int64, code string) (bool, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	codes, err := s.Repository.GetUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, recoveryCode := range codes {
+		if s.Hasher.CompareHashAndPassword([]byte(recoveryCode.CodeHash), []byte(code)) != nil {
+			continue
+		}
+
+		used, err := s.Repository.UseRecoveryCode(ctx, recoveryCode.ID)
+		if err != nil || !used {
+			return false, err
+		}
+
+		return true, s.Repository.InsertUserActivityLog(ctx, userID, common.RECOVERY_CODE_USED_ACTIVITY)
+	}
+
+	return false, nil
+}
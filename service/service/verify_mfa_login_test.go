@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper"
+	"github.com/sawitpro/UserService/helper/totp"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestVerifyMFALogin(t *testing.T) {
+	now := time.Now()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	code, err := totp.Code(secret, now)
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	mfaToken, err := helper.CreateMFAToken(1, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create mfa token: %v", err)
+	}
+	accessToken, err := helper.CreateToken(1, 1, nil, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create access token: %v", err)
+	}
+	enabled := &repository.UserTOTP{UserID: 1, Secret: secret, ConfirmedAt: &now}
+	lockedUntil := now.Add(time.Minute)
+
+	testCases := []struct {
+		name          string
+		params        service.VerifyMFALoginParam
+		user          *repository.User
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful TOTP Login",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: nil,
+		},
+		{
+			name:          "Successful Recovery Code Login",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: " K7M2P-XQ9RT "},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: nil,
+		},
+		{
+			name:          "Access Token Instead Of MFA Token",
+			params:        service.VerifyMFALoginParam{MFAToken: accessToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.InvalidMFATokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "MFA Token Already Used",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.InvalidMFATokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "TOTP Code Replayed",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.InvalidMFACodeErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Wrong TOTP Code",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: "abcdef"},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.InvalidMFACodeErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Wrong Recovery Code",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: "aaaaa-bbbbb"},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.InvalidMFACodeErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "TOTP Not Enabled",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError(commonErr.InvalidMFATokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Successful Login Clears Failed Logins",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, FailedLoginCount: 3},
+			expectedError: nil,
+		},
+		{
+			name:          "Wrong Code Locks Account",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: "abcdef"},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, FailedLoginCount: 4},
+			expectedError: commonErr.NewError(commonErr.InvalidMFACodeErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Record Failed Login Error",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: "abcdef"},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("record error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Account Locked",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS, FailedLoginCount: 5, LockedUntil: &lockedUntil},
+			expectedError: commonErr.NewError(commonErr.AccountLockedErrorMessage, commonErr.LockedErrorType),
+		},
+		{
+			name:          "User Not Found",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			expectedError: commonErr.NewError(commonErr.InvalidMFATokenErrorMessage, commonErr.UnauthorizedErrorType),
+		},
+		{
+			name:          "Error DB - Get User By ID",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "Revocation Store Error",
+			params:        service.VerifyMFALoginParam{MFAToken: mfaToken, Code: code},
+			user:          &repository.User{ID: 1, Status: common.ACTIVE_STATUS},
+			expectedError: commonErr.NewError("store error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			mockStore := mocks.NewMockStore(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			expectToken := func() {
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+			}
+			expectUser := func() {
+				expectToken()
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(tc.user, nil)
+			}
+			expectFailedLogin := func(failedCount int64) {
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.LOGIN_FAILED_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementFailedLoginCount(gomock.Any(), int64(1)).Return(failedCount, nil)
+			}
+			expectLogin := func() {
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.LOGIN_ACTIVITY).Return(nil)
+				mockRepo.EXPECT().IncrementLoginCount(gomock.Any(), int64(1)).Return(nil)
+				mockRepo.EXPECT().InsertUserSession(gomock.Any(), gomock.Any()).Return(&repository.UserSession{ID: 3}, nil)
+				mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(&repository.RefreshToken{ID: 1}, nil)
+				mockRepo.EXPECT().GetUserAccess(gomock.Any(), int64(1)).Return(&repository.UserAccess{}, nil)
+			}
+
+			switch tc.name {
+			case "Successful TOTP Login":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				mockRepo.EXPECT().UpdateTOTPLastUsedStep(gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+				expectLogin()
+			case "Successful Recovery Code Login":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				mockRepo.EXPECT().GetUnusedRecoveryCodes(gomock.Any(), int64(1)).Return([]*repository.RecoveryCode{
+					{ID: 1, UserID: 1, CodeHash: "hash 1"},
+					{ID: 2, UserID: 1, CodeHash: "hash 2"},
+				}, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte("hash 1"), []byte("k7m2p-xq9rt")).Return(errors.New("mismatch"))
+				mockHasher.EXPECT().CompareHashAndPassword([]byte("hash 2"), []byte("k7m2p-xq9rt")).Return(nil)
+				mockRepo.EXPECT().UseRecoveryCode(gomock.Any(), int64(2)).Return(true, nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.RECOVERY_CODE_USED_ACTIVITY).Return(nil)
+				expectLogin()
+			case "Access Token Instead Of MFA Token":
+			case "MFA Token Already Used":
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+			case "TOTP Code Replayed":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				mockRepo.EXPECT().UpdateTOTPLastUsedStep(gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+				expectFailedLogin(1)
+			case "Wrong TOTP Code":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				expectFailedLogin(1)
+			case "Wrong Recovery Code":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				mockRepo.EXPECT().GetUnusedRecoveryCodes(gomock.Any(), int64(1)).Return([]*repository.RecoveryCode{
+					{ID: 1, UserID: 1, CodeHash: "hash 1"},
+				}, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(errors.New("mismatch"))
+				expectFailedLogin(1)
+			case "TOTP Not Enabled":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(nil, nil)
+			case "Successful Login Clears Failed Logins":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				mockRepo.EXPECT().UpdateTOTPLastUsedStep(gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().ClearLoginLockout(gomock.Any(), int64(1)).Return(nil)
+				expectLogin()
+			case "Wrong Code Locks Account":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				expectFailedLogin(5)
+				mockRepo.EXPECT().LockUser(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int64, lockedUntil time.Time) error {
+					assert.WithinDuration(t, time.Now().Add(time.Minute), lockedUntil, time.Second)
+					return nil
+				})
+			case "Record Failed Login Error":
+				expectUser()
+				mockRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(1)).Return(enabled, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), int64(1), common.LOGIN_FAILED_ACTIVITY).Return(errors.New("record error"))
+			case "Account Locked":
+				expectUser()
+			case "User Not Found":
+				expectUser()
+			case "Error DB - Get User By ID":
+				expectToken()
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(nil, errors.New("some error"))
+			case "Revocation Store Error":
+				mockStore.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(false, errors.New("store error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository:      mockRepo,
+				Hasher:          mockHasher,
+				RevocationStore: mockStore,
+				Lockout:         DefaultLockoutPolicy(),
+			})
+
+			response, err := svc.VerifyMFALogin(context.Background(), tc.params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+				assert.Equal(t, int64(1), response.UserID)
+				assert.NotEmpty(t, response.Token)
+				assert.NotEmpty(t, response.RefreshToken)
+			}
+		})
+	}
+}
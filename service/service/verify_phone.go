@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sawitpro/UserService/common"
+	"github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+const phoneVerificationMessageFormat = "Your phone verification code is %s. It expires in 10 minutes. Do not share it with anyone."
+
+// VerifyPhone confirms the phone number the latest verification code was
+// sent to. A pending phone change is applied to the user at this point.
+func (s *Service) VerifyPhone(ctx context.Context, params service.VerifyPhoneParam) common.Error {
+
+	user, err := s.Repository.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.BadRequestErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	target, ok := phoneVerificationTarget(user)
+	if !ok {
+		return errors.NewErrorWithCode(
+			errors.PhoneAlreadyVerifiedErrorMessage,
+			errors.BadRequestErrorType,
+			errors.PhoneAlreadyVerifiedErrorCode)
+	}
+
+	code, errSvc := s.checkVerificationCode(ctx, user.ID, common.PHONE_VERIFICATION_PURPOSE, target, params.Code)
+	if errSvc != nil {
+		return errSvc
+	}
+
+	if target != user.Phone {
+		// Someone else may have claimed the number since it was requested.
+		existingUser, err := s.Repository.GetUserByPhone(ctx, target)
+		if err != nil {
+			return errors.NewError(
+				err.Error(),
+				errors.SystemErrorType)
+		}
+
+		if existingUser != nil {
+			return errors.NewErrorWithCode(
+				errors.NewPhoneAlreadyUsedErrorMessage(target),
+				errors.ConflictErrorType,
+				errors.PhoneAlreadyUsedErrorCode,
+				target)
+		}
+	}
+
+	consumed := false
+	err = s.Repository.ExecTransaction(ctx, func(ctx context.Context) error {
+		consumed, err = s.Repository.ConsumeVerificationCode(ctx, code.ID)
+		if err != nil || !consumed {
+			return err
+		}
+
+		if target != user.Phone {
+			err = s.Repository.UpdateUser(ctx, user.ID, user.FullName, target)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = s.Repository.MarkPhoneVerified(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.Repository.InsertUserActivityLog(ctx, user.ID, common.PHONE_VERIFIED_ACTIVITY)
+	})
+
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !consumed {
+		return errors.NewErrorWithCode(
+			errors.InvalidVerificationCodeErrorMessage,
+			errors.BadRequestErrorType,
+			errors.InvalidVerificationCodeErrorCode)
+	}
+
+	return nil
+}
+
+func (s *Service) ResendPhoneVerification(ctx context.Context, userID int64) common.Error {
+
+	user, err := s.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if user == nil {
+		return errors.NewErrorWithCode(
+			errors.UserDataNotFoundErrorMessage,
+			errors.BadRequestErrorType,
+			errors.UserDataNotFoundErrorCode)
+	}
+
+	target, ok := phoneVerificationTarget(user)
+	if !ok {
+		return errors.NewErrorWithCode(
+			errors.PhoneAlreadyVerifiedErrorMessage,
+			errors.BadRequestErrorType,
+			errors.PhoneAlreadyVerifiedErrorCode)
+	}
+
+	sent, err := s.sendVerificationCode(ctx, user.ID, common.PHONE_VERIFICATION_PURPOSE, target, phoneVerificationMessageFormat)
+	if err != nil {
+		return errors.NewError(
+			err.Error(),
+			errors.SystemErrorType)
+	}
+
+	if !sent {
+		return errors.NewErrorWithCode(
+			errors.TooManyVerificationCodesErrorMessage,
+			errors.TooManyRequestsErrorType,
+			errors.TooManyVerificationCodesErrorCode)
+	}
+
+	return nil
+}
+
+// phoneVerificationTarget returns the phone number that still needs to be
+// verified: the pending one if a change is in progress, otherwise the
+// current one if it has never been verified.
+func phoneVerificationTarget(user *repository.User) (string, bool) {
+	if user.PendingPhone != nil {
+		return *user.PendingPhone, true
+	}
+
+	if user.PhoneVerifiedAt == nil {
+		return user.Phone, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sawitpro/UserService/common"
+	commonErr "github.com/sawitpro/UserService/common/errors"
+	"github.com/sawitpro/UserService/helper/notifier/memory"
+	"github.com/sawitpro/UserService/mocks"
+	"github.com/sawitpro/UserService/repository"
+	"github.com/sawitpro/UserService/service"
+)
+
+func TestVerifyPhone(t *testing.T) {
+	now := time.Now()
+	params := service.VerifyPhoneParam{UserID: 1, Code: "123456"}
+	pendingPhone := "+62811111111"
+	unverifiedUser := &repository.User{ID: 1, FullName: "maulana aji", Phone: "+628232482440"}
+	verifiedUser := &repository.User{ID: 1, FullName: "maulana aji", Phone: "+628232482440", PhoneVerifiedAt: &now}
+	changingUser := &repository.User{ID: 1, FullName: "maulana aji", Phone: "+628232482440", PhoneVerifiedAt: &now, PendingPhone: &pendingPhone}
+	code := &repository.VerificationCode{
+		ID:        10,
+		UserID:    1,
+		Purpose:   common.PHONE_VERIFICATION_PURPOSE,
+		Target:    "+628232482440",
+		CodeHash:  "code hash",
+		ExpiresAt: now.Add(time.Minute),
+	}
+	pendingCode := *code
+	pendingCode.Target = pendingPhone
+
+	testCases := []struct {
+		name          string
+		expectedError common.Error
+	}{
+		{
+			name:          "Successful Verify Current Phone",
+			expectedError: nil,
+		},
+		{
+			name:          "Successful Verify Pending Phone",
+			expectedError: nil,
+		},
+		{
+			name:          "Error DB - Get User By ID",
+			expectedError: commonErr.NewError("some error", commonErr.SystemErrorType),
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Already Verified",
+			expectedError: commonErr.NewError(commonErr.PhoneAlreadyVerifiedErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Code Sent To Previous Number",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Pending Phone Taken",
+			expectedError: commonErr.NewError(commonErr.NewPhoneAlreadyUsedErrorMessage(pendingPhone), commonErr.ConflictErrorType),
+		},
+		{
+			name:          "Code Consumed Concurrently",
+			expectedError: commonErr.NewError(commonErr.InvalidVerificationCodeErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Update User Error",
+			expectedError: commonErr.NewError("update error", commonErr.SystemErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			execTransaction := func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+
+			switch tc.name {
+			case "Successful Verify Current Phone":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(unverifiedUser, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), params.UserID, common.PHONE_VERIFICATION_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword([]byte(code.CodeHash), []byte(params.Code)).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(true, nil)
+				mockRepo.EXPECT().MarkPhoneVerified(gomock.Any(), params.UserID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.PHONE_VERIFIED_ACTIVITY).Return(nil)
+			case "Successful Verify Pending Phone":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(changingUser, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), params.UserID, common.PHONE_VERIFICATION_PURPOSE).Return(&pendingCode, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), pendingCode.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), pendingPhone).Return(nil, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), pendingCode.ID).Return(true, nil)
+				mockRepo.EXPECT().UpdateUser(gomock.Any(), params.UserID, changingUser.FullName, pendingPhone).Return(nil)
+				mockRepo.EXPECT().MarkPhoneVerified(gomock.Any(), params.UserID).Return(nil)
+				mockRepo.EXPECT().InsertUserActivityLog(gomock.Any(), params.UserID, common.PHONE_VERIFIED_ACTIVITY).Return(nil)
+			case "Error DB - Get User By ID":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(nil, errors.New("some error"))
+			case "User Not Found":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(nil, nil)
+			case "Already Verified":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(verifiedUser, nil)
+			case "Code Sent To Previous Number":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(changingUser, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), params.UserID, common.PHONE_VERIFICATION_PURPOSE).Return(code, nil)
+			case "Pending Phone Taken":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(changingUser, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), params.UserID, common.PHONE_VERIFICATION_PURPOSE).Return(&pendingCode, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), pendingCode.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), pendingPhone).Return(&repository.User{ID: 2}, nil)
+			case "Code Consumed Concurrently":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(unverifiedUser, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), params.UserID, common.PHONE_VERIFICATION_PURPOSE).Return(code, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), code.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), code.ID).Return(false, nil)
+			case "Update User Error":
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), params.UserID).Return(changingUser, nil)
+				mockRepo.EXPECT().GetLatestVerificationCode(gomock.Any(), params.UserID, common.PHONE_VERIFICATION_PURPOSE).Return(&pendingCode, nil)
+				mockRepo.EXPECT().SpendVerificationCodeAttempt(gomock.Any(), pendingCode.ID, verificationCodeMaxAttempts).Return(true, nil)
+				mockHasher.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetUserByPhone(gomock.Any(), pendingPhone).Return(nil, nil)
+				mockRepo.EXPECT().ExecTransaction(gomock.Any(), gomock.Any()).DoAndReturn(execTransaction)
+				mockRepo.EXPECT().ConsumeVerificationCode(gomock.Any(), pendingCode.ID).Return(true, nil)
+				mockRepo.EXPECT().UpdateUser(gomock.Any(), params.UserID, changingUser.FullName, pendingPhone).Return(errors.New("update error"))
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+				Hasher:     mockHasher,
+			})
+
+			err := svc.VerifyPhone(context.Background(), params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestResendPhoneVerification(t *testing.T) {
+	now := time.Now()
+	pendingPhone := "+62811111111"
+	unverifiedUser := &repository.User{ID: 1, Phone: "+628232482440"}
+	verifiedUser := &repository.User{ID: 1, Phone: "+628232482440", PhoneVerifiedAt: &now}
+	changingUser := &repository.User{ID: 1, Phone: "+628232482440", PhoneVerifiedAt: &now, PendingPhone: &pendingPhone}
+
+	testCases := []struct {
+		name           string
+		user           *repository.User
+		expectedTarget string
+		expectedError  common.Error
+	}{
+		{
+			name:           "Successful Resend Current Phone",
+			user:           unverifiedUser,
+			expectedTarget: unverifiedUser.Phone,
+			expectedError:  nil,
+		},
+		{
+			name:           "Successful Resend Pending Phone",
+			user:           changingUser,
+			expectedTarget: pendingPhone,
+			expectedError:  nil,
+		},
+		{
+			name:          "Already Verified",
+			user:          verifiedUser,
+			expectedError: commonErr.NewError(commonErr.PhoneAlreadyVerifiedErrorMessage, commonErr.BadRequestErrorType),
+		},
+		{
+			name:          "Rate Limited",
+			user:          unverifiedUser,
+			expectedError: commonErr.NewError(commonErr.TooManyVerificationCodesErrorMessage, commonErr.TooManyRequestsErrorType),
+		},
+		{
+			name:          "User Not Found",
+			expectedError: commonErr.NewError(commonErr.UserDataNotFoundErrorMessage, commonErr.BadRequestErrorType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepositoryInterface(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			notifier := memory.NewNotifier()
+
+			mockRepo.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(tc.user, nil)
+
+			switch tc.name {
+			case "Successful Resend Current Phone", "Successful Resend Pending Phone":
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), int64(1), common.PHONE_VERIFICATION_PURPOSE, gomock.Any()).Return(int64(0), nil)
+				mockHasher.EXPECT().HashPassword(gomock.Any()).Return("code hash", nil)
+				mockRepo.EXPECT().InsertVerificationCode(gomock.Any(), gomock.Any()).Return(&repository.VerificationCode{ID: 1}, nil)
+			case "Rate Limited":
+				mockRepo.EXPECT().CountVerificationCodesSince(gomock.Any(), int64(1), common.PHONE_VERIFICATION_PURPOSE, gomock.Any()).Return(int64(verificationCodeMaxPerHour), nil)
+			}
+
+			svc := NewService(ServiceOpts{
+				Repository: mockRepo,
+				Hasher:     mockHasher,
+				Notifier:   notifier,
+			})
+
+			err := svc.ResendPhoneVerification(context.Background(), 1)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.GetErrorMessage(), err.GetErrorMessage())
+				assert.Equal(t, tc.expectedError.GetErrorType(), err.GetErrorType())
+				assert.Empty(t, notifier.Messages())
+			} else {
+				assert.Nil(t, err)
+				assert.Len(t, notifier.Messages(), 1)
+				assert.Equal(t, tc.expectedTarget, notifier.Messages()[0].PhoneNumber)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package service
 
+import "time"
+
 type RegisterParam struct {
 	FullName    string
 	PhoneNumber string
@@ -15,23 +17,305 @@ type LoginParam struct {
 	Password    string
 }
 
+// LoginResponse carries either a token pair, or, when MFARequired is set,
+// only the challenge token to complete the login with a second factor.
 type LoginResponse struct {
+	UserID       int64
+	Token        string
+	RefreshToken string
+	MFARequired  bool
+	MFAToken     string
+}
+
+type VerifyMFALoginParam struct {
+	MFAToken string
+	Code     string
+}
+
+type SetupTOTPResponse struct {
+	Secret string
+	URI    string
+}
+
+type ConfirmTOTPParam struct {
 	UserID int64
-	Token  string
+	Code   string
 }
 
-type UserInfoResponse struct {
-	FullName    string
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string
+}
+
+type RequestLoginCodeParam struct {
+	PhoneNumber string
+}
+
+type VerifyLoginCodeParam struct {
+	PhoneNumber string
+	Code        string
+}
+
+// BeginPasskeyRegistrationResponse holds the options the client passes to
+// navigator.credentials.create, and the ceremony token that carries the
+// challenge to FinishPasskeyRegistration.
+type BeginPasskeyRegistrationResponse struct {
+	CeremonyToken      string
+	Challenge          string
+	RelyingPartyID     string
+	RelyingPartyName   string
+	UserHandle         []byte
+	UserName           string
+	UserDisplayName    string
+	Algorithms         []int64
+	ExcludeCredentials []PasskeyDescriptor
+	Timeout            time.Duration
+}
+
+// PasskeyDescriptor identifies a registered passkey to the client.
+type PasskeyDescriptor struct {
+	CredentialID []byte
+	Transports   []string
+}
+
+type FinishPasskeyRegistrationParam struct {
+	UserID            int64
+	CeremonyToken     string
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AttestationObject []byte
+	Transports        []string
+}
+
+// BeginPasskeyLoginResponse holds the options the client passes to
+// navigator.credentials.get, and the ceremony token that carries the
+// challenge to FinishPasskeyLogin.
+type BeginPasskeyLoginResponse struct {
+	CeremonyToken  string
+	Challenge      string
+	RelyingPartyID string
+	Timeout        time.Duration
+}
+
+type FinishPasskeyLoginParam struct {
+	CeremonyToken     string
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
+
+type RefreshTokenParam struct {
+	RefreshToken string
+}
+
+type LogoutParam struct {
+	UserID         int64
+	SessionID      int64
+	TokenID        string
+	TokenExpiresAt time.Time
+	RefreshToken   string
+}
+
+type ChangePasswordParam struct {
+	UserID          int64
+	CurrentPassword string
+	NewPassword     string
+}
+
+type ForgotPasswordParam struct {
 	PhoneNumber string
 }
 
+type ResetPasswordParam struct {
+	PhoneNumber string
+	Code        string
+	NewPassword string
+}
+
+type VerifyPhoneParam struct {
+	UserID int64
+	Code   string
+}
+
+type UserInfoResponse struct {
+	FullName           string
+	PhoneNumber        string
+	PhoneVerified      bool
+	PendingPhoneNumber string
+}
+
 type UpdateProfileParam struct {
 	FullName    string
 	PhoneNumber string
 	UserID      int64
 }
 
+type ListUsersParam struct {
+	PhonePrefix string
+	Name        string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Cursor      string
+	Limit       int
+}
+
+// ListUsersResponse holds one page of users, newest first. NextCursor is
+// empty on the last page.
+type ListUsersResponse struct {
+	Users      []AdminUser
+	NextCursor string
+}
+
+// AdminUser is the view of an account shown to support staff.
+type AdminUser struct {
+	ID                  int64
+	FullName            string
+	PhoneNumber         string
+	PhoneVerified       bool
+	LoginCount          int64
+	FailedLoginCount    int64
+	LockedUntil         *time.Time
+	Status              string
+	StatusReason        *string
+	StatusChangedAt     *time.Time
+	DeletionScheduledAt *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type ListActivitiesParam struct {
+	UserID       int64
+	ActivityType string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	Cursor       string
+	Limit        int
+}
+
+// ListActivitiesResponse holds one page of a user's activities, newest
+// first. NextCursor is empty on the last page.
+type ListActivitiesResponse struct {
+	Activities []UserActivity
+	NextCursor string
+}
+
+type UserActivity struct {
+	ActivityType string
+	IPAddress    string
+	UserAgent    string
+	DeviceFamily string
+	OSFamily     string
+	RequestID    string
+	CreatedAt    time.Time
+}
+
+type UserDetailResponse struct {
+	User             AdminUser
+	Roles            []string
+	Permissions      []string
+	RecentActivities []UserActivity
+}
+
+type SuspendUserParam struct {
+	UserID int64
+	Reason string
+}
+
+type DeactivateAccountParam struct {
+	UserID   int64
+	Password string
+	Reason   string
+}
+
+type DeleteProfileParam struct {
+	UserID   int64
+	Password string
+}
+
+type DeleteProfileResponse struct {
+	DeleteAfter time.Time
+}
+
+type ExportUserDataParam struct {
+	UserID int64
+	Writer UserDataWriter
+}
+
+// UserDataProfile is the account part of a personal data export. It leaves
+// out the password hash and the second factor secrets.
+type UserDataProfile struct {
+	ID                  int64
+	FullName            string
+	PhoneNumber         string
+	PhoneVerifiedAt     *time.Time
+	PendingPhoneNumber  *string
+	LoginCount          int64
+	FailedLoginCount    int64
+	LockedUntil         *time.Time
+	Status              string
+	StatusReason        *string
+	StatusChangedAt     *time.Time
+	DeletionScheduledAt *time.Time
+	Roles               []string
+	TwoFactorEnabledAt  *time.Time
+	RecoveryCodesLeft   int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// UserSession is one login of the user, on the device named by DeviceName.
+// Current marks the session of the caller when listing sessions.
+type UserSession struct {
+	ID         int64
+	DeviceName string
+	IPAddress  string
+	UserAgent  string
+	Current    bool
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type ListSessionsParam struct {
+	UserID           int64
+	CurrentSessionID int64
+}
+
+type ListSessionsResponse struct {
+	Sessions []UserSession
+}
+
+type RevokeSessionParam struct {
+	UserID    int64
+	SessionID int64
+}
+
+type UserVerificationCode struct {
+	Purpose    string
+	Target     string
+	Attempts   int64
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// UserPasskey is a passkey of the user in a personal data export. It leaves
+// out the public key and the signature counter.
+type UserPasskey struct {
+	CredentialID []byte
+	Transports   []string
+	LastUsedAt   *time.Time
+	CreatedAt    time.Time
+}
+
+type UserRoleParam struct {
+	UserID int64
+	Role   string
+}
+
 type UpdateProfileResponse struct {
-	FullName    string
-	PhoneNumber string
+	FullName           string
+	PhoneNumber        string
+	PendingPhoneNumber string
 }